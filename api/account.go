@@ -1,15 +1,12 @@
 package api
 
 import (
-	"database/sql"
 	"errors"
-	"log"
 	"net/http"
 
 	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/anil1226/go-simplebank-grpc/token"
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 type createAccountRequest struct {
@@ -31,10 +28,10 @@ func (s *Server) createAccount(ctx *gin.Context) {
 		Currency: req.Currency,
 	}
 
-	acc, err := s.store.CreateAccount(ctx, arg)
+	acc, err := s.store.CreateAccountTx(ctx, arg)
 	if err != nil {
-		if pq, ok := err.(*pq.Error); ok {
-			log.Println(pq.Code.Name())
+		errCode := store.ErrorCode(err)
+		if errCode == store.ForeignKeyViolation || errCode == store.UniqueViolation {
 			ctx.JSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
@@ -57,7 +54,7 @@ func (s *Server) getAccount(ctx *gin.Context) {
 
 	acc, err := s.store.GetAccount(ctx, req.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == store.ErrRecordNotFound {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -31,7 +31,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "200",
 			accountID: acc.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, user.Role, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -47,13 +47,13 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "404",
 			accountID: acc.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, user.Role, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(acc.ID)).
 					Times(1).
-					Return(sqlstore.Account{}, sql.ErrNoRows)
+					Return(sqlstore.Account{}, sqlstore.ErrRecordNotFound)
 			},
 			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
@@ -63,7 +63,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "500",
 			accountID: acc.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, user.Role, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -79,7 +79,7 @@ func TestGetAccountAPI(t *testing.T) {
 			name:      "400",
 			accountID: 0,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationType, user.Username, user.Role, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
@@ -138,6 +138,7 @@ func randomUser(t *testing.T) (user sqlstore.User, password string) {
 		HashedPassword: hashedPassword,
 		FullName:       util.RandomOwner(),
 		Email:          util.RandomEmail(),
+		Role:           util.DepositorRole,
 	}
 	return
 }
@@ -148,9 +149,10 @@ func addAuthorization(
 	tokenMaker token.Maker,
 	authorizationType string,
 	username string,
+	role string,
 	duration time.Duration,
 ) {
-	token, _, err := tokenMaker.CreateToken(username, duration)
+	token, _, err := tokenMaker.CreateToken(username, role, duration)
 	require.NoError(t, err)
 
 	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, token)
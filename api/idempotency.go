@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/token"
+	"github.com/gin-gonic/gin"
+)
+
+const idempotencyKeyHeaderKey = "Idempotency-Key"
+
+var errResponseNotStored = errors.New("response not stored")
+
+// idempotencyWriter holds the response back until the idempotency key is committed.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func idempotencyMiddleware(s store.Store, ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeaderKey)
+		if len(key) == 0 {
+			ctx.Next()
+			return
+		}
+
+		reqBody, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(reqBody))
+
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Request.Method + " " + ctx.FullPath()))
+		hash.Write(reqBody)
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		writer := ctx.Writer
+		rec := &idempotencyWriter{ResponseWriter: writer}
+		ctx.Writer = rec
+
+		res, err := s.IdempotencyTx(ctx, store.IdempotencyTxParams{
+			Username:    authPayload.Username,
+			Key:         key,
+			RequestHash: hex.EncodeToString(hash.Sum(nil)),
+			ExpiresAt:   time.Now().Add(ttl),
+			Handle: func() (int32, []byte, error) {
+				ctx.Next()
+				// failed requests are not stored so the client can fix and retry them
+				if rec.Status() >= http.StatusMultipleChoices {
+					return 0, nil, errResponseNotStored
+				}
+				return int32(rec.Status()), rec.body.Bytes(), nil
+			},
+		})
+		ctx.Writer = writer
+
+		if err != nil && !errors.Is(err, errResponseNotStored) {
+			if errors.Is(err, store.ErrIdempotencyKeyReused) {
+				ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			if errors.Is(err, store.ErrIdempotencyKeyInProgress) {
+				ctx.AbortWithStatusJSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if res.Replayed {
+			ctx.Data(int(res.ResponseCode), "application/json; charset=utf-8", res.ResponseBody)
+			ctx.Abort()
+			return
+		}
+
+		writer.WriteHeaderNow()
+		writer.Write(rec.body.Bytes())
+	}
+}
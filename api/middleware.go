@@ -5,14 +5,17 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/anil1226/go-simplebank-grpc/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 const (
 	authorizationHeaderKey  = "authorization"
 	authorizationType       = "bearer"
 	authorizationPayloadKey = "authorization_payload"
+	requestIDHeaderKey      = "X-Request-Id"
 )
 
 func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
@@ -44,6 +47,22 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 		ctx.Set(authorizationPayloadKey, payLoad)
+		withAuditActor(ctx, payLoad.Username)
 		ctx.Next()
 	}
 }
+
+// withAuditActor makes the changes of the request appear in the audit log as made by username.
+// Requests without an id get a new one.
+func withAuditActor(ctx *gin.Context, username string) {
+	requestID := ctx.GetHeader(requestIDHeaderKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	ctx.Request = ctx.Request.WithContext(store.WithAuditActor(ctx.Request.Context(), store.AuditActor{
+		Username:  username,
+		ClientIP:  ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+		RequestID: requestID,
+	}))
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+)
+
+// parseMoney converts a request amount into minor units like the grpc api does. Amounts with
+// more decimals than the currency allows are rejected rather than rounded.
+func parseMoney(amount string, currency string) (util.Money, error) {
+	m, err := util.ParseMoney(amount, currency, util.RoundUnnecessary)
+	if err == nil {
+		err = val.ValidateAmount(m.Amount)
+	}
+	if err != nil {
+		return util.Money{}, fmt.Errorf("amount %s: %w", amount, err)
+	}
+	return m, nil
+}
@@ -30,6 +30,8 @@ func NewServer(config util.Config, store store.Store) (*Server, error) {
 		tokenMaker: tokenMaker,
 	}
 	router := gin.Default()
+	// the handlers pass the gin context to the store, the audit actor lives in the request context
+	router.ContextWithFallback = true
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
@@ -40,10 +42,10 @@ func NewServer(config util.Config, store store.Store) (*Server, error) {
 	router.POST("/users/renew", server.renewToken)
 
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
-	authRoutes.POST("/accounts", server.createAccount)
+	authRoutes.POST("/accounts", idempotencyMiddleware(server.store, config.IdempotencyKeyTTL), server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	authRoutes.GET("/accounts", server.listAccount)
-	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.POST("/transfers", idempotencyMiddleware(server.store, config.IdempotencyKeyTTL), server.createTransfer)
 
 	router.StaticFS("/swagger", http.Dir("./doc/swagger"))
 
@@ -60,7 +60,7 @@ func (s *Server) renewToken(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, payload, err := s.tokenMaker.CreateToken(payload.Username, s.config.AccessTokenDuration)
+	accessToken, payload, err := s.tokenMaker.CreateToken(payload.Username, payload.Role, s.config.AccessTokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,13 +8,16 @@ import (
 	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/anil1226/go-simplebank-grpc/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,min=1"`
+	// Amount is a decimal in units of currency, such as "12.50"
+	Amount   string `json:"amount" binding:"required"`
+	Currency string `json:"currency" binding:"required,currency"`
+	QuoteID  string `json:"quote_id" binding:"omitempty,uuid"`
 }
 
 func (s *Server) createTransfer(ctx *gin.Context) {
@@ -24,6 +26,11 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	amount, err := parseMoney(req.Amount, req.Currency)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	fromAccount, ok := s.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !ok {
@@ -37,19 +44,42 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, ok = s.validAccount(ctx, req.ToAccountID, req.Currency)
-	if !ok {
-		return
-	}
-
 	arg := store.TransferTxParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		Amount:        amount.Amount,
+	}
+
+	// a quote allows the destination account to use another currency
+	if len(req.QuoteID) == 0 {
+		toAccount, ok := s.validAccount(ctx, req.ToAccountID, req.Currency)
+		if !ok {
+			return
+		}
+		if store.IsSystemAccount(toAccount) {
+			err := errors.New("cannot transfer to a system account")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	} else {
+		arg.QuoteID = uuid.NullUUID{UUID: uuid.MustParse(req.QuoteID), Valid: true}
 	}
 
 	res, err := s.store.TransferTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, store.ErrQuoteNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, store.ErrQuoteExpired) || errors.Is(err, store.ErrQuoteUsed) || errors.Is(err, store.ErrQuoteMismatch) ||
+			errors.Is(err, store.ErrInsufficientFunds) || errors.Is(err, store.ErrLimitExceeded) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, store.ErrAccountFrozen) || errors.Is(err, store.ErrAccountClosed) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -59,7 +89,7 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 func (s *Server) validAccount(ctx *gin.Context, accid int64, cuurency string) (store.Account, bool) {
 	acc, err := s.store.GetAccount(ctx, accid)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == store.ErrRecordNotFound {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return acc, false
 		}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"log"
 	"net/http"
 	"time"
 
@@ -9,7 +8,6 @@ import (
 	"github.com/anil1226/go-simplebank-grpc/util"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 )
 
 type createUserRequest struct {
@@ -49,8 +47,7 @@ func (s *Server) createUser(ctx *gin.Context) {
 
 	usr, err := s.store.CreateUser(ctx, arg)
 	if err != nil {
-		if pq, ok := err.(*pq.Error); ok {
-			log.Println(pq.Code.Name())
+		if store.ErrorCode(err) == store.UniqueViolation {
 			ctx.JSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
@@ -98,13 +95,13 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, payload, err := s.tokenMaker.CreateToken(req.Username, s.config.AccessTokenDuration)
+	accessToken, payload, err := s.tokenMaker.CreateToken(req.Username, user.Role, s.config.AccessTokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(req.Username, s.config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(req.Username, user.Role, s.config.RefreshTokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
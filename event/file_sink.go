@@ -0,0 +1,103 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// FileSink appends the events to a file, one JSON object per line.
+type FileSink struct {
+	mu         sync.Mutex
+	file       *os.File
+	lastOffset int64
+}
+
+// NewFileSink opens path for appending, the events already in the file are not written again.
+func NewFileSink(path string) (*FileSink, error) {
+	lastOffset, err := readLastOffset(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{
+		file:       file,
+		lastOffset: lastOffset,
+	}, nil
+}
+
+func readLastOffset(path string) (int64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var last []byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+
+	event := &pb.DomainEvent{}
+	if err := protojson.Unmarshal(last, event); err != nil {
+		return 0, err
+	}
+	return event.Offset, nil
+}
+
+func (s *FileSink) Publish(ctx context.Context, events []*pb.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buf bytes.Buffer
+	lastOffset := s.lastOffset
+	for _, e := range events {
+		if e.Offset <= lastOffset {
+			continue
+		}
+		line, err := protojson.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		lastOffset = e.Offset
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	if _, err := s.file.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+	s.lastOffset = lastOffset
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
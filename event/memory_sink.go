@@ -0,0 +1,54 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+)
+
+// MemorySink keeps the events in memory, for tests and local development.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []*pb.DomainEvent
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Publish(ctx context.Context, events []*pb.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range events {
+		if e.Offset <= s.lastOffset() {
+			continue
+		}
+		s.events = append(s.events, e)
+	}
+	return nil
+}
+
+func (s *MemorySink) lastOffset() int64 {
+	if len(s.events) == 0 {
+		return 0
+	}
+	return s.events[len(s.events)-1].Offset
+}
+
+// Events returns the events published after offset.
+func (s *MemorySink) Events(after int64) []*pb.DomainEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []*pb.DomainEvent
+	for _, e := range s.events {
+		if e.Offset > after {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func (s *MemorySink) Close() error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+// RedisSink appends the events to a Redis stream. The id of an entry is its offset, so
+// consumers can read from any offset with XRANGE or XREAD.
+type RedisSink struct {
+	client *redis.Client
+	stream string
+}
+
+func NewRedisSink(client *redis.Client, stream string) *RedisSink {
+	return &RedisSink{
+		client: client,
+		stream: stream,
+	}
+}
+
+func (s *RedisSink) Publish(ctx context.Context, events []*pb.DomainEvent) error {
+	for _, e := range events {
+		payload, err := proto.Marshal(e)
+		if err != nil {
+			return err
+		}
+		err = s.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.stream,
+			ID:     fmt.Sprintf("%d-1", e.Offset),
+			Values: map[string]any{
+				"type":    e.Type,
+				"version": e.Version,
+				"event":   payload,
+			},
+		}).Err()
+		// the stream rejects ids at or below its last one, the event is already there
+		if err != nil && !strings.Contains(err.Error(), "equal or smaller") {
+			return fmt.Errorf("cannot add event %d to stream: %w", e.Offset, err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisSink) Close() error {
+	return s.client.Close()
+}
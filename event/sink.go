@@ -0,0 +1,38 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/redis/go-redis/v9"
+)
+
+// Sink receives the domain events in offset order. Events are delivered at least once, a sink
+// skips the events at or below the offset it already has.
+type Sink interface {
+	Publish(ctx context.Context, events []*pb.DomainEvent) error
+	Close() error
+}
+
+// Kinds of sink for the EVENT_SINK setting.
+const (
+	SinkMemory = "memory"
+	SinkRedis  = "redis"
+	SinkFile   = "file"
+)
+
+// NewSink creates the sink chosen in config.
+func NewSink(config util.Config) (Sink, error) {
+	switch config.EventSink {
+	case "", SinkMemory:
+		return NewMemorySink(), nil
+	case SinkRedis:
+		client := redis.NewClient(&redis.Options{Addr: config.RedisAddress})
+		return NewRedisSink(client, config.EventStream), nil
+	case SinkFile:
+		return NewFileSink(config.EventFile)
+	}
+	return nil, fmt.Errorf("unknown event sink %q", config.EventSink)
+}
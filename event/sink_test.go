@@ -0,0 +1,64 @@
+package event
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvents(from, to int64) []*pb.DomainEvent {
+	var events []*pb.DomainEvent
+	for offset := from; offset <= to; offset++ {
+		events = append(events, &pb.DomainEvent{
+			Offset:  offset,
+			Type:    "UserUpdated",
+			Version: 1,
+			Payload: &pb.DomainEvent_UserUpdated{UserUpdated: &pb.UserUpdated{Username: "user"}},
+		})
+	}
+	return events
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := NewMemorySink()
+	require.NoError(t, sink.Publish(context.Background(), testEvents(1, 3)))
+	// published again after a failed commit
+	require.NoError(t, sink.Publish(context.Background(), testEvents(2, 4)))
+
+	events := sink.Events(0)
+	require.Len(t, events, 4)
+	for i, e := range events {
+		require.Equal(t, int64(i+1), e.Offset)
+	}
+	require.Len(t, sink.Events(3), 1)
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, sink.Publish(context.Background(), testEvents(1, 3)))
+	require.NoError(t, sink.Close())
+
+	// reopening picks up where the file ends
+	sink, err = NewFileSink(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), sink.lastOffset)
+	require.NoError(t, sink.Publish(context.Background(), testEvents(2, 5)))
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 5)
+
+	lastOffset, err := readLastOffset(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), lastOffset)
+}
@@ -0,0 +1,266 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) FreezeAccount(ctx context.Context, in *pb.FreezeAccountRequest) (*pb.FreezeAccountResponse, error) {
+	acc, err := s.setAccountStatus(ctx, "freeze_account", in.AccountId, in.Reason, store.AccountActive, store.AccountFrozen)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FreezeAccountResponse{
+		Account: convertAccount(acc),
+	}, nil
+}
+
+func (s *Server) UnfreezeAccount(ctx context.Context, in *pb.UnfreezeAccountRequest) (*pb.UnfreezeAccountResponse, error) {
+	acc, err := s.setAccountStatus(ctx, "unfreeze_account", in.AccountId, in.Reason, store.AccountFrozen, store.AccountActive)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnfreezeAccountResponse{
+		Account: convertAccount(acc),
+	}, nil
+}
+
+// setAccountStatus is shared by FreezeAccount and UnfreezeAccount, the update only applies to
+// accounts that are still in the from status.
+func (s *Server) setAccountStatus(ctx context.Context, action string, accountID int64, reason string, from string, to string) (store.Account, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return store.Account{}, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateReason(reason); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+	if violations != nil {
+		return store.Account{}, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, accountID)
+	if err != nil {
+		return acc, err
+	}
+	if store.IsSystemAccount(acc) {
+		return acc, status.Error(codes.InvalidArgument, "cannot change the status of a system account")
+	}
+
+	updated, err := s.updateAccount(ctx, action, acc.ID, func(q *store.Queries) (store.Account, error) {
+		return q.UpdateAccountStatus(ctx, store.UpdateAccountStatusParams{
+			ID:           acc.ID,
+			FromStatus:   from,
+			Status:       to,
+			StatusReason: reason,
+		})
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return acc, status.Errorf(codes.FailedPrecondition, "account is %s", acc.Status)
+		}
+		return acc, status.Error(codes.Internal, err.Error())
+	}
+	return updated, nil
+}
+
+func (s *Server) CloseAccount(ctx context.Context, in *pb.CloseAccountRequest) (*pb.CloseAccountResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateCloseAccountRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	acc, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Role == util.DepositorRole && acc.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	if store.IsSystemAccount(acc) {
+		return nil, status.Error(codes.InvalidArgument, "cannot close a system account")
+	}
+
+	arg := store.CloseAccountTxParams{
+		AccountID: acc.ID,
+		Reason:    in.Reason,
+	}
+	if in.SweepAccountId != nil {
+		sweep, err := s.getAccount(ctx, *in.SweepAccountId)
+		if err != nil {
+			return nil, err
+		}
+		if store.IsSystemAccount(sweep) {
+			return nil, status.Error(codes.InvalidArgument, "cannot sweep to a system account")
+		}
+		if payload.Role == util.DepositorRole && sweep.Owner != acc.Owner {
+			return nil, status.Error(codes.PermissionDenied, "sweep account doesn't belong to the account owner")
+		}
+		if sweep.Currency != acc.Currency {
+			return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+		}
+		arg.SweepAccountID = sweep.ID
+	}
+
+	res, err := s.store.CloseAccountTx(ctx, arg)
+	if err != nil {
+		if isAccountStatusError(err) ||
+			errors.Is(err, store.ErrAccountHasHolds) ||
+			errors.Is(err, store.ErrAccountInDebit) ||
+			errors.Is(err, store.ErrSweepAccountNeeded) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.CloseAccountResponse{
+		Account: convertAccount(res.Account),
+	}
+	if res.Sweep != nil {
+		for _, e := range res.Sweep.Entries {
+			resp.SweepEntries = append(resp.SweepEntries, convertEntry(e, acc.Currency))
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) SetOverdraft(ctx context.Context, in *pb.SetOverdraftRequest) (*pb.SetOverdraftResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	limit, errs := parseLimit("limit", in.Limit)
+	violations = append(violations, errs...)
+	if err := val.ValidateInterestBps(in.InterestBps); err != nil {
+		violations = append(violations, fieldViolation("interest_bps", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(acc) {
+		return nil, status.Error(codes.InvalidArgument, "cannot set an overdraft on a system account")
+	}
+	if acc.Currency != limit.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	// lowering the limit below a current debit balance is allowed, it only blocks further debits
+	updated, err := s.updateAccount(ctx, "set_overdraft", acc.ID, func(q *store.Queries) (store.Account, error) {
+		return q.SetAccountOverdraft(ctx, store.SetAccountOverdraftParams{
+			ID:                   acc.ID,
+			OverdraftLimit:       limit.Amount,
+			OverdraftInterestBps: in.InterestBps,
+		})
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetOverdraftResponse{
+		Account: convertAccount(updated),
+	}, nil
+}
+
+func (s *Server) ListDebitBalances(ctx context.Context, in *pb.ListDebitBalancesRequest) (*pb.ListDebitBalancesResponse, error) {
+	_, err := s.authorizeUser(ctx, []string{util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	// system accounts are negative by design, cash coming in debits the cash account
+	accounts, err := s.store.ListDebitBalances(ctx, store.ListDebitBalancesParams{
+		ExcludedOwners: store.SystemOwners(),
+		Limit:          in.PageSize,
+		Offset:         (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListDebitBalancesResponse{}
+	for _, acc := range accounts {
+		resp.Accounts = append(resp.Accounts, convertAccount(acc))
+	}
+	return resp, nil
+}
+
+// isAccountStatusError tells whether a posting failed because an account isn't active.
+func isAccountStatusError(err error) bool {
+	return errors.Is(err, store.ErrAccountFrozen) || errors.Is(err, store.ErrAccountClosed)
+}
+
+func convertAccount(a store.Account) *pb.Account {
+	acc := &pb.Account{
+		Id:                   a.ID,
+		Owner:                a.Owner,
+		LedgerBalance:        convertMoney(a.Balance, a.Currency),
+		AvailableBalance:     convertMoney(a.AvailableBalance, a.Currency),
+		Status:               a.Status,
+		StatusReason:         a.StatusReason,
+		CreatedAt:            timestamppb.New(a.CreatedAt),
+		OverdraftLimit:       convertMoney(a.OverdraftLimit, a.Currency),
+		OverdraftInterestBps: a.OverdraftInterestBps,
+		AccountType:          a.AccountType,
+	}
+	if a.ClosedAt.Valid {
+		acc.ClosedAt = timestamppb.New(a.ClosedAt.Time)
+	}
+	if a.InterestProductID.Valid {
+		acc.InterestProductId = &a.InterestProductID.Int64
+	}
+	return acc
+}
+
+func validateCloseAccountRequest(in *pb.CloseAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if in.SweepAccountId != nil {
+		if err := val.ValidateID(*in.SweepAccountId); err != nil {
+			violations = append(violations, fieldViolation("sweep_account_id", err))
+		} else if *in.SweepAccountId == in.AccountId {
+			violations = append(violations, fieldViolation("sweep_account_id", fmt.Errorf("must differ from account_id")))
+		}
+	}
+	if err := val.ValidateReason(in.Reason); err != nil {
+		violations = append(violations, fieldViolation("reason", err))
+	}
+	return
+}
@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// withAuditActor makes the changes done with the returned context appear in the audit log
+// as made by username. Requests without an id get a new one.
+func (s *Server) withAuditActor(ctx context.Context, username string) context.Context {
+	mtdt := s.extractMetaData(ctx)
+	if mtdt.RequestID == "" {
+		mtdt.RequestID = uuid.NewString()
+	}
+	return store.WithAuditActor(ctx, store.AuditActor{
+		Username:  username,
+		ClientIP:  mtdt.ClientIP,
+		UserAgent: mtdt.UserAgent,
+		RequestID: mtdt.RequestID,
+	})
+}
+
+// updateAccount changes an account in an audited transaction. The account is locked first,
+// so the event records exactly what update changed.
+func (s *Server) updateAccount(ctx context.Context, action string, id int64, update func(q *store.Queries) (store.Account, error)) (store.Account, error) {
+	var updated store.Account
+	err := s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		acc, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		updated, err = update(q)
+		return store.AuditRecord{
+			Action:     action,
+			EntityType: "account",
+			EntityID:   id,
+			Before:     acc,
+			After:      updated,
+		}, err
+	})
+	return updated, err
+}
+
+func (s *Server) ListAuditEvents(ctx context.Context, in *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	_, err := s.authorizeUser(ctx, []string{util.AuditorRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	arg, errs := validateListAuditEventsRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	events, err := s.store.ListAuditEvents(ctx, arg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListAuditEventsResponse{}
+	for _, e := range events {
+		event, err := convertAuditEvent(e)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
+
+func convertAuditEvent(e store.AuditEvent) (*pb.AuditEvent, error) {
+	before := &structpb.Value{}
+	if err := protojson.Unmarshal(e.Before, before); err != nil {
+		return nil, err
+	}
+	after := &structpb.Value{}
+	if err := protojson.Unmarshal(e.After, after); err != nil {
+		return nil, err
+	}
+	return &pb.AuditEvent{
+		Id:         e.ID,
+		Actor:      e.Actor,
+		Action:     e.Action,
+		EntityType: e.EntityType,
+		EntityId:   e.EntityID,
+		Before:     before,
+		After:      after,
+		ClientIp:   e.ClientIp,
+		UserAgent:  e.UserAgent,
+		RequestId:  e.RequestID,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}, nil
+}
+
+func validateListAuditEventsRequest(in *pb.ListAuditEventsRequest) (arg store.ListAuditEventsParams, violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validatePage(in.PageId, in.PageSize)
+	arg.Limit = in.PageSize
+	arg.Offset = (in.PageId - 1) * in.PageSize
+
+	filters := []struct {
+		in  *string
+		out *sql.NullString
+	}{
+		{in.Actor, &arg.Actor},
+		{in.Action, &arg.Action},
+		{in.EntityType, &arg.EntityType},
+		{in.EntityId, &arg.EntityID},
+		{in.RequestId, &arg.RequestID},
+	}
+	for _, f := range filters {
+		if f.in != nil {
+			*f.out = sql.NullString{String: *f.in, Valid: true}
+		}
+	}
+
+	if in.CreatedAfter != nil {
+		if err := in.CreatedAfter.CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("created_after", err))
+		}
+		arg.CreatedAfter = sql.NullTime{Time: in.CreatedAfter.AsTime(), Valid: true}
+	}
+	if in.CreatedBefore != nil {
+		if err := in.CreatedBefore.CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("created_before", err))
+		}
+		arg.CreatedBefore = sql.NullTime{Time: in.CreatedBefore.AsTime(), Valid: true}
+	}
+	if in.Actor != nil && *in.Actor != "" {
+		if err := val.ValidateUsername(*in.Actor); err != nil {
+			violations = append(violations, fieldViolation("actor", err))
+		}
+	}
+	return
+}
@@ -3,13 +3,14 @@ package gapi
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/anil1226/go-simplebank-grpc/token"
 	"google.golang.org/grpc/metadata"
 )
 
-func (s *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
+func (s *Server) authenticateUser(ctx context.Context) (*token.Payload, error) {
 	meta, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("missing metadata")
@@ -36,3 +37,14 @@ func (s *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	}
 	return payLoad, nil
 }
+
+func (s *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+	payLoad, err := s.authenticateUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(accessibleRoles, payLoad.Role) {
+		return nil, fmt.Errorf("permission denied")
+	}
+	return payLoad, nil
+}
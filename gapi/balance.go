@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) GetBalanceAsOf(ctx context.Context, in *pb.GetBalanceAsOfRequest) (*pb.GetBalanceAsOfResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if in.AsOf == nil {
+		violations = append(violations, fieldViolation("as_of", fmt.Errorf("is required")))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	// bankers, admins and auditors may look at any account
+	if payload.Role == util.DepositorRole && account.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+
+	balance, err := s.store.GetBalanceAsOf(withReadConsistency(ctx), account.ID, in.AsOf.AsTime())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.GetBalanceAsOfResponse{
+		AccountId: balance.AccountID,
+		AsOf:      timestamppb.New(balance.At),
+		Balance:   convertMoney(balance.Balance, account.Currency),
+	}
+	if balance.SnapshotAt.Valid {
+		resp.SnapshotAt = timestamppb.New(balance.SnapshotAt.Time)
+	}
+	return resp, nil
+}
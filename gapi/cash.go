@@ -0,0 +1,170 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const dateLayout = "2006-01-02"
+
+func (s *Server) Deposit(ctx context.Context, in *pb.DepositRequest) (*pb.DepositResponse, error) {
+	res, err := s.cashTx(ctx, store.JournalDeposit, in.AccountId, in.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DepositResponse{
+		Receipt: convertCashReceipt(res.Receipt),
+		Balance: convertMoney(res.Account.Balance, res.Account.Currency),
+	}, nil
+}
+
+func (s *Server) Withdraw(ctx context.Context, in *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	res, err := s.cashTx(ctx, store.JournalWithdrawal, in.AccountId, in.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.WithdrawResponse{
+		Receipt: convertCashReceipt(res.Receipt),
+		Balance: convertMoney(res.Account.Balance, res.Account.Currency),
+	}, nil
+}
+
+// cashTx is shared by Deposit and Withdraw, only the kind of the receipt differs.
+func (s *Server) cashTx(ctx context.Context, kind string, accountID int64, in *pb.Money) (store.CashTxResult, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.TellerRole})
+	if err != nil {
+		return store.CashTxResult{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	amount, errs := parseMoney("amount", in)
+	violations = append(violations, errs...)
+	if violations != nil {
+		return store.CashTxResult{}, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, accountID)
+	if err != nil {
+		return store.CashTxResult{}, err
+	}
+	if store.IsSystemAccount(acc) {
+		return store.CashTxResult{}, status.Error(codes.InvalidArgument, "cannot book cash to a system account")
+	}
+	if acc.Currency != amount.Currency {
+		return store.CashTxResult{}, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	res, err := s.store.CashTx(ctx, store.CashTxParams{
+		Kind:      kind,
+		AccountID: acc.ID,
+		Amount:    amount.Amount,
+		Teller:    payload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrInsufficientFunds) || isAccountStatusError(err) {
+			return store.CashTxResult{}, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return store.CashTxResult{}, status.Error(codes.Internal, err.Error())
+	}
+	return res, nil
+}
+
+func (s *Server) GetTellerTotals(ctx context.Context, in *pb.GetTellerTotalsRequest) (*pb.GetTellerTotalsResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.TellerRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	day, errs := validateGetTellerTotalsRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	teller := payload.Username
+	if in.Teller != nil && *in.Teller != payload.Username {
+		if payload.Role == util.TellerRole {
+			return nil, status.Error(codes.PermissionDenied, "cannot see the totals of another teller")
+		}
+		teller = *in.Teller
+	}
+
+	rows, err := s.store.ListTellerTotals(ctx, store.ListTellerTotalsParams{
+		Teller:   teller,
+		FromTime: day,
+		ToTime:   day.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// rows come ordered by currency, one per receipt kind
+	resp := &pb.GetTellerTotalsResponse{
+		Teller: teller,
+		Date:   day.Format(dateLayout),
+	}
+	var deposited, withdrawn int64
+	var current *pb.TellerTotal
+	for _, row := range rows {
+		if current == nil || current.Currency != row.Currency {
+			deposited, withdrawn = 0, 0
+			current = &pb.TellerTotal{Currency: row.Currency}
+			resp.Totals = append(resp.Totals, current)
+		}
+		switch row.Kind {
+		case store.JournalDeposit:
+			deposited = row.Total
+			current.DepositCount = row.Count
+		case store.JournalWithdrawal:
+			withdrawn = row.Total
+			current.WithdrawalCount = row.Count
+		}
+		current.Deposited = convertMoney(deposited, row.Currency)
+		current.Withdrawn = convertMoney(withdrawn, row.Currency)
+		current.Net = convertMoney(deposited-withdrawn, row.Currency)
+	}
+	return resp, nil
+}
+
+func convertCashReceipt(r store.CashReceipt) *pb.CashReceipt {
+	return &pb.CashReceipt{
+		Id:        r.ID,
+		Kind:      r.Kind,
+		AccountId: r.AccountID,
+		Amount:    convertMoney(r.Amount, r.Currency),
+		Teller:    r.Teller,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+}
+
+// validateGetTellerTotalsRequest returns the requested day, today in UTC when no date is given.
+func validateGetTellerTotalsRequest(in *pb.GetTellerTotalsRequest) (day time.Time, violations []*errdetails.BadRequest_FieldViolation) {
+	if in.Teller != nil {
+		if err := val.ValidateUsername(*in.Teller); err != nil {
+			violations = append(violations, fieldViolation("teller", err))
+		}
+	}
+	day = time.Now().UTC().Truncate(24 * time.Hour)
+	if in.Date != nil {
+		var err error
+		day, err = time.Parse(dateLayout, *in.Date)
+		if err != nil {
+			violations = append(violations, fieldViolation("date", fmt.Errorf("must be formatted as YYYY-MM-DD")))
+		}
+	}
+	return
+}
@@ -0,0 +1,185 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateCurrency(ctx context.Context, in *pb.CreateCurrencyRequest) (*pb.CreateCurrencyResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateCreateCurrencyRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	var currency store.Currency
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		currency, err = q.CreateCurrency(ctx, store.CreateCurrencyParams{
+			Code:       in.Code,
+			MinorUnits: in.MinorUnits,
+			Symbol:     in.Symbol,
+			Enabled:    in.Enabled,
+		})
+		return store.AuditRecord{
+			Action:     "create_currency",
+			EntityType: "currency",
+			EntityID:   in.Code,
+			After:      currency,
+		}, err
+	})
+	if err != nil {
+		if store.ErrorCode(err) == store.UniqueViolation {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.reloadCurrencies(ctx)
+
+	return &pb.CreateCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}, nil
+}
+
+func (s *Server) UpdateCurrency(ctx context.Context, in *pb.UpdateCurrencyRequest) (*pb.UpdateCurrencyResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateUpdateCurrencyRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	var currency store.Currency
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		before, err := q.GetCurrencyForUpdate(ctx, in.Code)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		if in.MinorUnits != nil && in.GetMinorUnits() != before.MinorUnits {
+			inUse, err := q.CurrencyHasAccounts(ctx, in.Code)
+			if err != nil {
+				return store.AuditRecord{}, err
+			}
+			if inUse {
+				return store.AuditRecord{}, store.ErrCurrencyInUse
+			}
+		}
+		currency, err = q.UpdateCurrency(ctx, store.UpdateCurrencyParams{
+			Code: in.Code,
+			MinorUnits: sql.NullInt32{
+				Int32: in.GetMinorUnits(),
+				Valid: in.MinorUnits != nil,
+			},
+			Symbol: sql.NullString{
+				String: in.GetSymbol(),
+				Valid:  in.Symbol != nil,
+			},
+			Enabled: sql.NullBool{
+				Bool:  in.GetEnabled(),
+				Valid: in.Enabled != nil,
+			},
+		})
+		return store.AuditRecord{
+			Action:     "update_currency",
+			EntityType: "currency",
+			EntityID:   in.Code,
+			Before:     before,
+			After:      currency,
+		}, err
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if err == store.ErrCurrencyInUse {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.reloadCurrencies(ctx)
+
+	return &pb.UpdateCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}, nil
+}
+
+func (s *Server) ListCurrencies(ctx context.Context, in *pb.ListCurrenciesRequest) (*pb.ListCurrenciesResponse, error) {
+	currencies, err := s.store.ListCurrencies(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListCurrenciesResponse{}
+	for _, c := range currencies {
+		if !c.Enabled && !in.IncludeDisabled {
+			continue
+		}
+		resp.Currencies = append(resp.Currencies, convertCurrency(c))
+	}
+	return resp, nil
+}
+
+// reloadCurrencies refreshes the cached registry right away, other instances pick the change up on their next refresh.
+func (s *Server) reloadCurrencies(ctx context.Context) {
+	if err := store.LoadCurrencies(ctx, s.store); err != nil {
+		log.Error().Err(err).Msg("cannot reload currencies")
+	}
+}
+
+func convertCurrency(c store.Currency) *pb.Currency {
+	return &pb.Currency{
+		Code:       c.Code,
+		MinorUnits: c.MinorUnits,
+		Symbol:     c.Symbol,
+		Enabled:    c.Enabled,
+		CreatedAt:  timestamppb.New(c.CreatedAt),
+	}
+}
+
+func validateCreateCurrencyRequest(in *pb.CreateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(in.Code); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+	if err := val.ValidateMinorUnits(in.MinorUnits); err != nil {
+		violations = append(violations, fieldViolation("minor_units", err))
+	}
+	if err := val.ValidateString(in.Symbol, 1, 10); err != nil {
+		violations = append(violations, fieldViolation("symbol", err))
+	}
+	return
+}
+
+func validateUpdateCurrencyRequest(in *pb.UpdateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(in.Code); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+	if in.MinorUnits != nil {
+		if err := val.ValidateMinorUnits(*in.MinorUnits); err != nil {
+			violations = append(violations, fieldViolation("minor_units", err))
+		}
+	}
+	if in.Symbol != nil {
+		if err := val.ValidateString(*in.Symbol, 1, 10); err != nil {
+			violations = append(violations, fieldViolation("symbol", err))
+		}
+	}
+	return
+}
@@ -1,6 +1,10 @@
 package gapi
 
 import (
+	"errors"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,3 +26,28 @@ func invalidArgumentError(errs []*errdetails.BadRequest_FieldViolation) error {
 	}
 	return status.Err()
 }
+
+// limitExceededError reports the limit a transfer hit, the details let clients tell the
+// limit and its reset time apart without parsing the message.
+func limitExceededError(err error) error {
+	var limitErr *store.LimitError
+	if !errors.As(err, &limitErr) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	info := &errdetails.ErrorInfo{
+		Reason: "TRANSFER_LIMIT_EXCEEDED",
+		Domain: "simplebank",
+		Metadata: map[string]string{
+			"limit": limitErr.Limit,
+		},
+	}
+	if !limitErr.ResetsAt.IsZero() {
+		info.Metadata["resets_at"] = limitErr.ResetsAt.Format(time.RFC3339)
+	}
+	statusLimit := status.New(codes.FailedPrecondition, err.Error())
+	status, err := statusLimit.WithDetails(info)
+	if err != nil {
+		return statusLimit.Err()
+	}
+	return status.Err()
+}
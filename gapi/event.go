@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListDomainEvents(ctx context.Context, in *pb.ListDomainEventsRequest) (*pb.ListDomainEventsResponse, error) {
+	_, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateOffset(in.AfterOffset); err != nil {
+		violations = append(violations, fieldViolation("after_offset", err))
+	}
+	if err := val.ValidateBatchSize(in.PageSize); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	events, err := s.store.ListDomainEvents(ctx, store.ListDomainEventsParams{
+		AfterOffset: in.AfterOffset,
+		Limit:       in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListDomainEventsResponse{}
+	for _, e := range events {
+		event, err := store.DecodeDomainEvent(e)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
@@ -0,0 +1,225 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) SetFeeRule(ctx context.Context, in *pb.SetFeeRuleRequest) (*pb.SetFeeRuleResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	arg, errs := validateSetFeeRuleRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	var rule store.FeeRule
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		rec := store.AuditRecord{Action: "set_fee_rule", EntityType: "fee_rule"}
+		before, err := q.GetFeeRuleForUpdate(ctx, store.GetFeeRuleForUpdateParams{
+			Currency:    arg.Currency,
+			AccountType: arg.AccountType,
+		})
+		if err == nil {
+			rec.Before = before
+		} else if err != store.ErrRecordNotFound {
+			return rec, err
+		}
+
+		rule, err = q.SetFeeRule(ctx, arg)
+		rec.EntityID, rec.After = rule.ID, rule
+		return rec, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetFeeRuleResponse{
+		Rule: convertFeeRule(rule),
+	}, nil
+}
+
+func (s *Server) ListFeeRules(ctx context.Context, in *pb.ListFeeRulesRequest) (*pb.ListFeeRulesResponse, error) {
+	_, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	rules, err := s.store.ListFeeRules(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListFeeRulesResponse{}
+	for _, r := range rules {
+		resp.Rules = append(resp.Rules, convertFeeRule(r))
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteFeeRule(ctx context.Context, in *pb.DeleteFeeRuleRequest) (*pb.DeleteFeeRuleResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	if err := val.ValidateID(in.Id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		rule, err := q.DeleteFeeRule(ctx, in.Id)
+		return store.AuditRecord{
+			Action:     "delete_fee_rule",
+			EntityType: "fee_rule",
+			EntityID:   in.Id,
+			Before:     rule,
+		}, err
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "fee rule %d not found", in.Id)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteFeeRuleResponse{}, nil
+}
+
+func (s *Server) SetAccountType(ctx context.Context, in *pb.SetAccountTypeRequest) (*pb.SetAccountTypeResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateAccountType(in.AccountType); err != nil {
+		violations = append(violations, fieldViolation("account_type", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(acc) {
+		return nil, status.Error(codes.InvalidArgument, "system accounts have no type")
+	}
+	if acc.Status == store.AccountClosed {
+		return nil, status.Error(codes.FailedPrecondition, store.ErrAccountClosed.Error())
+	}
+
+	updated, err := s.updateAccount(ctx, "set_account_type", acc.ID, func(q *store.Queries) (store.Account, error) {
+		return q.SetAccountType(ctx, store.SetAccountTypeParams{
+			ID:          acc.ID,
+			AccountType: in.AccountType,
+		})
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetAccountTypeResponse{
+		Account: convertAccount(updated),
+	}, nil
+}
+
+func convertFeeRule(r store.FeeRule) *pb.FeeRule {
+	rule := &pb.FeeRule{
+		Id:         r.ID,
+		Currency:   r.Currency,
+		Kind:       r.Kind,
+		FlatAmount: convertMoney(r.FlatAmount, r.Currency),
+		RateBps:    r.RateBps,
+		UpdatedAt:  timestamppb.New(r.UpdatedAt),
+	}
+	if r.AccountType.Valid {
+		rule.AccountType = &r.AccountType.String
+	}
+	if r.MinAmount.Valid {
+		rule.MinAmount = convertMoney(r.MinAmount.Int64, r.Currency)
+	}
+	if r.MaxAmount.Valid {
+		rule.MaxAmount = convertMoney(r.MaxAmount.Int64, r.Currency)
+	}
+	return rule
+}
+
+func validateSetFeeRuleRequest(in *pb.SetFeeRuleRequest) (arg store.SetFeeRuleParams, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(in.Currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	arg.Currency = in.Currency
+	if in.AccountType != nil {
+		if err := val.ValidateAccountType(*in.AccountType); err != nil {
+			violations = append(violations, fieldViolation("account_type", err))
+		}
+		arg.AccountType = sql.NullString{String: *in.AccountType, Valid: true}
+	}
+
+	arg.Kind = in.Kind
+	switch in.Kind {
+	case store.FeeFlat:
+		amount, errs := parseMoney("flat_amount", in.FlatAmount)
+		violations = append(violations, errs...)
+		if errs == nil && amount.Currency != in.Currency {
+			violations = append(violations, fieldViolation("flat_amount.currency", fmt.Errorf("must match currency")))
+		}
+		arg.FlatAmount = amount.Amount
+		if in.RateBps != 0 {
+			violations = append(violations, fieldViolation("rate_bps", fmt.Errorf("must not be set for a flat rule")))
+		}
+	case store.FeePercentage:
+		if err := val.ValidateFeeRateBps(in.RateBps); err != nil {
+			violations = append(violations, fieldViolation("rate_bps", err))
+		}
+		arg.RateBps = in.RateBps
+		if in.FlatAmount != nil {
+			violations = append(violations, fieldViolation("flat_amount", fmt.Errorf("must not be set for a percentage rule")))
+		}
+	default:
+		violations = append(violations, fieldViolation("kind", fmt.Errorf("must be flat or percentage")))
+	}
+
+	bounds := []struct {
+		field string
+		in    *pb.Money
+		out   *sql.NullInt64
+	}{
+		{"min_amount", in.MinAmount, &arg.MinAmount},
+		{"max_amount", in.MaxAmount, &arg.MaxAmount},
+	}
+	for _, b := range bounds {
+		if b.in == nil {
+			continue
+		}
+		amount, errs := parseLimit(b.field, b.in)
+		violations = append(violations, errs...)
+		if errs == nil && amount.Currency != in.Currency {
+			violations = append(violations, fieldViolation(b.field+".currency", fmt.Errorf("must match currency")))
+		}
+		*b.out = sql.NullInt64{Int64: amount.Amount, Valid: true}
+	}
+	if arg.MinAmount.Valid && arg.MaxAmount.Valid && arg.MaxAmount.Int64 < arg.MinAmount.Int64 {
+		violations = append(violations, fieldViolation("max_amount", fmt.Errorf("must not be less than min_amount")))
+	}
+	return
+}
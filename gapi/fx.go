@@ -0,0 +1,257 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) QuoteTransfer(ctx context.Context, in *pb.QuoteTransferRequest) (*pb.QuoteTransferResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	amount, errs := validateQuoteTransferRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	fromAccount, err := s.getAccount(ctx, in.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	toAccount, err := s.getAccount(ctx, in.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(toAccount) {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer to a system account")
+	}
+	if fromAccount.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+	if fromAccount.Currency == toAccount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "accounts use the same currency, no quote needed")
+	}
+
+	fromCurrency, ok := util.Currencies.Lookup(fromAccount.Currency)
+	if !ok || !fromCurrency.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "currency %s is not enabled", fromAccount.Currency)
+	}
+	toCurrency, ok := util.Currencies.Lookup(toAccount.Currency)
+	if !ok || !toCurrency.Enabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "currency %s is not enabled", toAccount.Currency)
+	}
+
+	mid, spreadBps, err := s.midRate(ctx, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+	rate := util.QuoteRate(mid, spreadBps)
+	converted := util.ConvertAmount(amount.Amount, rate, fromCurrency.MinorUnits, toCurrency.MinorUnits)
+	if converted <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount too small to convert")
+	}
+	spread := util.ConvertAmount(amount.Amount, mid, fromCurrency.MinorUnits, toCurrency.MinorUnits) - converted
+
+	houseFrom, err := store.SystemAccount(ctx, s.store, store.SystemFx, fromAccount.Currency)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	houseTo, err := store.SystemAccount(ctx, s.store, store.SystemFx, toAccount.Currency)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var quote store.FxQuote
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		quote, err = q.CreateFxQuote(ctx, store.CreateFxQuoteParams{
+			ID:                 uuid.New(),
+			Username:           payload.Username,
+			FromAccountID:      fromAccount.ID,
+			ToAccountID:        toAccount.ID,
+			HouseFromAccountID: houseFrom.ID,
+			HouseToAccountID:   houseTo.ID,
+			Amount:             amount.Amount,
+			Rate:               rate.FloatString(10),
+			ConvertedAmount:    converted,
+			SpreadAmount:       spread,
+			ExpiresAt:          time.Now().Add(s.config.FxQuoteDuration),
+		})
+		return store.AuditRecord{
+			Action:     "create_fx_quote",
+			EntityType: "fx_quote",
+			EntityID:   quote.ID,
+			After:      quote,
+		}, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.QuoteTransferResponse{
+		QuoteId:         quote.ID.String(),
+		FromCurrency:    fromAccount.Currency,
+		ToCurrency:      toAccount.Currency,
+		Rate:            quote.Rate,
+		Amount:          convertMoney(quote.Amount, fromAccount.Currency),
+		ConvertedAmount: convertMoney(quote.ConvertedAmount, toAccount.Currency),
+		SpreadAmount:    convertMoney(quote.SpreadAmount, toAccount.Currency),
+		ExpiresAt:       timestamppb.New(quote.ExpiresAt),
+	}, nil
+}
+
+func (s *Server) SetFxRates(ctx context.Context, in *pb.SetFxRatesRequest) (*pb.SetFxRatesResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateSetFxRatesRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	rates := make([]util.FxRate, 0, len(in.Rates))
+	for _, r := range in.Rates {
+		rates = append(rates, util.FxRate{
+			BaseCurrency:  r.BaseCurrency,
+			QuoteCurrency: r.QuoteCurrency,
+			Rate:          r.Rate,
+			SpreadBps:     r.SpreadBps,
+		})
+	}
+	saved, err := UpsertFxRates(ctx, s.store, rates)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.SetFxRatesResponse{}
+	for _, r := range saved {
+		resp.Rates = append(resp.Rates, &pb.FxRate{
+			BaseCurrency:  r.BaseCurrency,
+			QuoteCurrency: r.QuoteCurrency,
+			Rate:          r.Rate,
+			SpreadBps:     r.SpreadBps,
+			UpdatedAt:     timestamppb.New(r.UpdatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// UpsertFxRates saves rates coming from the admin api or from the rates file. Changes are
+// audited when ctx carries an actor.
+func UpsertFxRates(ctx context.Context, s store.Store, rates []util.FxRate) ([]store.FxRate, error) {
+	saved := make([]store.FxRate, 0, len(rates))
+	for _, r := range rates {
+		var rate store.FxRate
+		err := s.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+			rec := store.AuditRecord{
+				Action:     "set_fx_rate",
+				EntityType: "fx_rate",
+				EntityID:   r.BaseCurrency + "/" + r.QuoteCurrency,
+			}
+			before, err := q.GetFxRate(ctx, store.GetFxRateParams{
+				BaseCurrency:  r.BaseCurrency,
+				QuoteCurrency: r.QuoteCurrency,
+			})
+			if err == nil {
+				rec.Before = before
+			} else if err != store.ErrRecordNotFound {
+				return rec, err
+			}
+			rate, err = q.UpsertFxRate(ctx, store.UpsertFxRateParams{
+				BaseCurrency:  r.BaseCurrency,
+				QuoteCurrency: r.QuoteCurrency,
+				Rate:          r.Rate,
+				SpreadBps:     r.SpreadBps,
+			})
+			rec.After = rate
+			return rec, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot save rate %s/%s: %w", r.BaseCurrency, r.QuoteCurrency, err)
+		}
+		saved = append(saved, rate)
+	}
+	return saved, nil
+}
+
+// midRate looks up the rate for base/quote and falls back to inverting the quote/base rate.
+func (s *Server) midRate(ctx context.Context, base string, quote string) (*big.Rat, int32, error) {
+	inverse := false
+	fxRate, err := s.store.GetFxRate(ctx, store.GetFxRateParams{BaseCurrency: base, QuoteCurrency: quote})
+	if err == store.ErrRecordNotFound {
+		inverse = true
+		fxRate, err = s.store.GetFxRate(ctx, store.GetFxRateParams{BaseCurrency: quote, QuoteCurrency: base})
+	}
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, 0, status.Errorf(codes.FailedPrecondition, "no fx rate for %s/%s", base, quote)
+		}
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+
+	mid, err := util.ParseRate(fxRate.Rate)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	if inverse {
+		mid.Inv(mid)
+	}
+	return mid, fxRate.SpreadBps, nil
+}
+
+func validateQuoteTransferRequest(in *pb.QuoteTransferRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.FromAccountId); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateID(in.ToAccountId); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	amount, errs := parseMoney("amount", in.Amount)
+	violations = append(violations, errs...)
+	return
+}
+
+func validateSetFxRatesRequest(in *pb.SetFxRatesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if len(in.Rates) == 0 {
+		violations = append(violations, fieldViolation("rates", fmt.Errorf("at least one rate is required")))
+	}
+	for i, r := range in.Rates {
+		field := fmt.Sprintf("rates[%d]", i)
+		if err := val.ValidateCurrency(r.BaseCurrency); err != nil {
+			violations = append(violations, fieldViolation(field+".base_currency", err))
+		}
+		if err := val.ValidateCurrency(r.QuoteCurrency); err != nil {
+			violations = append(violations, fieldViolation(field+".quote_currency", err))
+		}
+		if r.BaseCurrency == r.QuoteCurrency {
+			violations = append(violations, fieldViolation(field+".quote_currency", fmt.Errorf("must differ from base currency")))
+		}
+		if _, err := util.ParseRate(r.Rate); err != nil {
+			violations = append(violations, fieldViolation(field+".rate", err))
+		}
+		if err := val.ValidateSpreadBps(r.SpreadBps); err != nil {
+			violations = append(violations, fieldViolation(field+".spread_bps", err))
+		}
+	}
+	return
+}
@@ -0,0 +1,241 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the number of rows read and streamed at a time by the exports.
+const exportChunkSize = 500
+
+func (s *Server) ListTransferHistory(ctx context.Context, in *pb.ListTransferHistoryRequest) (*pb.ListTransferHistoryResponse, error) {
+	account, arg, err := s.historyQuery(ctx, in.Filter, &historyPage{size: in.PageSize, token: in.PageToken})
+	if err != nil {
+		return nil, err
+	}
+
+	// one more row tells whether there is a next page
+	arg.Limit = in.PageSize + 1
+	transfers, err := s.store.ListTransferHistory(withReadConsistency(ctx), arg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListTransferHistoryResponse{}
+	if len(transfers) > int(in.PageSize) {
+		transfers = transfers[:in.PageSize]
+		last := transfers[len(transfers)-1]
+		resp.NextPageToken = util.PageToken{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	resp.Transfers, err = s.convertAccountTransfers(ctx, account, transfers)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) ListEntryHistory(ctx context.Context, in *pb.ListEntryHistoryRequest) (*pb.ListEntryHistoryResponse, error) {
+	account, arg, err := s.historyQuery(ctx, in.Filter, &historyPage{size: in.PageSize, token: in.PageToken})
+	if err != nil {
+		return nil, err
+	}
+
+	arg.Limit = in.PageSize + 1
+	entries, err := s.store.ListEntryHistory(withReadConsistency(ctx), store.ListEntryHistoryParams(arg))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListEntryHistoryResponse{}
+	if len(entries) > int(in.PageSize) {
+		entries = entries[:in.PageSize]
+		last := entries[len(entries)-1]
+		resp.NextPageToken = util.PageToken{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, convertEntry(e, account.Currency))
+	}
+	return resp, nil
+}
+
+// ExportTransferHistory streams every matching transfer in chunks, newest first.
+func (s *Server) ExportTransferHistory(in *pb.ExportTransferHistoryRequest, stream pb.SimpleBank_ExportTransferHistoryServer) error {
+	ctx := stream.Context()
+	account, arg, err := s.historyQuery(ctx, in.Filter, nil)
+	if err != nil {
+		return err
+	}
+
+	arg.Limit = exportChunkSize
+	for {
+		transfers, err := s.store.ListTransferHistory(withReadConsistency(ctx), arg)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if len(transfers) == 0 {
+			return nil
+		}
+		resp := &pb.ExportTransferHistoryResponse{}
+		resp.Transfers, err = s.convertAccountTransfers(ctx, account, transfers)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		if len(transfers) < exportChunkSize {
+			return nil
+		}
+		last := transfers[len(transfers)-1]
+		arg.CursorCreatedAt = sql.NullTime{Time: last.CreatedAt, Valid: true}
+		arg.CursorID = last.ID
+	}
+}
+
+// ExportEntryHistory streams every matching entry in chunks, newest first.
+func (s *Server) ExportEntryHistory(in *pb.ExportEntryHistoryRequest, stream pb.SimpleBank_ExportEntryHistoryServer) error {
+	ctx := stream.Context()
+	account, arg, err := s.historyQuery(ctx, in.Filter, nil)
+	if err != nil {
+		return err
+	}
+
+	arg.Limit = exportChunkSize
+	for {
+		entries, err := s.store.ListEntryHistory(withReadConsistency(ctx), store.ListEntryHistoryParams(arg))
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		resp := &pb.ExportEntryHistoryResponse{}
+		for _, e := range entries {
+			resp.Entries = append(resp.Entries, convertEntry(e, account.Currency))
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		if len(entries) < exportChunkSize {
+			return nil
+		}
+		last := entries[len(entries)-1]
+		arg.CursorCreatedAt = sql.NullTime{Time: last.CreatedAt, Valid: true}
+		arg.CursorID = last.ID
+	}
+}
+
+// historyPage is the page requested from a history list.
+type historyPage struct {
+	size  int32
+	token string
+}
+
+// historyQuery authorizes the caller for the account of filter and turns the filter and page
+// into query parameters. The entry queries take the same parameters. The exports pass no page.
+func (s *Server) historyQuery(ctx context.Context, filter *pb.HistoryFilter, page *historyPage) (store.Account, store.ListTransferHistoryParams, error) {
+	var arg store.ListTransferHistoryParams
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return store.Account{}, arg, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if page != nil {
+		if err := val.ValidatePageSize(page.size); err != nil {
+			violations = append(violations, fieldViolation("page_size", err))
+		}
+		if page.token != "" {
+			token, err := util.DecodePageToken(page.token)
+			if err != nil {
+				violations = append(violations, fieldViolation("page_token", err))
+			}
+			arg.CursorCreatedAt = sql.NullTime{Time: token.CreatedAt, Valid: err == nil}
+			arg.CursorID = token.ID
+		}
+	}
+	minAmount, maxAmount, errs := validateHistoryFilter(filter, &arg)
+	violations = append(violations, errs...)
+	if violations != nil {
+		return store.Account{}, arg, invalidArgumentError(violations)
+	}
+
+	account, err := s.getAccount(ctx, filter.AccountId)
+	if err != nil {
+		return account, arg, err
+	}
+	if payload.Role == util.DepositorRole && account.Owner != payload.Username {
+		return account, arg, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	for _, m := range []*util.Money{minAmount, maxAmount} {
+		if m != nil && m.Currency != account.Currency {
+			return account, arg, status.Error(codes.InvalidArgument, "currency mismatch")
+		}
+	}
+	return account, arg, nil
+}
+
+// validateHistoryFilter validates filter and copies it to arg. The amount bounds are returned
+// for the currency check against the account.
+func validateHistoryFilter(filter *pb.HistoryFilter, arg *store.ListTransferHistoryParams) (minAmount *util.Money, maxAmount *util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if filter == nil {
+		return nil, nil, []*errdetails.BadRequest_FieldViolation{fieldViolation("filter", fmt.Errorf("is required"))}
+	}
+
+	if err := val.ValidateID(filter.AccountId); err != nil {
+		violations = append(violations, fieldViolation("filter.account_id", err))
+	}
+	arg.AccountID = filter.AccountId
+
+	if filter.Direction != "" {
+		if err := val.ValidateDirection(filter.Direction); err != nil {
+			violations = append(violations, fieldViolation("filter.direction", err))
+		}
+		arg.Incoming = sql.NullBool{Bool: filter.Direction == "incoming", Valid: true}
+	}
+
+	if filter.StartTime != nil {
+		arg.StartTime = sql.NullTime{Time: filter.StartTime.AsTime(), Valid: true}
+	}
+	if filter.EndTime != nil {
+		arg.EndTime = sql.NullTime{Time: filter.EndTime.AsTime(), Valid: true}
+	}
+	if arg.StartTime.Valid && arg.EndTime.Valid && !arg.StartTime.Time.Before(arg.EndTime.Time) {
+		violations = append(violations, fieldViolation("filter.end_time", fmt.Errorf("must be after start_time")))
+	}
+
+	if filter.MinAmount != nil {
+		amount, errs := parseMoney("filter.min_amount", filter.MinAmount)
+		violations = append(violations, errs...)
+		minAmount = &amount
+		arg.MinAmount = sql.NullInt64{Int64: amount.Amount, Valid: true}
+	}
+	if filter.MaxAmount != nil {
+		amount, errs := parseMoney("filter.max_amount", filter.MaxAmount)
+		violations = append(violations, errs...)
+		maxAmount = &amount
+		arg.MaxAmount = sql.NullInt64{Int64: amount.Amount, Valid: true}
+	}
+	if arg.MinAmount.Valid && arg.MaxAmount.Valid && arg.MinAmount.Int64 > arg.MaxAmount.Int64 {
+		violations = append(violations, fieldViolation("filter.max_amount", fmt.Errorf("must not be less than min_amount")))
+	}
+
+	if filter.CounterpartyAccountId != 0 {
+		if err := val.ValidateID(filter.CounterpartyAccountId); err != nil {
+			violations = append(violations, fieldViolation("filter.counterparty_account_id", err))
+		} else if filter.CounterpartyAccountId == filter.AccountId {
+			violations = append(violations, fieldViolation("filter.counterparty_account_id", fmt.Errorf("must not be the account itself")))
+		}
+		arg.CounterpartyID = sql.NullInt64{Int64: filter.CounterpartyAccountId, Valid: true}
+	}
+	return
+}
@@ -0,0 +1,215 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateHold(ctx context.Context, in *pb.CreateHoldRequest) (*pb.CreateHoldResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	amount, errs := validateCreateHoldRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	account, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	toAccount, err := s.getAccount(ctx, in.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(toAccount) {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer to a system account")
+	}
+	if account.Currency != amount.Currency || toAccount.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	res, err := s.store.CreateHoldTx(ctx, store.CreateHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      amount.Amount,
+		ExpiresAt:   time.Now().Add(s.config.HoldDuration),
+	})
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return &pb.CreateHoldResponse{
+		Hold:    convertHold(res.Hold),
+		Balance: convertAccountBalance(res.Account),
+	}, nil
+}
+
+func (s *Server) CaptureHold(ctx context.Context, in *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.Id); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	amount, errs := parseMoney("amount", in.Amount)
+	violations = append(violations, errs...)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hold, err := s.getHold(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	// the hold is captured by the receiving side, like a merchant settling a card payment
+	toAccount, err := s.getAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if toAccount.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "hold isn't made out to the authenticated user")
+	}
+	if hold.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	res, err := s.store.CaptureHoldTx(ctx, store.CaptureHoldTxParams{
+		ID:     hold.ID,
+		Amount: amount.Amount,
+		Final:  in.Final,
+	})
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return &pb.CaptureHoldResponse{
+		Hold:     convertHold(res.Hold),
+		Transfer: convertTransfer(res.Transfer.Transfer, hold.Currency),
+		Balance:  convertAccountBalance(res.Transfer.ToAccount),
+	}, nil
+}
+
+func (s *Server) VoidHold(ctx context.Context, in *pb.VoidHoldRequest) (*pb.VoidHoldResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	if err := val.ValidateID(in.Id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	hold, err := s.getHold(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	// either side may call the payment off
+	account, err := s.getAccount(ctx, hold.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Owner != payload.Username {
+		toAccount, err := s.getAccount(ctx, hold.ToAccountID)
+		if err != nil {
+			return nil, err
+		}
+		if toAccount.Owner != payload.Username {
+			return nil, status.Error(codes.PermissionDenied, "hold doesn't belong to authenticated user")
+		}
+	}
+
+	res, err := s.store.VoidHoldTx(ctx, hold.ID)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	resp := &pb.VoidHoldResponse{
+		Hold: convertHold(res.Hold),
+	}
+	// the balance of the held account is only shown to its owner
+	if account.Owner == payload.Username {
+		resp.Balance = convertAccountBalance(res.Account)
+	}
+	return resp, nil
+}
+
+func (s *Server) getHold(ctx context.Context, id int64) (store.Hold, error) {
+	hold, err := s.store.GetHold(ctx, id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return hold, status.Errorf(codes.NotFound, "hold %d not found", id)
+		}
+		return hold, status.Error(codes.Internal, err.Error())
+	}
+	return hold, nil
+}
+
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrLimitExceeded):
+		return limitExceededError(err)
+	case errors.Is(err, store.ErrInsufficientFunds),
+		errors.Is(err, store.ErrHoldNotActive),
+		errors.Is(err, store.ErrHoldExpired),
+		errors.Is(err, store.ErrHoldAmountExceeded),
+		isAccountStatusError(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func convertHold(h store.Hold) *pb.Hold {
+	return &pb.Hold{
+		Id:             h.ID,
+		AccountId:      h.AccountID,
+		ToAccountId:    h.ToAccountID,
+		Amount:         convertMoney(h.Amount, h.Currency),
+		CapturedAmount: convertMoney(h.CapturedAmount, h.Currency),
+		Status:         h.Status,
+		ExpiresAt:      timestamppb.New(h.ExpiresAt),
+		CreatedAt:      timestamppb.New(h.CreatedAt),
+	}
+}
+
+func convertAccountBalance(a store.Account) *pb.AccountBalance {
+	return &pb.AccountBalance{
+		AccountId:        a.ID,
+		LedgerBalance:    convertMoney(a.Balance, a.Currency),
+		AvailableBalance: convertMoney(a.AvailableBalance, a.Currency),
+	}
+}
+
+func validateCreateHoldRequest(in *pb.CreateHoldRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateID(in.ToAccountId); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if in.AccountId == in.ToAccountId {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from account_id")))
+	}
+	amount, errs := parseMoney("amount", in.Amount)
+	violations = append(violations, errs...)
+	return
+}
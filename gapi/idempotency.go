@@ -0,0 +1,151 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const idempotencyKeyHeader = "idempotency-key"
+
+// idempotentMethods lists the mutating RPCs that honour the idempotency-key metadata.
+// CreateWebhookEndpoint and RotateWebhookSecret are left out, their responses carry the
+// plaintext signing secret which must not be kept in the stored response.
+var idempotentMethods = map[string]bool{
+	pb.SimpleBank_CreateUser_FullMethodName:            true,
+	pb.SimpleBank_UpdateUser_FullMethodName:            true,
+	pb.SimpleBank_CreateCurrency_FullMethodName:        true,
+	pb.SimpleBank_UpdateCurrency_FullMethodName:        true,
+	pb.SimpleBank_CreateTransfer_FullMethodName:        true,
+	pb.SimpleBank_QuoteTransfer_FullMethodName:         true,
+	pb.SimpleBank_SetFxRates_FullMethodName:            true,
+	pb.SimpleBank_Deposit_FullMethodName:               true,
+	pb.SimpleBank_Withdraw_FullMethodName:              true,
+	pb.SimpleBank_CreateStandingOrder_FullMethodName:   true,
+	pb.SimpleBank_PauseStandingOrder_FullMethodName:    true,
+	pb.SimpleBank_ResumeStandingOrder_FullMethodName:   true,
+	pb.SimpleBank_CancelStandingOrder_FullMethodName:   true,
+	pb.SimpleBank_CreateHold_FullMethodName:            true,
+	pb.SimpleBank_CaptureHold_FullMethodName:           true,
+	pb.SimpleBank_VoidHold_FullMethodName:              true,
+	pb.SimpleBank_ReverseTransfer_FullMethodName:       true,
+	pb.SimpleBank_FreezeAccount_FullMethodName:         true,
+	pb.SimpleBank_UnfreezeAccount_FullMethodName:       true,
+	pb.SimpleBank_CloseAccount_FullMethodName:          true,
+	pb.SimpleBank_SetOverdraft_FullMethodName:          true,
+	pb.SimpleBank_SetTransferLimit_FullMethodName:      true,
+	pb.SimpleBank_SetUserTier_FullMethodName:           true,
+	pb.SimpleBank_CreateInterestProduct_FullMethodName: true,
+	pb.SimpleBank_SetInterestProduct_FullMethodName:    true,
+	pb.SimpleBank_SetFeeRule_FullMethodName:            true,
+	pb.SimpleBank_DeleteFeeRule_FullMethodName:         true,
+	pb.SimpleBank_SetAccountType_FullMethodName:        true,
+	pb.SimpleBank_DeleteWebhookEndpoint_FullMethodName: true,
+	pb.SimpleBank_RedeliverWebhook_FullMethodName:      true,
+	pb.SimpleBank_CreateTransferBatch_FullMethodName:   true,
+}
+
+func (s *Server) IdempotencyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !idempotentMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	key := idempotencyKey(ctx)
+	if key == "" {
+		return handler(ctx, req)
+	}
+
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+	reqBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(reqMsg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	hash := sha256.New()
+	hash.Write([]byte(info.FullMethod))
+	hash.Write(reqBytes)
+
+	requestHash := hex.EncodeToString(hash.Sum(nil))
+
+	// keys are scoped per user. Anonymous calls such as CreateUser have no user to scope by,
+	// their keys are scoped by the method and request so that unrelated clients never share one.
+	var username string
+	if payload, err := s.authenticateUser(ctx); err == nil {
+		username = payload.Username
+	} else {
+		key = requestHash + ":" + key
+	}
+
+	var resp any
+	var handlerErr error
+	res, err := s.store.IdempotencyTx(ctx, store.IdempotencyTxParams{
+		Username:    username,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(s.config.IdempotencyKeyTTL),
+		Handle: func() (int32, []byte, error) {
+			resp, handlerErr = handler(ctx, req)
+			if handlerErr != nil {
+				return 0, nil, handlerErr
+			}
+			respMsg, ok := resp.(proto.Message)
+			if !ok {
+				return 0, nil, errors.New("response is not a proto message")
+			}
+			anyResp, err := anypb.New(respMsg)
+			if err != nil {
+				return 0, nil, err
+			}
+			body, err := proto.Marshal(anyResp)
+			return int32(codes.OK), body, err
+		},
+	})
+	if handlerErr != nil {
+		return nil, handlerErr
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrIdempotencyKeyReused) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, store.ErrIdempotencyKeyInProgress) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !res.Replayed {
+		return resp, nil
+	}
+
+	var anyResp anypb.Any
+	if err := proto.Unmarshal(res.ResponseBody, &anyResp); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	replayed, err := anyResp.UnmarshalNew()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return replayed, nil
+}
+
+func idempotencyKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			return keys[0]
+		}
+	}
+	return ""
+}
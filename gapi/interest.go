@@ -0,0 +1,211 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateInterestProduct(ctx context.Context, in *pb.CreateInterestProductRequest) (*pb.CreateInterestProductResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateCreateInterestProductRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	var product store.InterestProduct
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		product, err = q.CreateInterestProduct(ctx, store.CreateInterestProductParams{
+			Name:          in.Name,
+			Currency:      in.Currency,
+			AnnualRateBps: in.AnnualRateBps,
+			DayCount:      in.DayCount,
+		})
+		return store.AuditRecord{
+			Action:     "create_interest_product",
+			EntityType: "interest_product",
+			EntityID:   product.ID,
+			After:      product,
+		}, err
+	})
+	if err != nil {
+		if store.ErrorCode(err) == store.UniqueViolation {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateInterestProductResponse{
+		Product: convertInterestProduct(product),
+	}, nil
+}
+
+func (s *Server) ListInterestProducts(ctx context.Context, in *pb.ListInterestProductsRequest) (*pb.ListInterestProductsResponse, error) {
+	_, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	products, err := s.store.ListInterestProducts(ctx, store.ListInterestProductsParams{
+		Limit:  in.PageSize,
+		Offset: (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListInterestProductsResponse{}
+	for _, p := range products {
+		resp.Products = append(resp.Products, convertInterestProduct(p))
+	}
+	return resp, nil
+}
+
+func (s *Server) SetInterestProduct(ctx context.Context, in *pb.SetInterestProductRequest) (*pb.SetInterestProductResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if in.ProductId != nil {
+		if err := val.ValidateID(*in.ProductId); err != nil {
+			violations = append(violations, fieldViolation("product_id", err))
+		}
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(acc) {
+		return nil, status.Error(codes.InvalidArgument, "system accounts don't earn interest")
+	}
+	if acc.Status == store.AccountClosed {
+		return nil, status.Error(codes.FailedPrecondition, store.ErrAccountClosed.Error())
+	}
+
+	arg := store.SetAccountInterestProductParams{
+		ID: acc.ID,
+	}
+	if in.ProductId != nil {
+		product, err := s.store.GetInterestProduct(ctx, *in.ProductId)
+		if err != nil {
+			if err == store.ErrRecordNotFound {
+				return nil, status.Errorf(codes.NotFound, "interest product %d not found", *in.ProductId)
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if product.Currency != acc.Currency {
+			return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+		}
+		arg.InterestProductID = sql.NullInt64{Int64: product.ID, Valid: true}
+	}
+
+	updated, err := s.updateAccount(ctx, "set_interest_product", acc.ID, func(q *store.Queries) (store.Account, error) {
+		return q.SetAccountInterestProduct(ctx, arg)
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetInterestProductResponse{
+		Account: convertAccount(updated),
+	}, nil
+}
+
+func (s *Server) ListInterestAccruals(ctx context.Context, in *pb.ListInterestAccrualsRequest) (*pb.ListInterestAccrualsResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	violations := validatePage(in.PageId, in.PageSize)
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	acc, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Role == util.DepositorRole && acc.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+
+	accruals, err := s.store.ListInterestAccruals(ctx, store.ListInterestAccrualsParams{
+		AccountID: acc.ID,
+		Limit:     in.PageSize,
+		Offset:    (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListInterestAccrualsResponse{}
+	for _, a := range accruals {
+		resp.Accruals = append(resp.Accruals, &pb.InterestAccrual{
+			AccountId:     a.AccountID,
+			AccruedOn:     a.AccruedOn.Format(time.DateOnly),
+			AnnualRateBps: a.AnnualRateBps,
+			Balance:       convertMoney(a.Balance, acc.Currency),
+			Amount:        convertMicros(a.AmountMicros, acc.Currency),
+			Capitalized:   a.CapitalizationID.Valid,
+		})
+	}
+	return resp, nil
+}
+
+func convertInterestProduct(p store.InterestProduct) *pb.InterestProduct {
+	return &pb.InterestProduct{
+		Id:            p.ID,
+		Name:          p.Name,
+		Currency:      p.Currency,
+		AnnualRateBps: p.AnnualRateBps,
+		DayCount:      p.DayCount,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+	}
+}
+
+func validateCreateInterestProductRequest(in *pb.CreateInterestProductRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateProductName(in.Name); err != nil {
+		violations = append(violations, fieldViolation("name", err))
+	}
+	if err := val.ValidateCurrency(in.Currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	if err := val.ValidateInterestBps(in.AnnualRateBps); err != nil {
+		violations = append(violations, fieldViolation("annual_rate_bps", err))
+	}
+	if err := val.ValidateDayCount(in.DayCount); err != nil {
+		violations = append(violations, fieldViolation("day_count", err))
+	}
+	return
+}
@@ -0,0 +1,197 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) SetTransferLimit(ctx context.Context, in *pb.SetTransferLimitRequest) (*pb.SetTransferLimitResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	arg, errs := validateSetTransferLimitRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	if arg.Scope == store.LimitScopeUser {
+		if _, err := s.store.GetUser(ctx, arg.Subject); err != nil {
+			if err == store.ErrRecordNotFound {
+				return nil, status.Errorf(codes.NotFound, "user %s not found", arg.Subject)
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	var limit store.TransferLimit
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		rec := store.AuditRecord{
+			Action:     "set_transfer_limit",
+			EntityType: "transfer_limit",
+			EntityID:   arg.Scope + "/" + arg.Subject + "/" + arg.Currency,
+		}
+		before, err := q.GetTransferLimitForUpdate(ctx, store.GetTransferLimitForUpdateParams{
+			Scope:    arg.Scope,
+			Subject:  arg.Subject,
+			Currency: arg.Currency,
+		})
+		if err == nil {
+			rec.Before = before
+		} else if err != store.ErrRecordNotFound {
+			return rec, err
+		}
+		limit, err = q.SetTransferLimit(ctx, arg)
+		rec.After = limit
+		return rec, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetTransferLimitResponse{
+		Limit: convertTransferLimit(limit),
+	}, nil
+}
+
+func (s *Server) SetUserTier(ctx context.Context, in *pb.SetUserTierRequest) (*pb.SetUserTierResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateUsername(in.Username); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if err := val.ValidateTier(in.Tier); err != nil {
+		violations = append(violations, fieldViolation("tier", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	var user store.User
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		before, err := q.GetUserForUpdate(ctx, in.Username)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		user, err = q.SetUserTier(ctx, store.SetUserTierParams{
+			Username: in.Username,
+			Tier:     in.Tier,
+		})
+		if err == nil {
+			err = q.EmitEvent(ctx, store.UserUpdatedEvent(user))
+		}
+		return store.AuditRecord{
+			Action:     "set_user_tier",
+			EntityType: "user",
+			EntityID:   in.Username,
+			Before:     before,
+			After:      user,
+		}, err
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", in.Username)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SetUserTierResponse{
+		User: &pb.User{
+			Username:          user.Username,
+			FullName:          user.FullName,
+			Email:             user.Email,
+			PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
+			CreatedAt:         timestamppb.New(user.CreatedAt),
+			Tier:              user.Tier,
+		},
+	}, nil
+}
+
+func convertTransferLimit(l store.TransferLimit) *pb.TransferLimit {
+	limit := &pb.TransferLimit{
+		Scope:     l.Scope,
+		Subject:   l.Subject,
+		Currency:  l.Currency,
+		UpdatedAt: timestamppb.New(l.UpdatedAt),
+	}
+	if l.PerTransfer.Valid {
+		limit.PerTransfer = convertMoney(l.PerTransfer.Int64, l.Currency)
+	}
+	if l.Daily.Valid {
+		limit.Daily = convertMoney(l.Daily.Int64, l.Currency)
+	}
+	if l.Monthly.Valid {
+		limit.Monthly = convertMoney(l.Monthly.Int64, l.Currency)
+	}
+	if l.HourlyCount.Valid {
+		limit.HourlyCount = &l.HourlyCount.Int32
+	}
+	return limit
+}
+
+func validateSetTransferLimitRequest(in *pb.SetTransferLimitRequest) (arg store.SetTransferLimitParams, violations []*errdetails.BadRequest_FieldViolation) {
+	switch {
+	case in.Username != "" && in.Tier != "":
+		violations = append(violations, fieldViolation("tier", fmt.Errorf("must not be set together with username")))
+	case in.Username != "":
+		arg.Scope, arg.Subject = store.LimitScopeUser, in.Username
+		if err := val.ValidateUsername(in.Username); err != nil {
+			violations = append(violations, fieldViolation("username", err))
+		}
+	case in.Tier != "":
+		arg.Scope, arg.Subject = store.LimitScopeTier, in.Tier
+		if err := val.ValidateTier(in.Tier); err != nil {
+			violations = append(violations, fieldViolation("tier", err))
+		}
+	default:
+		violations = append(violations, fieldViolation("username", fmt.Errorf("either username or tier is required")))
+	}
+	if err := val.ValidateCurrency(in.Currency); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	arg.Currency = in.Currency
+
+	amounts := []struct {
+		field string
+		in    *pb.Money
+		out   *sql.NullInt64
+	}{
+		{"per_transfer", in.PerTransfer, &arg.PerTransfer},
+		{"daily", in.Daily, &arg.Daily},
+		{"monthly", in.Monthly, &arg.Monthly},
+	}
+	for _, a := range amounts {
+		if a.in == nil {
+			continue
+		}
+		amount, errs := parseMoney(a.field, a.in)
+		violations = append(violations, errs...)
+		if errs == nil && amount.Currency != in.Currency {
+			violations = append(violations, fieldViolation(a.field+".currency", fmt.Errorf("must match currency")))
+		}
+		*a.out = sql.NullInt64{Int64: amount.Amount, Valid: true}
+	}
+
+	if in.HourlyCount != nil {
+		if err := val.ValidateCount(*in.HourlyCount); err != nil {
+			violations = append(violations, fieldViolation("hourly_count", err))
+		}
+		arg.HourlyCount = sql.NullInt32{Int32: *in.HourlyCount, Valid: true}
+	}
+	return
+}
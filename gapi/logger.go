@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
 	"google.golang.org/grpc"
@@ -48,6 +49,14 @@ func (rec *ResponseRecorder) Write(body []byte) (int, error) {
 func HttpLogger(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		startTime := time.Now()
+		// every request gets an id, the gateway passes it on to the handlers for the audit log
+		requestID := req.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			req.Header.Set(requestIDHeader, requestID)
+		}
+		res.Header().Set(requestIDHeader, requestID)
+
 		rec := &ResponseRecorder{
 			ResponseWriter: res,
 			StatusCode:     http.StatusOK,
@@ -60,6 +69,7 @@ func HttpLogger(handler http.Handler) http.Handler {
 			logger = log.Error().Bytes("body", rec.Body)
 		}
 		logger.Str("protocol", "http").
+			Str("request_id", requestID).
 			Str("method", req.Method).
 			Str("path", req.RequestURI).
 			Dur("duration", duration).
@@ -2,7 +2,10 @@ package gapi
 
 import (
 	"context"
+	"strings"
 
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -11,11 +14,14 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	requestIDHeader            = "x-request-id"
+	readYourWritesHeader       = "x-read-your-writes"
 )
 
 type Metadata struct {
 	UserAgent string
 	ClientIP  string
+	RequestID string
 }
 
 func (s *Server) extractMetaData(ctx context.Context) *Metadata {
@@ -32,6 +38,10 @@ func (s *Server) extractMetaData(ctx context.Context) *Metadata {
 		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
 			mtdt.ClientIP = clientIPs[0]
 		}
+
+		if requestIDs := md.Get(requestIDHeader); len(requestIDs) > 0 {
+			mtdt.RequestID = requestIDs[0]
+		}
 	}
 
 	if p, ok := peer.FromContext(ctx); ok {
@@ -39,3 +49,26 @@ func (s *Server) extractMetaData(ctx context.Context) *Metadata {
 	}
 	return mtdt
 }
+
+// withReadConsistency sends the reads of the request to the primary when the client asks to
+// read its own writes with the x-read-your-writes: true header.
+func withReadConsistency(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(readYourWritesHeader); len(values) > 0 && values[0] == "true" {
+			return store.WithReadYourWrites(ctx)
+		}
+	}
+	return ctx
+}
+
+// GatewayHeaderMatcher passes the request id and read consistency headers on to the handlers,
+// next to the headers the gateway forwards by default.
+func GatewayHeaderMatcher(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, requestIDHeader):
+		return requestIDHeader, true
+	case strings.EqualFold(key, readYourWritesHeader):
+		return readYourWritesHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
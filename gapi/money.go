@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// parseMoney converts a request amount into minor units. Amounts with more
+// decimals than the currency allows are rejected rather than rounded.
+func parseMoney(field string, in *pb.Money) (util.Money, []*errdetails.BadRequest_FieldViolation) {
+	return parseMoneyWith(field, in, val.ValidateAmount)
+}
+
+// parseLimit is parseMoney for limits, which may also be zero.
+func parseLimit(field string, in *pb.Money) (util.Money, []*errdetails.BadRequest_FieldViolation) {
+	return parseMoneyWith(field, in, val.ValidateLimit)
+}
+
+func parseMoneyWith(field string, in *pb.Money, validate func(int64) error) (util.Money, []*errdetails.BadRequest_FieldViolation) {
+	if in == nil {
+		return util.Money{}, []*errdetails.BadRequest_FieldViolation{fieldViolation(field, fmt.Errorf("is required"))}
+	}
+	if err := val.ValidateCurrency(in.Currency); err != nil {
+		return util.Money{}, []*errdetails.BadRequest_FieldViolation{fieldViolation(field+".currency", err)}
+	}
+	m, err := util.ParseMoney(in.Amount, in.Currency, util.RoundUnnecessary)
+	if err != nil {
+		return util.Money{}, []*errdetails.BadRequest_FieldViolation{fieldViolation(field+".amount", err)}
+	}
+	if err := validate(m.Amount); err != nil {
+		return util.Money{}, []*errdetails.BadRequest_FieldViolation{fieldViolation(field+".amount", err)}
+	}
+	return m, nil
+}
+
+func convertMoney(amount int64, currency string) *pb.Money {
+	return &pb.Money{
+		Currency: currency,
+		Amount:   util.NewMoney(amount, currency).Decimal(),
+	}
+}
+
+// convertMicros converts an amount in millionths of a minor unit, such as accrued interest.
+func convertMicros(micros int64, currency string) *pb.Money {
+	return &pb.Money{
+		Currency: currency,
+		Amount:   util.FormatMicros(micros, currency),
+	}
+}
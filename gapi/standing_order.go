@@ -0,0 +1,341 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateStandingOrder(ctx context.Context, in *pb.CreateStandingOrderRequest) (*pb.CreateStandingOrderResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	amount, errs := validateCreateStandingOrderRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	fromAccount, err := s.getAccount(ctx, in.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	toAccount, err := s.getAccount(ctx, in.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(toAccount) {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer to a system account")
+	}
+	if fromAccount.Currency != amount.Currency || toAccount.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	startAt := time.Now()
+	if in.StartAt != nil {
+		startAt = in.StartAt.AsTime()
+	}
+	schedule, _ := util.ParseSchedule(in.Schedule)
+	firstRun := util.FirstRun(schedule, startAt)
+
+	arg := store.CreateStandingOrderParams{
+		Owner:         payload.Username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount.Amount,
+		Currency:      amount.Currency,
+		Schedule:      in.Schedule,
+		StartAt:       startAt,
+		NextRunAt:     sql.NullTime{Time: firstRun, Valid: true},
+	}
+	if in.EndAt != nil {
+		arg.EndAt = sql.NullTime{Time: in.EndAt.AsTime(), Valid: true}
+		if firstRun.After(arg.EndAt.Time) {
+			return nil, status.Error(codes.InvalidArgument, "schedule has no run before end_at")
+		}
+	}
+	if in.MaxExecutions != nil {
+		arg.MaxExecutions = sql.NullInt32{Int32: *in.MaxExecutions, Valid: true}
+	}
+
+	var order store.StandingOrder
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		order, err = q.CreateStandingOrder(ctx, arg)
+		return store.AuditRecord{
+			Action:     "create_standing_order",
+			EntityType: "standing_order",
+			EntityID:   order.ID,
+			After:      order,
+		}, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateStandingOrderResponse{
+		StandingOrder: convertStandingOrder(order),
+	}, nil
+}
+
+func (s *Server) ListStandingOrders(ctx context.Context, in *pb.ListStandingOrdersRequest) (*pb.ListStandingOrdersResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	orders, err := s.store.ListStandingOrders(ctx, store.ListStandingOrdersParams{
+		Owner:  payload.Username,
+		Limit:  in.PageSize,
+		Offset: (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListStandingOrdersResponse{}
+	for _, order := range orders {
+		resp.StandingOrders = append(resp.StandingOrders, convertStandingOrder(order))
+	}
+	return resp, nil
+}
+
+func (s *Server) PauseStandingOrder(ctx context.Context, in *pb.PauseStandingOrderRequest) (*pb.PauseStandingOrderResponse, error) {
+	order, err := s.getOwnStandingOrder(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = s.withAuditActor(ctx, order.Owner)
+	paused, err := s.updateStandingOrder(ctx, "pause_standing_order", order.ID, func(q *store.Queries) (store.StandingOrder, error) {
+		return q.PauseStandingOrder(ctx, order.ID)
+	})
+	if err != nil {
+		return nil, standingOrderStatusError(order, err)
+	}
+	return &pb.PauseStandingOrderResponse{
+		StandingOrder: convertStandingOrder(paused),
+	}, nil
+}
+
+func (s *Server) ResumeStandingOrder(ctx context.Context, in *pb.ResumeStandingOrderRequest) (*pb.ResumeStandingOrderResponse, error) {
+	order, err := s.getOwnStandingOrder(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// runs missed while paused are skipped
+	schedule, err := util.ParseSchedule(order.Schedule)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	now := time.Now()
+	next := order.NextRunAt.Time
+	if !order.NextRunAt.Valid || next.Before(now) {
+		next = schedule.Next(now)
+	}
+	if order.EndAt.Valid && next.After(order.EndAt.Time) {
+		return nil, status.Error(codes.FailedPrecondition, "standing order has no run left before its end")
+	}
+
+	ctx = s.withAuditActor(ctx, order.Owner)
+	resumed, err := s.updateStandingOrder(ctx, "resume_standing_order", order.ID, func(q *store.Queries) (store.StandingOrder, error) {
+		return q.ResumeStandingOrder(ctx, store.ResumeStandingOrderParams{
+			ID:        order.ID,
+			NextRunAt: sql.NullTime{Time: next, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, standingOrderStatusError(order, err)
+	}
+	return &pb.ResumeStandingOrderResponse{
+		StandingOrder: convertStandingOrder(resumed),
+	}, nil
+}
+
+func (s *Server) CancelStandingOrder(ctx context.Context, in *pb.CancelStandingOrderRequest) (*pb.CancelStandingOrderResponse, error) {
+	order, err := s.getOwnStandingOrder(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = s.withAuditActor(ctx, order.Owner)
+	cancelled, err := s.updateStandingOrder(ctx, "cancel_standing_order", order.ID, func(q *store.Queries) (store.StandingOrder, error) {
+		return q.CancelStandingOrder(ctx, order.ID)
+	})
+	if err != nil {
+		return nil, standingOrderStatusError(order, err)
+	}
+	return &pb.CancelStandingOrderResponse{
+		StandingOrder: convertStandingOrder(cancelled),
+	}, nil
+}
+
+func (s *Server) ListStandingOrderRuns(ctx context.Context, in *pb.ListStandingOrderRunsRequest) (*pb.ListStandingOrderRunsResponse, error) {
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	order, err := s.getOwnStandingOrder(ctx, in.StandingOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.store.ListStandingOrderRuns(ctx, store.ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           in.PageSize,
+		Offset:          (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListStandingOrderRunsResponse{}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, convertStandingOrderRun(run))
+	}
+	return resp, nil
+}
+
+// getOwnStandingOrder authorizes the caller and loads one of their standing orders.
+func (s *Server) getOwnStandingOrder(ctx context.Context, id int64) (store.StandingOrder, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return store.StandingOrder{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := val.ValidateID(id); err != nil {
+		return store.StandingOrder{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	order, err := s.store.GetStandingOrder(ctx, id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return order, status.Errorf(codes.NotFound, "standing order %d not found", id)
+		}
+		return order, status.Error(codes.Internal, err.Error())
+	}
+	if order.Owner != payload.Username {
+		return order, status.Error(codes.PermissionDenied, "standing order doesn't belong to authenticated user")
+	}
+	return order, nil
+}
+
+// updateStandingOrder changes a standing order in an audited transaction.
+func (s *Server) updateStandingOrder(ctx context.Context, action string, id int64, update func(q *store.Queries) (store.StandingOrder, error)) (store.StandingOrder, error) {
+	var updated store.StandingOrder
+	err := s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		order, err := q.GetStandingOrderForUpdate(ctx, id)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		updated, err = update(q)
+		return store.AuditRecord{
+			Action:     action,
+			EntityType: "standing_order",
+			EntityID:   id,
+			Before:     order,
+			After:      updated,
+		}, err
+	})
+	return updated, err
+}
+
+// standingOrderStatusError maps the no rows error of a guarded status update to the status that blocked it.
+func standingOrderStatusError(order store.StandingOrder, err error) error {
+	if err == store.ErrRecordNotFound {
+		return status.Errorf(codes.FailedPrecondition, "standing order is %s", order.Status)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func convertStandingOrder(o store.StandingOrder) *pb.StandingOrder {
+	order := &pb.StandingOrder{
+		Id:            o.ID,
+		Owner:         o.Owner,
+		FromAccountId: o.FromAccountID,
+		ToAccountId:   o.ToAccountID,
+		Amount:        convertMoney(o.Amount, o.Currency),
+		Schedule:      o.Schedule,
+		Status:        o.Status,
+		StartAt:       timestamppb.New(o.StartAt),
+		Executions:    o.Executions,
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+	}
+	if o.EndAt.Valid {
+		order.EndAt = timestamppb.New(o.EndAt.Time)
+	}
+	if o.MaxExecutions.Valid {
+		order.MaxExecutions = &o.MaxExecutions.Int32
+	}
+	if o.NextRunAt.Valid {
+		order.NextRunAt = timestamppb.New(o.NextRunAt.Time)
+	}
+	return order
+}
+
+func convertStandingOrderRun(r store.StandingOrderRun) *pb.StandingOrderRun {
+	run := &pb.StandingOrderRun{
+		Id:          r.ID,
+		ScheduledAt: timestamppb.New(r.ScheduledAt),
+		Status:      r.Status,
+		Error:       r.Error,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+	}
+	if r.TransferID.Valid {
+		run.TransferId = &r.TransferID.Int64
+	}
+	return run
+}
+
+func validateCreateStandingOrderRequest(in *pb.CreateStandingOrderRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.FromAccountId); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateID(in.ToAccountId); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if in.FromAccountId == in.ToAccountId {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must differ from from_account_id")))
+	}
+	amount, errs := parseMoney("amount", in.Amount)
+	violations = append(violations, errs...)
+	if err := val.ValidateSchedule(in.Schedule); err != nil {
+		violations = append(violations, fieldViolation("schedule", err))
+	}
+	if in.StartAt != nil && in.EndAt != nil && !in.EndAt.AsTime().After(in.StartAt.AsTime()) {
+		violations = append(violations, fieldViolation("end_at", fmt.Errorf("must be after start_at")))
+	}
+	if in.MaxExecutions != nil && *in.MaxExecutions < 1 {
+		violations = append(violations, fieldViolation("max_executions", fmt.Errorf("must be at least 1")))
+	}
+	return
+}
+
+func validatePage(pageID int32, pageSize int32) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidatePageID(pageID); err != nil {
+		violations = append(violations, fieldViolation("page_id", err))
+	}
+	if err := val.ValidatePageSize(pageSize); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return
+}
@@ -0,0 +1,330 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateTransfer(ctx context.Context, in *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	amount, errs := validateCreateTransferRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	fromAccount, err := s.getAccount(ctx, in.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+	if fromAccount.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+	}
+
+	toAccount, err := s.getAccount(ctx, in.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if store.IsSystemAccount(toAccount) {
+		return nil, status.Error(codes.InvalidArgument, "cannot transfer to a system account")
+	}
+
+	arg := store.TransferTxParams{
+		FromAccountID: in.FromAccountId,
+		ToAccountID:   in.ToAccountId,
+		Amount:        amount.Amount,
+	}
+	if in.QuoteId != nil {
+		arg.QuoteID = uuid.NullUUID{UUID: uuid.MustParse(*in.QuoteId), Valid: true}
+	} else if toAccount.Currency != amount.Currency {
+		return nil, status.Error(codes.InvalidArgument, "currency mismatch, quote the transfer first")
+	}
+
+	res, err := s.store.TransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, store.ErrQuoteNotFound) || errors.Is(err, store.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, store.ErrLimitExceeded) {
+			return nil, limitExceededError(err)
+		}
+		if errors.Is(err, store.ErrQuoteExpired) || errors.Is(err, store.ErrQuoteUsed) || errors.Is(err, store.ErrQuoteMismatch) ||
+			errors.Is(err, store.ErrInsufficientFunds) || isAccountStatusError(err) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.CreateTransferResponse{
+		Transfer:  convertTransfer(res.Transfer, fromAccount.Currency),
+		FromEntry: convertEntry(res.FromEntry, fromAccount.Currency),
+		ToEntry:   convertEntry(res.ToEntry, toAccount.Currency),
+	}
+	for _, e := range res.FeeEntries {
+		resp.FeeEntries = append(resp.FeeEntries, convertEntry(e, fromAccount.Currency))
+	}
+	return resp, nil
+}
+
+func (s *Server) ReverseTransfer(ctx context.Context, in *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := val.ValidateID(in.TransferId); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+	var amount util.Money
+	if in.Amount != nil {
+		var errs []*errdetails.BadRequest_FieldViolation
+		amount, errs = parseMoney("amount", in.Amount)
+		violations = append(violations, errs...)
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	original, err := s.getTransfer(ctx, in.TransferId)
+	if err != nil {
+		return nil, err
+	}
+	// the money goes back from the receiving account
+	fromAccount, err := s.getAccount(ctx, original.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+	// bankers and admins may reverse any transfer, customers may only refund what they received
+	// and only within the reversal window
+	if payload.Role == util.DepositorRole {
+		if fromAccount.Owner != payload.Username {
+			return nil, status.Error(codes.PermissionDenied, "only the receiving account can refund a transfer")
+		}
+		if time.Since(original.CreatedAt) > s.config.ReversalWindow {
+			return nil, status.Error(codes.PermissionDenied, "transfer is too old to be refunded")
+		}
+	}
+
+	arg := store.ReverseTransferTxParams{
+		TransferID: original.ID,
+		Amount:     original.Amount - original.ReversedAmount,
+	}
+	if in.Amount != nil {
+		if amount.Currency != fromAccount.Currency {
+			return nil, status.Error(codes.InvalidArgument, "currency mismatch")
+		}
+		arg.Amount = amount.Amount
+	}
+	if arg.Amount == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "transfer is already reversed")
+	}
+
+	res, err := s.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, store.ErrTransferIsReversal) ||
+			errors.Is(err, store.ErrTransferNotReversible) ||
+			errors.Is(err, store.ErrReversalExceeded) ||
+			errors.Is(err, store.ErrInsufficientFunds) ||
+			isAccountStatusError(err) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ReverseTransferResponse{
+		Reversal:  convertTransfer(res.Transfer, fromAccount.Currency),
+		Original:  convertTransfer(res.Original, fromAccount.Currency),
+		FromEntry: convertEntry(res.FromEntry, fromAccount.Currency),
+		ToEntry:   convertEntry(res.ToEntry, fromAccount.Currency),
+	}, nil
+}
+
+func (s *Server) GetTransfer(ctx context.Context, in *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := val.ValidateID(in.Id); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	transfer, err := s.getTransfer(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	fromAccount, err := s.getAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Role == util.DepositorRole && fromAccount.Owner != payload.Username {
+		toAccount, err := s.getAccount(ctx, transfer.ToAccountID)
+		if err != nil {
+			return nil, err
+		}
+		if toAccount.Owner != payload.Username {
+			return nil, status.Error(codes.PermissionDenied, "transfer doesn't belong to authenticated user")
+		}
+	}
+
+	reversals, err := s.store.ListReversals(ctx, sql.NullInt64{Int64: transfer.ID, Valid: true})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// reversals move the money the other way in the same currency
+	resp := &pb.GetTransferResponse{
+		Transfer: convertTransfer(transfer, fromAccount.Currency),
+	}
+	for _, r := range reversals {
+		resp.Reversals = append(resp.Reversals, convertTransfer(r, fromAccount.Currency))
+	}
+	return resp, nil
+}
+
+func (s *Server) ListTransfers(ctx context.Context, in *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole, util.AuditorRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	violations := validatePage(in.PageId, in.PageSize)
+	if err := val.ValidateID(in.AccountId); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.getAccount(ctx, in.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Role == util.DepositorRole && account.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+
+	transfers, err := s.store.ListTransfers(withReadConsistency(ctx), store.ListTransfersParams{
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID,
+		Limit:         in.PageSize,
+		Offset:        (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListTransfersResponse{}
+	resp.Transfers, err = s.convertAccountTransfers(ctx, account, transfers)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// convertAccountTransfers converts the transfers of account. Incoming transfers may come from
+// another currency, their amount is in the currency of the source account.
+func (s *Server) convertAccountTransfers(ctx context.Context, account store.Account, transfers []store.Transfer) ([]*pb.Transfer, error) {
+	currencies := map[int64]string{account.ID: account.Currency}
+	result := make([]*pb.Transfer, 0, len(transfers))
+	for _, t := range transfers {
+		currency, ok := currencies[t.FromAccountID]
+		if !ok {
+			from, err := s.getAccount(ctx, t.FromAccountID)
+			if err != nil {
+				return nil, err
+			}
+			currency = from.Currency
+			currencies[from.ID] = currency
+		}
+		result = append(result, convertTransfer(t, currency))
+	}
+	return result, nil
+}
+
+func (s *Server) getTransfer(ctx context.Context, id int64) (store.Transfer, error) {
+	transfer, err := s.store.GetTransfer(withReadConsistency(ctx), id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return transfer, status.Errorf(codes.NotFound, "transfer %d not found", id)
+		}
+		return transfer, status.Error(codes.Internal, err.Error())
+	}
+	return transfer, nil
+}
+
+// getAccount loads an account and maps lookup failures to grpc errors.
+func (s *Server) getAccount(ctx context.Context, id int64) (store.Account, error) {
+	acc, err := s.store.GetAccount(withReadConsistency(ctx), id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return acc, status.Errorf(codes.NotFound, "account %d not found", id)
+		}
+		return acc, status.Error(codes.Internal, err.Error())
+	}
+	return acc, nil
+}
+
+// convertTransfer formats the amounts in the currency of the source account.
+func convertTransfer(t store.Transfer, currency string) *pb.Transfer {
+	transfer := &pb.Transfer{
+		Id:             t.ID,
+		FromAccountId:  t.FromAccountID,
+		ToAccountId:    t.ToAccountID,
+		Amount:         convertMoney(t.Amount, currency),
+		CreatedAt:      timestamppb.New(t.CreatedAt),
+		Status:         store.TransferStatus(t),
+		ReversedAmount: convertMoney(t.ReversedAmount, currency),
+		Fee:            convertMoney(t.Fee, currency),
+	}
+	if t.ReversalOf.Valid {
+		transfer.ReversalOf = &t.ReversalOf.Int64
+	}
+	return transfer
+}
+
+func convertEntry(e store.Entry, currency string) *pb.Entry {
+	return &pb.Entry{
+		Id:        e.ID,
+		AccountId: e.AccountID,
+		Amount:    convertMoney(e.Amount, currency),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
+
+func validateCreateTransferRequest(in *pb.CreateTransferRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.FromAccountId); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateID(in.ToAccountId); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	amount, errs := parseMoney("amount", in.Amount)
+	violations = append(violations, errs...)
+	if in.QuoteId != nil {
+		if _, err := uuid.Parse(*in.QuoteId); err != nil {
+			violations = append(violations, fieldViolation("quote_id", err))
+		}
+	}
+	return
+}
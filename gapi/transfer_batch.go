@@ -0,0 +1,280 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/anil1226/go-simplebank-grpc/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Batches with more items than this are executed by the worker and returned while pending.
+const syncTransferBatchSize = 100
+
+// maxTransferBatchSize matches val.ValidateBatchSize.
+const maxTransferBatchSize = 1000
+
+func (s *Server) CreateTransferBatch(ctx context.Context, in *pb.CreateTransferBatchRequest) (*pb.CreateTransferBatchResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	items, errs := validateCreateTransferBatchRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	fromAccount, err := s.getAccount(ctx, in.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Owner != payload.Username {
+		return nil, status.Error(codes.PermissionDenied, "account doesn't belong to authenticated user")
+	}
+
+	// every item is checked before anything is stored, so a batch never fails on bad input
+	toAccounts := map[int64]store.Account{}
+	for i, item := range items {
+		toAccount, ok := toAccounts[item.ToAccountID]
+		if !ok {
+			toAccount, err = s.getAccount(ctx, item.ToAccountID)
+			if err != nil {
+				return nil, err
+			}
+			toAccounts[item.ToAccountID] = toAccount
+		}
+		if toAccount.ID == fromAccount.ID {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: cannot transfer to the source account", i)
+		}
+		if store.IsSystemAccount(toAccount) {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: cannot transfer to a system account", i)
+		}
+		if in.Items[i].Amount.Currency != fromAccount.Currency || toAccount.Currency != fromAccount.Currency {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: currency mismatch", i)
+		}
+	}
+
+	arg := store.CreateTransferBatchParams{
+		Owner:         payload.Username,
+		FromAccountID: fromAccount.ID,
+		Currency:      fromAccount.Currency,
+		Mode:          in.Mode,
+	}
+	var batch store.TransferBatch
+	var created []store.TransferBatchItem
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		batch, created, err = q.CreateTransferBatchWithItems(ctx, arg, items)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		// small batches are executed right away, the task only finishes them if that fails
+		return store.AuditRecord{
+			Action:     "create_transfer_batch",
+			EntityType: "transfer_batch",
+			EntityID:   batch.ID,
+			After:      batch,
+		}, q.EnqueueTask(ctx, worker.ExecuteTransferBatchTask(batch.ID))
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(items) <= syncTransferBatchSize {
+		res, err := s.store.ExecuteTransferBatchTx(ctx, batch.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		batch = res.Batch
+		created, err = s.store.ListTransferBatchItems(ctx, store.ListTransferBatchItemsParams{
+			BatchID: batch.ID,
+			Limit:   int32(len(items)),
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	resp := &pb.CreateTransferBatchResponse{
+		Batch: convertTransferBatch(batch),
+	}
+	for _, item := range created {
+		resp.Items = append(resp.Items, convertTransferBatchItem(item, batch.Currency))
+	}
+	return resp, nil
+}
+
+// CreateTransferBatchStream collects the streamed items and creates the batch like
+// CreateTransferBatch once the client closes the stream.
+func (s *Server) CreateTransferBatchStream(stream pb.SimpleBank_CreateTransferBatchStreamServer) error {
+	if _, err := s.authorizeUser(stream.Context(), []string{util.DepositorRole, util.BankerRole, util.AdminRole}); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "missing batch header")
+		}
+		return err
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "the first message must be the batch header")
+	}
+
+	in := &pb.CreateTransferBatchRequest{
+		FromAccountId: header.FromAccountId,
+		Mode:          header.Mode,
+	}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		item := msg.GetItem()
+		if item == nil {
+			return status.Error(codes.InvalidArgument, "the batch header must only be sent once")
+		}
+		if len(in.Items) == maxTransferBatchSize {
+			return status.Errorf(codes.InvalidArgument, "a batch has at most %d items", maxTransferBatchSize)
+		}
+		in.Items = append(in.Items, item)
+	}
+
+	resp, err := s.CreateTransferBatch(stream.Context(), in)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+func (s *Server) GetTransferBatch(ctx context.Context, in *pb.GetTransferBatchRequest) (*pb.GetTransferBatchResponse, error) {
+	batch, err := s.getOwnTransferBatch(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTransferBatchResponse{
+		Batch: convertTransferBatch(batch),
+	}, nil
+}
+
+func (s *Server) ListTransferBatchItems(ctx context.Context, in *pb.ListTransferBatchItemsRequest) (*pb.ListTransferBatchItemsResponse, error) {
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	batch, err := s.getOwnTransferBatch(ctx, in.BatchId)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.ListTransferBatchItems(ctx, store.ListTransferBatchItemsParams{
+		BatchID: batch.ID,
+		Limit:   in.PageSize,
+		Offset:  (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListTransferBatchItemsResponse{}
+	for _, item := range items {
+		resp.Items = append(resp.Items, convertTransferBatchItem(item, batch.Currency))
+	}
+	return resp, nil
+}
+
+// getOwnTransferBatch authorizes the caller and loads one of their transfer batches.
+func (s *Server) getOwnTransferBatch(ctx context.Context, id int64) (store.TransferBatch, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return store.TransferBatch{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := val.ValidateID(id); err != nil {
+		return store.TransferBatch{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	batch, err := s.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return batch, status.Errorf(codes.NotFound, "transfer batch %d not found", id)
+		}
+		return batch, status.Error(codes.Internal, err.Error())
+	}
+	if batch.Owner != payload.Username {
+		return batch, status.Error(codes.PermissionDenied, "transfer batch doesn't belong to authenticated user")
+	}
+	return batch, nil
+}
+
+func convertTransferBatch(b store.TransferBatch) *pb.TransferBatch {
+	batch := &pb.TransferBatch{
+		Id:             b.ID,
+		FromAccountId:  b.FromAccountID,
+		Mode:           b.Mode,
+		Status:         b.Status,
+		ItemCount:      b.ItemCount,
+		SucceededCount: b.SucceededCount,
+		FailedCount:    b.FailedCount,
+		CreatedAt:      timestamppb.New(b.CreatedAt),
+	}
+	if b.FinishedAt.Valid {
+		batch.FinishedAt = timestamppb.New(b.FinishedAt.Time)
+	}
+	return batch
+}
+
+func convertTransferBatchItem(i store.TransferBatchItem, currency string) *pb.TransferBatchItem {
+	item := &pb.TransferBatchItem{
+		Position:    i.Position,
+		ToAccountId: i.ToAccountID,
+		Amount:      convertMoney(i.Amount, currency),
+		Status:      i.Status,
+		Error:       i.Error,
+	}
+	if i.TransferID.Valid {
+		item.TransferId = &i.TransferID.Int64
+	}
+	return item
+}
+
+func validateCreateTransferBatchRequest(in *pb.CreateTransferBatchRequest) (items []store.TransferBatchItemParams, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateID(in.FromAccountId); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateBatchMode(in.Mode); err != nil {
+		violations = append(violations, fieldViolation("mode", err))
+	}
+	if err := val.ValidateBatchSize(int32(len(in.Items))); err != nil {
+		violations = append(violations, fieldViolation("items", err))
+	}
+	for i, item := range in.Items {
+		if err := val.ValidateID(item.ToAccountId); err != nil {
+			violations = append(violations, fieldViolation(fmt.Sprintf("items[%d].to_account_id", i), err))
+		}
+		amount, errs := parseMoney(fmt.Sprintf("items[%d].amount", i), item.Amount)
+		violations = append(violations, errs...)
+		items = append(items, store.TransferBatchItemParams{
+			ToAccountID: item.ToAccountId,
+			Amount:      amount.Amount,
+		})
+	}
+	if violations != nil {
+		return nil, violations
+	}
+	return items, nil
+}
@@ -3,7 +3,6 @@ package gapi
 import (
 	"context"
 	"database/sql"
-	"log"
 	"time"
 
 	"github.com/anil1226/go-simplebank-grpc/pb"
@@ -11,8 +10,6 @@ import (
 	"github.com/anil1226/go-simplebank-grpc/util"
 	"github.com/anil1226/go-simplebank-grpc/val"
 	"github.com/anil1226/go-simplebank-grpc/worker"
-	"github.com/hibiken/asynq"
-	"github.com/lib/pq"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -29,6 +26,8 @@ func (s *Server) CreateUser(ctx context.Context, in *pb.CreateUserRequest) (*pb.
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// sign up is done by the new user
+	ctx = s.withAuditActor(ctx, in.Username)
 
 	arg := store.CreateUserTxParams{
 		CreateUserParams: store.CreateUserParams{
@@ -37,25 +36,19 @@ func (s *Server) CreateUser(ctx context.Context, in *pb.CreateUserRequest) (*pb.
 			FullName:       in.FullName,
 			Email:          in.Email,
 		},
-		AfterCreate: func(user store.User) error {
-			taskPayload := &worker.PayLoadSendVerifyEmail{
-				Username: user.Username,
-			}
-
-			opts := []asynq.Option{
-				asynq.MaxRetry(10),
-				asynq.ProcessIn(10 * time.Second),
-				asynq.Queue(worker.QueueCritical),
-			}
-			return s.taskDistributor.DistributeTaskSendVerifyEmail(ctx, taskPayload, opts...)
-
+		Tasks: []store.OutboxTask{
+			{
+				Type:     worker.TaskSendVerifyEmail,
+				Payload:  &worker.PayLoadSendVerifyEmail{Username: in.Username},
+				Queue:    worker.QueueCritical,
+				MaxRetry: 10,
+			},
 		},
 	}
 
 	usr, err := s.store.CreateUserTx(ctx, arg)
 	if err != nil {
-		if pq, ok := err.(*pq.Error); ok {
-			log.Println(pq.Code.Name())
+		if store.ErrorCode(err) == store.UniqueViolation {
 			return nil, status.Error(codes.AlreadyExists, err.Error())
 		}
 		return nil, status.Error(codes.Internal, err.Error())
@@ -68,6 +61,7 @@ func (s *Server) CreateUser(ctx context.Context, in *pb.CreateUserRequest) (*pb.
 			Email:             usr.User.Email,
 			PasswordChangedAt: timestamppb.New(usr.User.PasswordChangedAt),
 			CreatedAt:         timestamppb.New(usr.User.CreatedAt),
+			Tier:              usr.User.Tier,
 		},
 	}, nil
 }
@@ -86,12 +80,12 @@ func (s *Server) LoginUser(ctx context.Context, in *pb.LoginUserRequest) (*pb.Lo
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	accessToken, payload, err := s.tokenMaker.CreateToken(in.Username, s.config.AccessTokenDuration)
+	accessToken, payload, err := s.tokenMaker.CreateToken(in.Username, user.Role, s.config.AccessTokenDuration)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(in.Username, s.config.RefreshTokenDuration)
+	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(in.Username, user.Role, s.config.RefreshTokenDuration)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -174,8 +168,8 @@ func validateUpdateUserRequest(in *pb.UpdateUserRequest) (violations []*errdetai
 }
 
 func (s *Server) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-
-	payload, err := s.authorizeUser(ctx)
+	// users of every role may update their own profile
+	payload, err := s.authenticateUser(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
@@ -188,6 +182,7 @@ func (s *Server) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.
 	if payload.Username != in.Username {
 		return nil, status.Error(codes.PermissionDenied, "user not allowed")
 	}
+	ctx = s.withAuditActor(ctx, payload.Username)
 
 	arg := store.UpdateUserParams{
 		Username: in.Username,
@@ -216,10 +211,26 @@ func (s *Server) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.
 		}
 	}
 
-	usr, err := s.store.UpdateUser(ctx, arg)
+	var usr store.User
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		before, err := q.GetUserForUpdate(ctx, in.Username)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		usr, err = q.UpdateUser(ctx, arg)
+		if err == nil {
+			err = q.EmitEvent(ctx, store.UserUpdatedEvent(usr))
+		}
+		return store.AuditRecord{
+			Action:     "update_user",
+			EntityType: "user",
+			EntityID:   in.Username,
+			Before:     before,
+			After:      usr,
+		}, err
+	})
 	if err != nil {
-		if pq, ok := err.(*pq.Error); ok {
-			log.Println(pq.Code.Name())
+		if store.ErrorCode(err) == store.UniqueViolation {
 			return nil, status.Error(codes.AlreadyExists, err.Error())
 		}
 		return nil, status.Error(codes.Internal, err.Error())
@@ -231,6 +242,7 @@ func (s *Server) UpdateUser(ctx context.Context, in *pb.UpdateUserRequest) (*pb.
 			Email:             usr.Email,
 			PasswordChangedAt: timestamppb.New(usr.PasswordChangedAt),
 			CreatedAt:         timestamppb.New(usr.CreatedAt),
+			Tier:              usr.Tier,
 		},
 	}, nil
 }
@@ -0,0 +1,280 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/anil1226/go-simplebank-grpc/val"
+	"github.com/anil1226/go-simplebank-grpc/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateWebhookEndpoint(ctx context.Context, in *pb.CreateWebhookEndpointRequest) (*pb.CreateWebhookEndpointResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = s.withAuditActor(ctx, payload.Username)
+
+	errs := validateCreateWebhookEndpointRequest(in)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	secret, err := util.NewWebhookSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	arg := store.CreateWebhookEndpointParams{
+		Owner:      payload.Username,
+		Url:        in.Url,
+		Secret:     secret,
+		EventTypes: in.EventTypes,
+	}
+	if arg.EventTypes == nil {
+		arg.EventTypes = []string{}
+	}
+
+	var endpoint store.WebhookEndpoint
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		endpoint, err = q.CreateWebhookEndpoint(ctx, arg)
+		return store.AuditRecord{
+			Action:     "create_webhook_endpoint",
+			EntityType: "webhook_endpoint",
+			EntityID:   endpoint.ID,
+			After:      endpoint,
+		}, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CreateWebhookEndpointResponse{
+		Endpoint: convertWebhookEndpoint(endpoint),
+		Secret:   secret,
+	}, nil
+}
+
+func (s *Server) ListWebhookEndpoints(ctx context.Context, in *pb.ListWebhookEndpointsRequest) (*pb.ListWebhookEndpointsResponse, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	endpoints, err := s.store.ListWebhookEndpoints(ctx, payload.Username)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListWebhookEndpointsResponse{}
+	for _, endpoint := range endpoints {
+		resp.Endpoints = append(resp.Endpoints, convertWebhookEndpoint(endpoint))
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteWebhookEndpoint(ctx context.Context, in *pb.DeleteWebhookEndpointRequest) (*pb.DeleteWebhookEndpointResponse, error) {
+	endpoint, err := s.getOwnWebhookEndpoint(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// pending deliveries are deleted with the endpoint and their tasks skip them
+	ctx = s.withAuditActor(ctx, endpoint.Owner)
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		deleted, err := q.DeleteWebhookEndpoint(ctx, endpoint.ID)
+		return store.AuditRecord{
+			Action:     "delete_webhook_endpoint",
+			EntityType: "webhook_endpoint",
+			EntityID:   endpoint.ID,
+			Before:     deleted,
+		}, err
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "webhook endpoint %d not found", endpoint.ID)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.DeleteWebhookEndpointResponse{}, nil
+}
+
+func (s *Server) RotateWebhookSecret(ctx context.Context, in *pb.RotateWebhookSecretRequest) (*pb.RotateWebhookSecretResponse, error) {
+	endpoint, err := s.getOwnWebhookEndpoint(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := util.NewWebhookSecret()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ctx = s.withAuditActor(ctx, endpoint.Owner)
+	var rotated store.WebhookEndpoint
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		rotated, err = q.RotateWebhookSecret(ctx, store.RotateWebhookSecretParams{
+			PreviousSecretExpiresAt: sql.NullTime{Time: time.Now().Add(store.WebhookSecretOverlap), Valid: true},
+			Secret:                  secret,
+			ID:                      endpoint.ID,
+		})
+		return store.AuditRecord{
+			Action:     "rotate_webhook_secret",
+			EntityType: "webhook_endpoint",
+			EntityID:   endpoint.ID,
+			Before:     endpoint,
+			After:      rotated,
+		}, err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RotateWebhookSecretResponse{
+		Endpoint: convertWebhookEndpoint(rotated),
+		Secret:   secret,
+	}, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, in *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	errs := validatePage(in.PageId, in.PageSize)
+	if errs != nil {
+		return nil, invalidArgumentError(errs)
+	}
+
+	endpoint, err := s.getOwnWebhookEndpoint(ctx, in.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, store.ListWebhookDeliveriesParams{
+		EndpointID: endpoint.ID,
+		Limit:      in.PageSize,
+		Offset:     (in.PageId - 1) * in.PageSize,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, convertWebhookDelivery(delivery))
+	}
+	return resp, nil
+}
+
+func (s *Server) RedeliverWebhook(ctx context.Context, in *pb.RedeliverWebhookRequest) (*pb.RedeliverWebhookResponse, error) {
+	if err := val.ValidateID(in.DeliveryId); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("delivery_id", err)})
+	}
+
+	delivery, err := s.store.GetWebhookDelivery(ctx, in.DeliveryId)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "webhook delivery %d not found", in.DeliveryId)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	endpoint, err := s.getOwnWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == store.WebhookPending {
+		return nil, status.Error(codes.FailedPrecondition, "webhook delivery is still pending")
+	}
+
+	ctx = s.withAuditActor(ctx, endpoint.Owner)
+	var reset store.WebhookDelivery
+	err = s.store.AuditTx(ctx, func(q *store.Queries) (store.AuditRecord, error) {
+		reset, err = q.ResetWebhookDelivery(ctx, delivery.ID)
+		if err != nil {
+			return store.AuditRecord{}, err
+		}
+		return store.AuditRecord{
+			Action:     "redeliver_webhook",
+			EntityType: "webhook_delivery",
+			EntityID:   delivery.ID,
+			Before:     delivery,
+			After:      reset,
+		}, q.EnqueueTask(ctx, worker.DeliverWebhookTask(delivery.ID))
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.RedeliverWebhookResponse{
+		Delivery: convertWebhookDelivery(reset),
+	}, nil
+}
+
+// getOwnWebhookEndpoint authorizes the caller and loads one of their webhook endpoints.
+func (s *Server) getOwnWebhookEndpoint(ctx context.Context, id int64) (store.WebhookEndpoint, error) {
+	payload, err := s.authorizeUser(ctx, []string{util.DepositorRole, util.BankerRole, util.AdminRole})
+	if err != nil {
+		return store.WebhookEndpoint{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := val.ValidateID(id); err != nil {
+		return store.WebhookEndpoint{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	endpoint, err := s.store.GetWebhookEndpoint(ctx, id)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return endpoint, status.Errorf(codes.NotFound, "webhook endpoint %d not found", id)
+		}
+		return endpoint, status.Error(codes.Internal, err.Error())
+	}
+	if endpoint.Owner != payload.Username {
+		return endpoint, status.Error(codes.PermissionDenied, "webhook endpoint doesn't belong to authenticated user")
+	}
+	return endpoint, nil
+}
+
+func convertWebhookEndpoint(e store.WebhookEndpoint) *pb.WebhookEndpoint {
+	endpoint := &pb.WebhookEndpoint{
+		Id:         e.ID,
+		Url:        e.Url,
+		EventTypes: e.EventTypes,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+	if e.PreviousSecretExpiresAt.Valid {
+		endpoint.PreviousSecretExpiresAt = timestamppb.New(e.PreviousSecretExpiresAt.Time)
+	}
+	return endpoint
+}
+
+func convertWebhookDelivery(d store.WebhookDelivery) *pb.WebhookDelivery {
+	delivery := &pb.WebhookDelivery{
+		Id:           d.ID,
+		EndpointId:   d.EndpointID,
+		EventId:      d.EventID.String(),
+		EventType:    d.EventType,
+		Status:       d.Status,
+		Attempts:     d.Attempts,
+		ResponseCode: d.ResponseCode,
+		LastError:    d.LastError,
+		CreatedAt:    timestamppb.New(d.CreatedAt),
+		Payload:      string(d.Payload),
+	}
+	if d.LastAttemptAt.Valid {
+		delivery.LastAttemptAt = timestamppb.New(d.LastAttemptAt.Time)
+	}
+	return delivery
+}
+
+func validateCreateWebhookEndpointRequest(in *pb.CreateWebhookEndpointRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateWebhookURL(in.Url); err != nil {
+		violations = append(violations, fieldViolation("url", err))
+	}
+	for _, eventType := range in.EventTypes {
+		if err := val.ValidateWebhookEventType(eventType); err != nil {
+			violations = append(violations, fieldViolation("event_types", err))
+			break
+		}
+	}
+	return
+}
@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"expvar"
+	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/anil1226/go-simplebank-grpc/api"
 	_ "github.com/anil1226/go-simplebank-grpc/doc/statik"
+	"github.com/anil1226/go-simplebank-grpc/event"
 	"github.com/anil1226/go-simplebank-grpc/gapi"
 	"github.com/anil1226/go-simplebank-grpc/pb"
 	"github.com/anil1226/go-simplebank-grpc/store"
@@ -23,13 +27,14 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	_ "github.com/lib/pq"
 	"github.com/rakyll/statik/fs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const currencyRefreshInterval = time.Minute
+
 func main() {
 
 	config, err := util.LoadConfig(".")
@@ -41,14 +46,27 @@ func main() {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
 
-	conn, err := sql.Open(config.DbDriver, config.DbSource)
+	connPool, err := store.NewConnPool(context.Background(), config)
 	if err != nil {
-		log.Fatal().Msg("not able to connect to db")
+		log.Fatal().Err(err).Msg("not able to connect to db")
 	}
 
 	runDBMigration("file://migrations", config.DbSource)
 
-	store := store.NewStore(conn)
+	replicaPool := openReplicaPool(config)
+	store := store.NewStore(connPool, replicaPool)
+
+	// simplebank reconcile [-repair] checks the ledger once and exits
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcile(store, os.Args[2:])
+		return
+	}
+
+	go runCurrencyRefresher(store)
+
+	if config.FxRatesFile != "" {
+		loadFxRates(config.FxRatesFile, store)
+	}
 
 	redisOpts := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
@@ -56,17 +74,37 @@ func main() {
 
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpts)
 
+	go runOutboxRelay(store, taskDistributor)
+
+	go runEventPublisher(config, store)
+
 	go runTaskProcessor(redisOpts, store)
 
-	go runGatewayServer(config, store, taskDistributor)
+	go runScheduler(redisOpts)
+
+	go runGatewayServer(config, store)
 
-	runGRPCServer(config, store, taskDistributor)
+	runGRPCServer(config, store)
 
 	//runGinServer(config, store)
 	//basicHttpServer(config, store)
 
 }
 
+// openReplicaPool connects to the read replica, nil when there is none.
+func openReplicaPool(config util.Config) *pgxpool.Pool {
+	if config.DbReplicaSource == "" {
+		return nil
+	}
+	replicaConfig := config
+	replicaConfig.DbSource = config.DbReplicaSource
+	replicaPool, err := store.NewConnPool(context.Background(), replicaConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("not able to connect to the replica db")
+	}
+	return replicaPool
+}
+
 func runDBMigration(migrationURL string, dbSource string) {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
@@ -87,14 +125,14 @@ func basicHttpServer(config util.Config, store store.Store) {
 	http.ListenAndServe(":8090", nil)
 }
 
-func runGRPCServer(config util.Config, store store.Store, taskDistributor worker.TaskDistributor) {
+func runGRPCServer(config util.Config, store store.Store) {
 
-	server, err := gapi.NewServer(config, store, taskDistributor)
+	server, err := gapi.NewServer(config, store)
 	if err != nil {
 		log.Fatal().Msg("cannot create server")
 	}
-	logger := grpc.UnaryInterceptor(gapi.GrpcLogger)
-	grpcServer := grpc.NewServer(logger)
+	interceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.IdempotencyInterceptor)
+	grpcServer := grpc.NewServer(interceptors)
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
@@ -110,9 +148,9 @@ func runGRPCServer(config util.Config, store store.Store, taskDistributor worker
 	}
 }
 
-func runGatewayServer(config util.Config, store store.Store, taskDistributor worker.TaskDistributor) {
+func runGatewayServer(config util.Config, store store.Store) {
 
-	server, err := gapi.NewServer(config, store, taskDistributor)
+	server, err := gapi.NewServer(config, store)
 	if err != nil {
 		log.Fatal().Msg("cannot create server")
 	}
@@ -126,7 +164,9 @@ func runGatewayServer(config util.Config, store store.Store, taskDistributor wor
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	headerOption := runtime.WithIncomingHeaderMatcher(gapi.GatewayHeaderMatcher)
+
+	grpcMux := runtime.NewServeMux(jsonOption, headerOption)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
@@ -143,6 +183,7 @@ func runGatewayServer(config util.Config, store store.Store, taskDistributor wor
 	}
 	swagHandler := http.StripPrefix("/swagger/", http.FileServer(fs))
 	mux.Handle("/swagger/", swagHandler)
+	mux.Handle("/debug/vars", expvar.Handler())
 
 	lister, err := net.Listen("tcp", config.HTTPServerAddress)
 	if err != nil {
@@ -169,6 +210,46 @@ func runGinServer(config util.Config, store store.Store) {
 	}
 }
 
+// runCurrencyRefresher keeps the cached currency registry in sync with the currencies table.
+func runCurrencyRefresher(s store.Store) {
+	ticker := time.NewTicker(currencyRefreshInterval)
+	defer ticker.Stop()
+	for ; ; <-ticker.C {
+		if err := store.LoadCurrencies(context.Background(), s); err != nil {
+			log.Error().Err(err).Msg("cannot load currencies")
+		}
+	}
+}
+
+func loadFxRates(path string, store store.Store) {
+	rates, err := util.ReadFxRates(path)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot read fx rates file")
+	}
+	if _, err := gapi.UpsertFxRates(context.Background(), store, rates); err != nil {
+		log.Fatal().Err(err).Msg("cannot load fx rates")
+	}
+	log.Info().Int("count", len(rates)).Msg("fx rates loaded")
+}
+
+// runReconcile exits with status 1 when a finding is left unrepaired.
+func runReconcile(store store.Store, args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "rebuild mismatched balances from their entries")
+	flags.Parse(args)
+
+	res, err := worker.ReconcileLedger(context.Background(), store, *repair)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot reconcile ledger")
+	}
+	log.Info().Int64("run_id", res.Run.ID).Int64("findings", res.Run.Findings).Msg("ledger reconciled")
+	for _, f := range res.Findings {
+		if !f.Repaired {
+			os.Exit(1)
+		}
+	}
+}
+
 func runTaskProcessor(redisOpts asynq.RedisClientOpt, store store.Store) {
 	taskProcessor := worker.NewRedisTaskProcessor(redisOpts, store)
 	log.Info().Msg("start task processor")
@@ -177,3 +258,39 @@ func runTaskProcessor(redisOpts asynq.RedisClientOpt, store store.Store) {
 		log.Fatal().Err(err).Msg("failed to start task processor")
 	}
 }
+
+func runOutboxRelay(store store.Store, taskDistributor worker.TaskDistributor) {
+	relay := worker.NewOutboxRelay(store, taskDistributor)
+	log.Info().Msg("start outbox relay")
+	err := relay.Run(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("outbox relay stopped")
+	}
+}
+
+func runEventPublisher(config util.Config, store store.Store) {
+	sink, err := event.NewSink(config)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create event sink")
+	}
+	defer sink.Close()
+
+	publisher := worker.NewEventPublisher(store, sink)
+	log.Info().Str("sink", config.EventSink).Msg("start event publisher")
+	err = publisher.Run(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("event publisher stopped")
+	}
+}
+
+func runScheduler(redisOpts asynq.RedisClientOpt) {
+	scheduler, err := worker.NewScheduler(redisOpts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create scheduler")
+	}
+	log.Info().Msg("start scheduler")
+	err = scheduler.Run()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start scheduler")
+	}
+}
@@ -0,0 +1,17 @@
+CREATE TABLE "currencies" (
+  "code" varchar(3) PRIMARY KEY,
+  "minor_units" int NOT NULL DEFAULT 2,
+  "symbol" varchar NOT NULL DEFAULT '',
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 alphabetic code';
+
+COMMENT ON COLUMN "currencies"."minor_units" IS 'ISO 4217 exponent, 2 means amounts are stored in cents';
+
+INSERT INTO "currencies" ("code", "minor_units", "symbol") VALUES
+  ('USD', 2, '$'),
+  ('INR', 2, '₹');
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
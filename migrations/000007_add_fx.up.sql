@@ -0,0 +1,56 @@
+CREATE TABLE "fx_rates" (
+  "base_currency" varchar(3) NOT NULL,
+  "quote_currency" varchar(3) NOT NULL,
+  "rate" numeric NOT NULL,
+  "spread_bps" int NOT NULL DEFAULT 0,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("base_currency", "quote_currency")
+);
+
+CREATE TABLE "fx_quotes" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "house_from_account_id" bigint NOT NULL,
+  "house_to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "rate" numeric NOT NULL,
+  "converted_amount" bigint NOT NULL,
+  "spread_amount" bigint NOT NULL,
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "fx_rates"."rate" IS 'mid market rate, 1 unit of base_currency in quote_currency';
+
+COMMENT ON COLUMN "fx_quotes"."amount" IS 'debited from from_account_id, in its minor units';
+
+COMMENT ON COLUMN "fx_quotes"."rate" IS 'quoted rate after the spread';
+
+COMMENT ON COLUMN "fx_quotes"."converted_amount" IS 'credited to to_account_id, in its minor units';
+
+COMMENT ON COLUMN "fx_quotes"."spread_amount" IS 'booked to house_to_account_id, in its minor units';
+
+ALTER TABLE "fx_rates" ADD FOREIGN KEY ("base_currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fx_rates" ADD FOREIGN KEY ("quote_currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("house_from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("house_to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "fx_quotes" ("transfer_id");
+
+-- owner of the FX position accounts, it has no password and cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('fx_house', '', 'FX House', 'fx_house@simplebank.local');
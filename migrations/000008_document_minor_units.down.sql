@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "accounts"."balance" IS NULL;
+
+COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
+
+COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "accounts"."balance" IS 'in minor units of currency';
+
+COMMENT ON COLUMN "entries"."amount" IS 'in minor units of the account currency, can be negative or positive';
+
+COMMENT ON COLUMN "transfers"."amount" IS 'in minor units of the from account currency, must be positive';
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "entries_journal_balanced" ON "entries";
+
+DROP FUNCTION IF EXISTS "check_journal_balanced"();
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "journal_id";
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "journal_id";
+
+DROP TABLE IF EXISTS "journals";
@@ -0,0 +1,53 @@
+CREATE TABLE "journals" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "journals"."kind" IS 'transfer, fx_transfer, ...';
+
+COMMENT ON COLUMN "journals"."reference" IS 'free text describing the business event';
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "journal_id" bigint;
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'null for entries posted before journals existed';
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE INDEX ON "entries" ("journal_id");
+
+CREATE UNIQUE INDEX ON "transfers" ("journal_id");
+
+-- the postings of a journal must sum to zero per currency, checked when the transaction commits
+CREATE FUNCTION "check_journal_balanced"() RETURNS trigger AS $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "entries" e
+    JOIN "accounts" a ON a."id" = e."account_id"
+    WHERE e."journal_id" = NEW."journal_id"
+    GROUP BY a."currency"
+    HAVING SUM(e."amount") <> 0
+  ) THEN
+    RAISE EXCEPTION 'journal % does not balance', NEW."journal_id";
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "entries_journal_balanced"
+AFTER INSERT ON "entries"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+WHEN (NEW."journal_id" IS NOT NULL)
+EXECUTE FUNCTION "check_journal_balanced"();
+
+-- owners of the system accounts, they have no password and cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('sys_cash', '', 'Cash', 'sys_cash@simplebank.local'),
+  ('sys_fees', '', 'Fees', 'sys_fees@simplebank.local'),
+  ('sys_suspense', '', 'Suspense', 'sys_suspense@simplebank.local');
@@ -0,0 +1,28 @@
+CREATE TABLE "cash_receipts" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "teller" varchar NOT NULL,
+  "journal_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "cash_receipts"."kind" IS 'deposit or withdrawal';
+
+COMMENT ON COLUMN "cash_receipts"."amount" IS 'cash handed over, in minor units of currency, always positive';
+
+COMMENT ON COLUMN "cash_receipts"."teller" IS 'username of the teller who handled the cash';
+
+ALTER TABLE "cash_receipts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "cash_receipts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "cash_receipts" ADD FOREIGN KEY ("teller") REFERENCES "users" ("username");
+
+ALTER TABLE "cash_receipts" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE UNIQUE INDEX ON "cash_receipts" ("journal_id");
+
+CREATE INDEX ON "cash_receipts" ("teller", "created_at");
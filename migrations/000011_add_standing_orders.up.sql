@@ -0,0 +1,60 @@
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "schedule" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "start_at" timestamptz NOT NULL,
+  "end_at" timestamptz,
+  "max_executions" int,
+  "executions" int NOT NULL DEFAULT 0,
+  "next_run_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_order_runs" (
+  "id" bigserial PRIMARY KEY,
+  "standing_order_id" bigint NOT NULL,
+  "scheduled_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "standing_orders"."amount" IS 'in minor units of currency, must be positive';
+
+COMMENT ON COLUMN "standing_orders"."currency" IS 'currency of both accounts';
+
+COMMENT ON COLUMN "standing_orders"."schedule" IS 'cron expression, descriptor such as @monthly or interval such as @every 168h';
+
+COMMENT ON COLUMN "standing_orders"."status" IS 'active, paused, cancelled or completed';
+
+COMMENT ON COLUMN "standing_orders"."max_executions" IS 'null for no limit';
+
+COMMENT ON COLUMN "standing_orders"."executions" IS 'number of successful runs';
+
+COMMENT ON COLUMN "standing_orders"."next_run_at" IS 'null once the order is cancelled or completed';
+
+COMMENT ON COLUMN "standing_order_runs"."status" IS 'succeeded or failed';
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "standing_order_runs" ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+ALTER TABLE "standing_order_runs" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+CREATE UNIQUE INDEX ON "standing_order_runs" ("standing_order_id", "scheduled_at");
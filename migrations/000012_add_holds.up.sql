@@ -0,0 +1,41 @@
+ALTER TABLE "accounts" ADD COLUMN "held_balance" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD COLUMN "available_balance" bigint GENERATED ALWAYS AS ("balance" - "held_balance") STORED;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "held_balance_not_negative" CHECK ("held_balance" >= 0);
+
+COMMENT ON COLUMN "accounts"."balance" IS 'ledger balance, in minor units of currency';
+
+COMMENT ON COLUMN "accounts"."held_balance" IS 'sum of the active holds, in minor units of currency';
+
+COMMENT ON COLUMN "accounts"."available_balance" IS 'balance minus held_balance';
+
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "captured_amount" bigint NOT NULL DEFAULT 0,
+  "currency" varchar(3) NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("captured_amount" <= "amount")
+);
+
+COMMENT ON COLUMN "holds"."amount" IS 'authorized amount, in minor units of currency';
+
+COMMENT ON COLUMN "holds"."captured_amount" IS 'part of amount already transferred to to_account_id';
+
+COMMENT ON COLUMN "holds"."status" IS 'active, captured, voided or expired';
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+CREATE INDEX ON "holds" ("account_id");
+
+CREATE INDEX ON "holds" ("status", "expires_at");
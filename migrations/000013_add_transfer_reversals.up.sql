@@ -0,0 +1,13 @@
+ALTER TABLE "transfers" ADD COLUMN "reversal_of" bigint;
+
+ALTER TABLE "transfers" ADD COLUMN "reversed_amount" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "transfers" ADD CONSTRAINT "reversed_amount_within_amount" CHECK ("reversed_amount" BETWEEN 0 AND "amount");
+
+COMMENT ON COLUMN "transfers"."reversal_of" IS 'transfer this one reverses, in full or in part';
+
+COMMENT ON COLUMN "transfers"."reversed_amount" IS 'part of amount already reversed';
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversal_of") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfers" ("reversal_of");
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "closed_at";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "status_reason";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,9 @@
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts" ADD COLUMN "status_reason" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed';
+
+COMMENT ON COLUMN "accounts"."status_reason" IS 'why the account was last frozen, unfrozen or closed';
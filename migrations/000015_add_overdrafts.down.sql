@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "overdraft_charges";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "overdraft_interest_bps";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "overdraft_limit";
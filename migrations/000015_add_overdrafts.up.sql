@@ -0,0 +1,27 @@
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD COLUMN "overdraft_interest_bps" integer NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "overdraft_limit_not_negative" CHECK ("overdraft_limit" >= 0);
+
+COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'how far balance may go below zero, in minor units of currency';
+
+COMMENT ON COLUMN "accounts"."overdraft_interest_bps" IS 'yearly interest on a negative balance, in basis points';
+
+CREATE INDEX ON "accounts" ("balance") WHERE "balance" < 0;
+
+CREATE TABLE "overdraft_charges" (
+  "account_id" bigint NOT NULL,
+  "charged_on" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "journal_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "charged_on")
+);
+
+COMMENT ON COLUMN "overdraft_charges"."balance" IS 'negative balance the interest was charged on';
+
+ALTER TABLE "overdraft_charges" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_charges" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
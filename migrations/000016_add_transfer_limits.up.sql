@@ -0,0 +1,33 @@
+ALTER TABLE "users" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+
+CREATE TABLE "transfer_limits" (
+  "scope" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transfer" bigint,
+  "daily" bigint,
+  "monthly" bigint,
+  "hourly_count" integer,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("scope", "subject", "currency")
+);
+
+COMMENT ON COLUMN "transfer_limits"."scope" IS 'user or tier';
+
+COMMENT ON COLUMN "transfer_limits"."subject" IS 'username or tier the limits apply to';
+
+COMMENT ON COLUMN "transfer_limits"."daily" IS 'total sent per UTC day, in minor units of currency';
+
+COMMENT ON COLUMN "transfer_limits"."monthly" IS 'total sent per UTC calendar month, in minor units of currency';
+
+COMMENT ON COLUMN "transfer_limits"."hourly_count" IS 'number of transfers in any rolling hour';
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limit_scope" CHECK ("scope" IN ('user', 'tier'));
+
+ALTER TABLE "transfer_limits" ADD CONSTRAINT "transfer_limits_positive" CHECK (
+  "per_transfer" > 0 AND "daily" > 0 AND "monthly" > 0 AND "hourly_count" > 0
+);
+
+ALTER TABLE "transfer_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
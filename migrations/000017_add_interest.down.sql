@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_capitalizations";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "interest_product_id";
+
+DROP TABLE IF EXISTS "interest_products";
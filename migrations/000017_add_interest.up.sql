@@ -0,0 +1,78 @@
+CREATE TABLE "interest_products" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar UNIQUE NOT NULL,
+  "currency" varchar NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "day_count" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "interest_products"."annual_rate_bps" IS 'yearly interest paid on a positive balance, in basis points';
+
+COMMENT ON COLUMN "interest_products"."day_count" IS 'act/365, act/360, act/act or 30/360';
+
+ALTER TABLE "interest_products" ADD CONSTRAINT "interest_rate_not_negative" CHECK ("annual_rate_bps" >= 0);
+
+ALTER TABLE "interest_products" ADD CONSTRAINT "interest_day_count" CHECK ("day_count" IN ('act/365', 'act/360', 'act/act', '30/360'));
+
+ALTER TABLE "interest_products" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "accounts" ADD COLUMN "interest_product_id" bigint;
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("interest_product_id") REFERENCES "interest_products" ("id");
+
+CREATE INDEX ON "accounts" ("id") WHERE "interest_product_id" IS NOT NULL;
+
+CREATE TABLE "interest_capitalizations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carried_micros" bigint NOT NULL,
+  "journal_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("account_id", "period")
+);
+
+COMMENT ON COLUMN "interest_capitalizations"."period" IS 'first day of the month the interest was accrued in';
+
+COMMENT ON COLUMN "interest_capitalizations"."accrued_micros" IS 'accruals of the period plus what the previous period carried, in millionths of a minor unit';
+
+COMMENT ON COLUMN "interest_capitalizations"."amount" IS 'whole minor units posted to the account';
+
+COMMENT ON COLUMN "interest_capitalizations"."carried_micros" IS 'fraction of a minor unit left for the next period';
+
+COMMENT ON COLUMN "interest_capitalizations"."journal_id" IS 'null when less than a minor unit accrued';
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE TABLE "interest_accruals" (
+  "account_id" bigint NOT NULL,
+  "accrued_on" date NOT NULL,
+  "product_id" bigint NOT NULL,
+  "annual_rate_bps" integer NOT NULL,
+  "balance" bigint NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "capitalization_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "accrued_on")
+);
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'closing balance of the day the interest accrued on';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'in millionths of a minor unit of the account currency';
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("product_id") REFERENCES "interest_products" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("capitalization_id") REFERENCES "interest_capitalizations" ("id");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "capitalization_id" IS NULL;
+
+-- pays the interest credited to customers, its negative balance is the interest expense of the bank
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('sys_interest', '', 'Interest', 'sys_interest@simplebank.local');
@@ -0,0 +1,42 @@
+ALTER TABLE "accounts" ADD COLUMN "account_type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_type" CHECK ("account_type" IN ('checking', 'savings', 'business'));
+
+CREATE TABLE "fee_rules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "account_type" varchar,
+  "kind" varchar NOT NULL,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "rate_bps" integer NOT NULL DEFAULT 0,
+  "min_amount" bigint,
+  "max_amount" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "fee_rules"."account_type" IS 'type of the sending account, null for any type without a rule of its own';
+
+COMMENT ON COLUMN "fee_rules"."kind" IS 'flat or percentage';
+
+COMMENT ON COLUMN "fee_rules"."flat_amount" IS 'fee of a flat rule, in minor units of currency';
+
+COMMENT ON COLUMN "fee_rules"."rate_bps" IS 'fee of a percentage rule, in basis points of the amount';
+
+COMMENT ON COLUMN "fee_rules"."min_amount" IS 'lowest fee charged, in minor units of currency';
+
+COMMENT ON COLUMN "fee_rules"."max_amount" IS 'highest fee charged, in minor units of currency';
+
+ALTER TABLE "fee_rules" ADD CONSTRAINT "fee_rule_kind" CHECK ("kind" IN ('flat', 'percentage'));
+
+ALTER TABLE "fee_rules" ADD CONSTRAINT "fee_rule_amounts" CHECK (
+  "flat_amount" >= 0 AND "rate_bps" >= 0 AND "min_amount" >= 0 AND "max_amount" >= "min_amount"
+);
+
+ALTER TABLE "fee_rules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+CREATE UNIQUE INDEX "fee_rules_currency_account_type_idx" ON "fee_rules" ("currency", COALESCE("account_type", ''));
+
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "transfers"."fee" IS 'charged to the sender on top of amount, in minor units of the from account currency';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reconciliation_findings";
+
+DROP TABLE IF EXISTS "reconciliation_runs";
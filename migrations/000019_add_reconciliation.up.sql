@@ -0,0 +1,50 @@
+CREATE TABLE "reconciliation_runs" (
+  "id" bigserial PRIMARY KEY,
+  "repair" boolean NOT NULL DEFAULT false,
+  "last_account_id" bigint NOT NULL DEFAULT 0,
+  "last_journal_id" bigint NOT NULL DEFAULT 0,
+  "findings" bigint NOT NULL DEFAULT 0,
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "finished_at" timestamptz
+);
+
+COMMENT ON COLUMN "reconciliation_runs"."repair" IS 'whether mismatched balances were rebuilt from their entries';
+
+COMMENT ON COLUMN "reconciliation_runs"."last_account_id" IS 'accounts up to this id were checked';
+
+COMMENT ON COLUMN "reconciliation_runs"."last_journal_id" IS 'journals up to this id were checked';
+
+COMMENT ON COLUMN "reconciliation_runs"."finished_at" IS 'null while the run is in progress or when it failed';
+
+CREATE TABLE "reconciliation_findings" (
+  "id" bigserial PRIMARY KEY,
+  "run_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "account_id" bigint,
+  "journal_id" bigint,
+  "currency" varchar NOT NULL,
+  "expected" bigint NOT NULL,
+  "actual" bigint NOT NULL,
+  "repaired" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "reconciliation_findings"."kind" IS 'balance_mismatch or unbalanced_journal';
+
+COMMENT ON COLUMN "reconciliation_findings"."account_id" IS 'set for balance_mismatch';
+
+COMMENT ON COLUMN "reconciliation_findings"."journal_id" IS 'set for unbalanced_journal';
+
+COMMENT ON COLUMN "reconciliation_findings"."expected" IS 'sum of the entries of the account, or zero for a journal';
+
+COMMENT ON COLUMN "reconciliation_findings"."actual" IS 'balance of the account, or sum of the journal entries in currency';
+
+ALTER TABLE "reconciliation_findings" ADD CONSTRAINT "reconciliation_finding_kind" CHECK ("kind" IN ('balance_mismatch', 'unbalanced_journal'));
+
+ALTER TABLE "reconciliation_findings" ADD FOREIGN KEY ("run_id") REFERENCES "reconciliation_runs" ("id");
+
+ALTER TABLE "reconciliation_findings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "reconciliation_findings" ADD FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE INDEX ON "reconciliation_findings" ("run_id");
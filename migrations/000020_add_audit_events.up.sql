@@ -0,0 +1,46 @@
+CREATE TABLE "audit_events" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "entity_type" varchar NOT NULL,
+  "entity_id" varchar NOT NULL,
+  "before" jsonb NOT NULL DEFAULT 'null',
+  "after" jsonb NOT NULL DEFAULT 'null',
+  "client_ip" varchar NOT NULL,
+  "user_agent" varchar NOT NULL,
+  "request_id" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "audit_events"."actor" IS 'username of the caller, the new user for CreateUser';
+
+COMMENT ON COLUMN "audit_events"."action" IS 'create_transfer, freeze_account, ...';
+
+COMMENT ON COLUMN "audit_events"."before" IS 'entity before the change, JSON null when it was created';
+
+COMMENT ON COLUMN "audit_events"."after" IS 'entity after the change, JSON null when it was deleted';
+
+CREATE INDEX ON "audit_events" ("actor");
+
+CREATE INDEX ON "audit_events" ("entity_type", "entity_id");
+
+CREATE INDEX ON "audit_events" ("request_id");
+
+CREATE INDEX ON "audit_events" ("created_at");
+
+-- the audit log is append-only
+CREATE FUNCTION "reject_audit_event_change"() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit events cannot be changed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_events_append_only"
+BEFORE UPDATE OR DELETE ON "audit_events"
+FOR EACH ROW
+EXECUTE FUNCTION "reject_audit_event_change"();
+
+CREATE TRIGGER "audit_events_no_truncate"
+BEFORE TRUNCATE ON "audit_events"
+FOR EACH STATEMENT
+EXECUTE FUNCTION "reject_audit_event_change"();
@@ -0,0 +1,26 @@
+CREATE TABLE "outbox_messages" (
+  "id" bigserial PRIMARY KEY,
+  "task_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "queue" varchar NOT NULL,
+  "max_retry" int NOT NULL,
+  "task_id" varchar NOT NULL DEFAULT '',
+  "attempts" int NOT NULL DEFAULT 0,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "last_error" varchar NOT NULL DEFAULT '',
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "outbox_messages"."task_id" IS 'asynq task id, empty for tasks that may be enqueued more than once';
+
+COMMENT ON COLUMN "outbox_messages"."attempts" IS 'failed attempts to publish the message';
+
+COMMENT ON COLUMN "outbox_messages"."published_at" IS 'null while the message is pending';
+
+CREATE INDEX ON "outbox_messages" ("next_attempt_at") WHERE "published_at" IS NULL;
+
+CREATE INDEX ON "outbox_messages" ("published_at");
+
+-- a task is only written once while it is pending
+CREATE UNIQUE INDEX ON "outbox_messages" ("task_id") WHERE "task_id" <> '' AND "published_at" IS NULL;
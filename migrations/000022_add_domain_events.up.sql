@@ -0,0 +1,16 @@
+CREATE TABLE "domain_events" (
+  "id" bigserial PRIMARY KEY,
+  "event_id" uuid UNIQUE NOT NULL,
+  "event_type" varchar NOT NULL,
+  "version" int NOT NULL,
+  "payload" bytea NOT NULL,
+  "stream_offset" bigint UNIQUE,
+  "published_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "domain_events"."payload" IS 'protobuf encoded pb.DomainEvent, without its offset';
+
+COMMENT ON COLUMN "domain_events"."stream_offset" IS 'assigned in publishing order, null while the event is pending';
+
+CREATE INDEX ON "domain_events" ("id") WHERE "stream_offset" IS NULL;
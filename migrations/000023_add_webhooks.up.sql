@@ -0,0 +1,42 @@
+CREATE TABLE "webhook_endpoints" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "previous_secret" varchar NOT NULL DEFAULT '',
+  "previous_secret_expires_at" timestamptz,
+  "event_types" varchar[] NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "webhook_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "endpoint_id" bigint NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "response_code" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "last_attempt_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "webhook_endpoints"."previous_secret" IS 'signs deliveries next to secret until previous_secret_expires_at';
+
+COMMENT ON COLUMN "webhook_endpoints"."event_types" IS 'event types delivered to the endpoint, empty for all';
+
+COMMENT ON COLUMN "webhook_deliveries"."payload" IS 'request body, the same for every attempt';
+
+COMMENT ON COLUMN "webhook_deliveries"."status" IS 'pending, succeeded or failed';
+
+COMMENT ON COLUMN "webhook_deliveries"."response_code" IS 'HTTP status of the last attempt, 0 when there was no response';
+
+ALTER TABLE "webhook_endpoints" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "webhook_deliveries" ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "webhook_endpoints" ("owner");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id", "id");
@@ -0,0 +1,52 @@
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "item_count" int NOT NULL,
+  "succeeded_count" int NOT NULL DEFAULT 0,
+  "failed_count" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "finished_at" timestamptz
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "position" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "error" varchar NOT NULL DEFAULT ''
+);
+
+COMMENT ON COLUMN "transfer_batches"."currency" IS 'currency of the source account and of every item';
+
+COMMENT ON COLUMN "transfer_batches"."mode" IS 'atomic or best_effort';
+
+COMMENT ON COLUMN "transfer_batches"."status" IS 'pending, completed, partially_completed or failed';
+
+COMMENT ON COLUMN "transfer_batch_items"."position" IS 'index of the item in the request';
+
+COMMENT ON COLUMN "transfer_batch_items"."amount" IS 'in minor units of currency, must be positive';
+
+COMMENT ON COLUMN "transfer_batch_items"."status" IS 'pending, succeeded or failed';
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "position");
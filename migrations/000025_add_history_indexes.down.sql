@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS "transfers_from_account_id_created_at_idx" ON "transfers" ("from_account_id", "created_at");
+
+DROP INDEX IF EXISTS "transfers_to_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "entries_account_id_created_at_id_idx";
@@ -0,0 +1,13 @@
+CREATE TABLE "balance_snapshots" (
+  "account_id" bigint NOT NULL,
+  "as_of" timestamptz NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "as_of")
+);
+
+COMMENT ON COLUMN "balance_snapshots"."as_of" IS 'midnight UTC that ends the day of the snapshot';
+
+COMMENT ON COLUMN "balance_snapshots"."balance" IS 'sum of the entries of the account created before as_of';
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
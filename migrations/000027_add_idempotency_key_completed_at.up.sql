@@ -0,0 +1,5 @@
+ALTER TABLE "idempotency_keys" ADD COLUMN "completed_at" timestamptz;
+
+UPDATE "idempotency_keys" SET "completed_at" = "created_at";
+
+COMMENT ON COLUMN "idempotency_keys"."completed_at" IS 'null while the request is in progress';
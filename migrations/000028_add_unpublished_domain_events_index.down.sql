@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "domain_events_stream_offset_idx";
+
+COMMENT ON COLUMN "domain_events"."published_at" IS NULL;
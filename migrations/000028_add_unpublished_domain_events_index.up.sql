@@ -0,0 +1,3 @@
+COMMENT ON COLUMN "domain_events"."published_at" IS 'set once the sink took the event, null while it has to be published again';
+
+CREATE INDEX ON "domain_events" ("stream_offset") WHERE "stream_offset" IS NOT NULL AND "published_at" IS NULL;
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "fx_quotes"."spread_amount" IS 'booked to house_to_account_id, in its minor units';
+
+DELETE FROM "users" WHERE "username" = 'sys_fx_income' AND NOT EXISTS (
+  SELECT 1 FROM "accounts" WHERE "owner" = 'sys_fx_income'
+);
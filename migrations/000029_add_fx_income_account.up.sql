@@ -0,0 +1,5 @@
+-- owner of the accounts the FX spread is booked to, it has no password and cannot log in
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('sys_fx_income', '', 'FX Income', 'sys_fx_income@simplebank.local');
+
+COMMENT ON COLUMN "fx_quotes"."spread_amount" IS 'booked to the fx income system account, in the minor units of to_account_id';
@@ -0,0 +1,9 @@
+ALTER TABLE "idempotency_keys" ADD COLUMN "expires_at" timestamptz;
+
+UPDATE "idempotency_keys" SET "expires_at" = "created_at" + interval '24 hours';
+
+ALTER TABLE "idempotency_keys" ALTER COLUMN "expires_at" SET NOT NULL;
+
+CREATE INDEX ON "idempotency_keys" ("expires_at");
+
+COMMENT ON COLUMN "idempotency_keys"."expires_at" IS 'the key can be used again and is purged after this time';
@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_create_interest_product.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateInterestProductRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	AnnualRateBps int32  `protobuf:"varint,3,opt,name=annual_rate_bps,json=annualRateBps,proto3" json:"annual_rate_bps,omitempty"`
+	DayCount      string `protobuf:"bytes,4,opt,name=day_count,json=dayCount,proto3" json:"day_count,omitempty"`
+}
+
+func (x *CreateInterestProductRequest) Reset() {
+	*x = CreateInterestProductRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_interest_product_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInterestProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInterestProductRequest) ProtoMessage() {}
+
+func (x *CreateInterestProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_interest_product_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInterestProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateInterestProductRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_interest_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateInterestProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateInterestProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateInterestProductRequest) GetAnnualRateBps() int32 {
+	if x != nil {
+		return x.AnnualRateBps
+	}
+	return 0
+}
+
+func (x *CreateInterestProductRequest) GetDayCount() string {
+	if x != nil {
+		return x.DayCount
+	}
+	return ""
+}
+
+type CreateInterestProductResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Product *InterestProduct `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+}
+
+func (x *CreateInterestProductResponse) Reset() {
+	*x = CreateInterestProductResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_interest_product_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInterestProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInterestProductResponse) ProtoMessage() {}
+
+func (x *CreateInterestProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_interest_product_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInterestProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateInterestProductResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_interest_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateInterestProductResponse) GetProduct() *InterestProduct {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+var File_rpc_create_interest_product_proto protoreflect.FileDescriptor
+
+var file_rpc_create_interest_product_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x72, 0x70, 0x63, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x93, 0x01, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x6e, 0x6e, 0x75,
+	0x61, 0x6c, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x61, 0x6e, 0x6e, 0x75, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x42, 0x70, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4e, 0x0a,
+	0x1d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x42, 0x2b, 0x5a,
+	0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69, 0x6c,
+	0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61,
+	0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_create_interest_product_proto_rawDescOnce sync.Once
+	file_rpc_create_interest_product_proto_rawDescData = file_rpc_create_interest_product_proto_rawDesc
+)
+
+func file_rpc_create_interest_product_proto_rawDescGZIP() []byte {
+	file_rpc_create_interest_product_proto_rawDescOnce.Do(func() {
+		file_rpc_create_interest_product_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_create_interest_product_proto_rawDescData)
+	})
+	return file_rpc_create_interest_product_proto_rawDescData
+}
+
+var file_rpc_create_interest_product_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_create_interest_product_proto_goTypes = []interface{}{
+	(*CreateInterestProductRequest)(nil),  // 0: pb.CreateInterestProductRequest
+	(*CreateInterestProductResponse)(nil), // 1: pb.CreateInterestProductResponse
+	(*InterestProduct)(nil),               // 2: pb.InterestProduct
+}
+var file_rpc_create_interest_product_proto_depIdxs = []int32{
+	2, // 0: pb.CreateInterestProductResponse.product:type_name -> pb.InterestProduct
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_interest_product_proto_init() }
+func file_rpc_create_interest_product_proto_init() {
+	if File_rpc_create_interest_product_proto != nil {
+		return
+	}
+	file_interest_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_create_interest_product_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInterestProductRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_interest_product_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateInterestProductResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_create_interest_product_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_interest_product_proto_goTypes,
+		DependencyIndexes: file_rpc_create_interest_product_proto_depIdxs,
+		MessageInfos:      file_rpc_create_interest_product_proto_msgTypes,
+	}.Build()
+	File_rpc_create_interest_product_proto = out.File
+	file_rpc_create_interest_product_proto_rawDesc = nil
+	file_rpc_create_interest_product_proto_goTypes = nil
+	file_rpc_create_interest_product_proto_depIdxs = nil
+}
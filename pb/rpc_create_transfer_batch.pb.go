@@ -0,0 +1,515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_create_transfer_batch.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTransferBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromAccountId int64 `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	// atomic books all transfers or none, best_effort books every transfer it can
+	Mode  string                      `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Items []*TransferBatchItemRequest `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *CreateTransferBatchRequest) Reset() {
+	*x = CreateTransferBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_transfer_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferBatchRequest) ProtoMessage() {}
+
+func (x *CreateTransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_transfer_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_transfer_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTransferBatchRequest) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *CreateTransferBatchRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *CreateTransferBatchRequest) GetItems() []*TransferBatchItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type TransferBatchItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ToAccountId int64 `protobuf:"varint,1,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// in the currency of the source account
+	Amount *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *TransferBatchItemRequest) Reset() {
+	*x = TransferBatchItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_transfer_batch_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferBatchItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchItemRequest) ProtoMessage() {}
+
+func (x *TransferBatchItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_transfer_batch_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchItemRequest.ProtoReflect.Descriptor instead.
+func (*TransferBatchItemRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_transfer_batch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransferBatchItemRequest) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *TransferBatchItemRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// CreateTransferBatchStreamRequest sends the header of a batch first and then its items one
+// per message.
+type CreateTransferBatchStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*CreateTransferBatchStreamRequest_Header_
+	//	*CreateTransferBatchStreamRequest_Item
+	Request isCreateTransferBatchStreamRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *CreateTransferBatchStreamRequest) Reset() {
+	*x = CreateTransferBatchStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_transfer_batch_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTransferBatchStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferBatchStreamRequest) ProtoMessage() {}
+
+func (x *CreateTransferBatchStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_transfer_batch_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferBatchStreamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransferBatchStreamRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_create_transfer_batch_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *CreateTransferBatchStreamRequest) GetRequest() isCreateTransferBatchStreamRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *CreateTransferBatchStreamRequest) GetHeader() *CreateTransferBatchStreamRequest_Header {
+	if x, ok := x.GetRequest().(*CreateTransferBatchStreamRequest_Header_); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CreateTransferBatchStreamRequest) GetItem() *TransferBatchItemRequest {
+	if x, ok := x.GetRequest().(*CreateTransferBatchStreamRequest_Item); ok {
+		return x.Item
+	}
+	return nil
+}
+
+type isCreateTransferBatchStreamRequest_Request interface {
+	isCreateTransferBatchStreamRequest_Request()
+}
+
+type CreateTransferBatchStreamRequest_Header_ struct {
+	Header *CreateTransferBatchStreamRequest_Header `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type CreateTransferBatchStreamRequest_Item struct {
+	Item *TransferBatchItemRequest `protobuf:"bytes,2,opt,name=item,proto3,oneof"`
+}
+
+func (*CreateTransferBatchStreamRequest_Header_) isCreateTransferBatchStreamRequest_Request() {}
+
+func (*CreateTransferBatchStreamRequest_Item) isCreateTransferBatchStreamRequest_Request() {}
+
+type CreateTransferBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// still pending when the batch is large enough to be executed in the background
+	Batch *TransferBatch       `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Items []*TransferBatchItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *CreateTransferBatchResponse) Reset() {
+	*x = CreateTransferBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_transfer_batch_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTransferBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferBatchResponse) ProtoMessage() {}
+
+func (x *CreateTransferBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_transfer_batch_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferBatchResponse.ProtoReflect.Descriptor instead.
+func (*CreateTransferBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_create_transfer_batch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTransferBatchResponse) GetBatch() *TransferBatch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *CreateTransferBatchResponse) GetItems() []*TransferBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateTransferBatchStreamRequest_Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromAccountId int64  `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	Mode          string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *CreateTransferBatchStreamRequest_Header) Reset() {
+	*x = CreateTransferBatchStreamRequest_Header{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_create_transfer_batch_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTransferBatchStreamRequest_Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransferBatchStreamRequest_Header) ProtoMessage() {}
+
+func (x *CreateTransferBatchStreamRequest_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_create_transfer_batch_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransferBatchStreamRequest_Header.ProtoReflect.Descriptor instead.
+func (*CreateTransferBatchStreamRequest_Header) Descriptor() ([]byte, []int) {
+	return file_rpc_create_transfer_batch_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *CreateTransferBatchStreamRequest_Header) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *CreateTransferBatchStreamRequest_Header) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+var File_rpc_create_transfer_batch_proto protoreflect.FileDescriptor
+
+var file_rpc_create_transfer_batch_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x72, 0x70, 0x63, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0b, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x01, 0x0a, 0x1a, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x61, 0x0a, 0x18, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e,
+	0x65, 0x79, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xee, 0x01, 0x0a, 0x20, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x45, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x1a, 0x44, 0x0a, 0x06, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66,
+	0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x73, 0x0a, 0x1b, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_create_transfer_batch_proto_rawDescOnce sync.Once
+	file_rpc_create_transfer_batch_proto_rawDescData = file_rpc_create_transfer_batch_proto_rawDesc
+)
+
+func file_rpc_create_transfer_batch_proto_rawDescGZIP() []byte {
+	file_rpc_create_transfer_batch_proto_rawDescOnce.Do(func() {
+		file_rpc_create_transfer_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_create_transfer_batch_proto_rawDescData)
+	})
+	return file_rpc_create_transfer_batch_proto_rawDescData
+}
+
+var file_rpc_create_transfer_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_rpc_create_transfer_batch_proto_goTypes = []interface{}{
+	(*CreateTransferBatchRequest)(nil),              // 0: pb.CreateTransferBatchRequest
+	(*TransferBatchItemRequest)(nil),                // 1: pb.TransferBatchItemRequest
+	(*CreateTransferBatchStreamRequest)(nil),        // 2: pb.CreateTransferBatchStreamRequest
+	(*CreateTransferBatchResponse)(nil),             // 3: pb.CreateTransferBatchResponse
+	(*CreateTransferBatchStreamRequest_Header)(nil), // 4: pb.CreateTransferBatchStreamRequest.Header
+	(*Money)(nil),             // 5: pb.Money
+	(*TransferBatch)(nil),     // 6: pb.TransferBatch
+	(*TransferBatchItem)(nil), // 7: pb.TransferBatchItem
+}
+var file_rpc_create_transfer_batch_proto_depIdxs = []int32{
+	1, // 0: pb.CreateTransferBatchRequest.items:type_name -> pb.TransferBatchItemRequest
+	5, // 1: pb.TransferBatchItemRequest.amount:type_name -> pb.Money
+	4, // 2: pb.CreateTransferBatchStreamRequest.header:type_name -> pb.CreateTransferBatchStreamRequest.Header
+	1, // 3: pb.CreateTransferBatchStreamRequest.item:type_name -> pb.TransferBatchItemRequest
+	6, // 4: pb.CreateTransferBatchResponse.batch:type_name -> pb.TransferBatch
+	7, // 5: pb.CreateTransferBatchResponse.items:type_name -> pb.TransferBatchItem
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_rpc_create_transfer_batch_proto_init() }
+func file_rpc_create_transfer_batch_proto_init() {
+	if File_rpc_create_transfer_batch_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	file_transfer_batch_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_create_transfer_batch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTransferBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_transfer_batch_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferBatchItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_transfer_batch_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTransferBatchStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_transfer_batch_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTransferBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_create_transfer_batch_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTransferBatchStreamRequest_Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_create_transfer_batch_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*CreateTransferBatchStreamRequest_Header_)(nil),
+		(*CreateTransferBatchStreamRequest_Item)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_create_transfer_batch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_create_transfer_batch_proto_goTypes,
+		DependencyIndexes: file_rpc_create_transfer_batch_proto_depIdxs,
+		MessageInfos:      file_rpc_create_transfer_batch_proto_msgTypes,
+	}.Build()
+	File_rpc_create_transfer_batch_proto = out.File
+	file_rpc_create_transfer_batch_proto_rawDesc = nil
+	file_rpc_create_transfer_batch_proto_goTypes = nil
+	file_rpc_create_transfer_batch_proto_depIdxs = nil
+}
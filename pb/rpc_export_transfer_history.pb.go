@@ -0,0 +1,221 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_export_transfer_history.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExportTransferHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter *HistoryFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *ExportTransferHistoryRequest) Reset() {
+	*x = ExportTransferHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_export_transfer_history_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportTransferHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTransferHistoryRequest) ProtoMessage() {}
+
+func (x *ExportTransferHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_export_transfer_history_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTransferHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ExportTransferHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_export_transfer_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportTransferHistoryRequest) GetFilter() *HistoryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// ExportTransferHistoryResponse is one chunk of the export, newest first.
+type ExportTransferHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transfers []*Transfer `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+}
+
+func (x *ExportTransferHistoryResponse) Reset() {
+	*x = ExportTransferHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_export_transfer_history_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportTransferHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTransferHistoryResponse) ProtoMessage() {}
+
+func (x *ExportTransferHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_export_transfer_history_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTransferHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ExportTransferHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_export_transfer_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportTransferHistoryResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+var File_rpc_export_transfer_history_proto protoreflect.FileDescriptor
+
+var file_rpc_export_transfer_history_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x72, 0x70, 0x63, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0d, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x49, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x22, 0x4b, 0x0a, 0x1d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x42, 0x2b,
+	0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69,
+	0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62,
+	0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_export_transfer_history_proto_rawDescOnce sync.Once
+	file_rpc_export_transfer_history_proto_rawDescData = file_rpc_export_transfer_history_proto_rawDesc
+)
+
+func file_rpc_export_transfer_history_proto_rawDescGZIP() []byte {
+	file_rpc_export_transfer_history_proto_rawDescOnce.Do(func() {
+		file_rpc_export_transfer_history_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_export_transfer_history_proto_rawDescData)
+	})
+	return file_rpc_export_transfer_history_proto_rawDescData
+}
+
+var file_rpc_export_transfer_history_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_export_transfer_history_proto_goTypes = []interface{}{
+	(*ExportTransferHistoryRequest)(nil),  // 0: pb.ExportTransferHistoryRequest
+	(*ExportTransferHistoryResponse)(nil), // 1: pb.ExportTransferHistoryResponse
+	(*HistoryFilter)(nil),                 // 2: pb.HistoryFilter
+	(*Transfer)(nil),                      // 3: pb.Transfer
+}
+var file_rpc_export_transfer_history_proto_depIdxs = []int32{
+	2, // 0: pb.ExportTransferHistoryRequest.filter:type_name -> pb.HistoryFilter
+	3, // 1: pb.ExportTransferHistoryResponse.transfers:type_name -> pb.Transfer
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_export_transfer_history_proto_init() }
+func file_rpc_export_transfer_history_proto_init() {
+	if File_rpc_export_transfer_history_proto != nil {
+		return
+	}
+	file_history_proto_init()
+	file_transfer_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_export_transfer_history_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportTransferHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_export_transfer_history_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportTransferHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_export_transfer_history_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_export_transfer_history_proto_goTypes,
+		DependencyIndexes: file_rpc_export_transfer_history_proto_depIdxs,
+		MessageInfos:      file_rpc_export_transfer_history_proto_msgTypes,
+	}.Build()
+	File_rpc_export_transfer_history_proto = out.File
+	file_rpc_export_transfer_history_proto_rawDesc = nil
+	file_rpc_export_transfer_history_proto_goTypes = nil
+	file_rpc_export_transfer_history_proto_depIdxs = nil
+}
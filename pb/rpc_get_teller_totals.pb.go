@@ -0,0 +1,365 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_get_teller_totals.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetTellerTotalsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to the caller, only bankers and admins can ask for another teller
+	Teller *string `protobuf:"bytes,1,opt,name=teller,proto3,oneof" json:"teller,omitempty"`
+	// YYYY-MM-DD in UTC, defaults to today
+	Date *string `protobuf:"bytes,2,opt,name=date,proto3,oneof" json:"date,omitempty"`
+}
+
+func (x *GetTellerTotalsRequest) Reset() {
+	*x = GetTellerTotalsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_teller_totals_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTellerTotalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTellerTotalsRequest) ProtoMessage() {}
+
+func (x *GetTellerTotalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_teller_totals_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTellerTotalsRequest.ProtoReflect.Descriptor instead.
+func (*GetTellerTotalsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_teller_totals_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTellerTotalsRequest) GetTeller() string {
+	if x != nil && x.Teller != nil {
+		return *x.Teller
+	}
+	return ""
+}
+
+func (x *GetTellerTotalsRequest) GetDate() string {
+	if x != nil && x.Date != nil {
+		return *x.Date
+	}
+	return ""
+}
+
+type TellerTotal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency        string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Deposited       *Money `protobuf:"bytes,2,opt,name=deposited,proto3" json:"deposited,omitempty"`
+	DepositCount    int64  `protobuf:"varint,3,opt,name=deposit_count,json=depositCount,proto3" json:"deposit_count,omitempty"`
+	Withdrawn       *Money `protobuf:"bytes,4,opt,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	WithdrawalCount int64  `protobuf:"varint,5,opt,name=withdrawal_count,json=withdrawalCount,proto3" json:"withdrawal_count,omitempty"`
+	// deposited minus withdrawn, the change of cash in the till
+	Net *Money `protobuf:"bytes,6,opt,name=net,proto3" json:"net,omitempty"`
+}
+
+func (x *TellerTotal) Reset() {
+	*x = TellerTotal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_teller_totals_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TellerTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TellerTotal) ProtoMessage() {}
+
+func (x *TellerTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_teller_totals_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TellerTotal.ProtoReflect.Descriptor instead.
+func (*TellerTotal) Descriptor() ([]byte, []int) {
+	return file_rpc_get_teller_totals_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TellerTotal) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TellerTotal) GetDeposited() *Money {
+	if x != nil {
+		return x.Deposited
+	}
+	return nil
+}
+
+func (x *TellerTotal) GetDepositCount() int64 {
+	if x != nil {
+		return x.DepositCount
+	}
+	return 0
+}
+
+func (x *TellerTotal) GetWithdrawn() *Money {
+	if x != nil {
+		return x.Withdrawn
+	}
+	return nil
+}
+
+func (x *TellerTotal) GetWithdrawalCount() int64 {
+	if x != nil {
+		return x.WithdrawalCount
+	}
+	return 0
+}
+
+func (x *TellerTotal) GetNet() *Money {
+	if x != nil {
+		return x.Net
+	}
+	return nil
+}
+
+type GetTellerTotalsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Teller string         `protobuf:"bytes,1,opt,name=teller,proto3" json:"teller,omitempty"`
+	Date   string         `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Totals []*TellerTotal `protobuf:"bytes,3,rep,name=totals,proto3" json:"totals,omitempty"`
+}
+
+func (x *GetTellerTotalsResponse) Reset() {
+	*x = GetTellerTotalsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_get_teller_totals_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTellerTotalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTellerTotalsResponse) ProtoMessage() {}
+
+func (x *GetTellerTotalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_teller_totals_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTellerTotalsResponse.ProtoReflect.Descriptor instead.
+func (*GetTellerTotalsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_teller_totals_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTellerTotalsResponse) GetTeller() string {
+	if x != nil {
+		return x.Teller
+	}
+	return ""
+}
+
+func (x *GetTellerTotalsResponse) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GetTellerTotalsResponse) GetTotals() []*TellerTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+var File_rpc_get_teller_totals_proto protoreflect.FileDescriptor
+
+var file_rpc_get_teller_totals_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x65, 0x6c, 0x6c, 0x65, 0x72,
+	0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70,
+	0x62, 0x1a, 0x0b, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x74, 0x65, 0x6c, 0x6c,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x74, 0x65, 0x6c, 0x6c,
+	0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x74, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x22, 0xe8, 0x01, 0x0a, 0x0b, 0x54, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x27,
+	0x0a, 0x09, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x09, 0x64, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x09,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x09, 0x77, 0x69, 0x74, 0x68,
+	0x64, 0x72, 0x61, 0x77, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1b, 0x0a, 0x03, 0x6e, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x03, 0x6e, 0x65, 0x74, 0x22, 0x6e, 0x0a,
+	0x17, 0x47, 0x65, 0x74, 0x54, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6c, 0x6c,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6c, 0x6c, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x6c, 0x6c, 0x65, 0x72,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x42, 0x2b, 0x5a,
+	0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69, 0x6c,
+	0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61,
+	0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_get_teller_totals_proto_rawDescOnce sync.Once
+	file_rpc_get_teller_totals_proto_rawDescData = file_rpc_get_teller_totals_proto_rawDesc
+)
+
+func file_rpc_get_teller_totals_proto_rawDescGZIP() []byte {
+	file_rpc_get_teller_totals_proto_rawDescOnce.Do(func() {
+		file_rpc_get_teller_totals_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_get_teller_totals_proto_rawDescData)
+	})
+	return file_rpc_get_teller_totals_proto_rawDescData
+}
+
+var file_rpc_get_teller_totals_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_get_teller_totals_proto_goTypes = []interface{}{
+	(*GetTellerTotalsRequest)(nil),  // 0: pb.GetTellerTotalsRequest
+	(*TellerTotal)(nil),             // 1: pb.TellerTotal
+	(*GetTellerTotalsResponse)(nil), // 2: pb.GetTellerTotalsResponse
+	(*Money)(nil),                   // 3: pb.Money
+}
+var file_rpc_get_teller_totals_proto_depIdxs = []int32{
+	3, // 0: pb.TellerTotal.deposited:type_name -> pb.Money
+	3, // 1: pb.TellerTotal.withdrawn:type_name -> pb.Money
+	3, // 2: pb.TellerTotal.net:type_name -> pb.Money
+	1, // 3: pb.GetTellerTotalsResponse.totals:type_name -> pb.TellerTotal
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_teller_totals_proto_init() }
+func file_rpc_get_teller_totals_proto_init() {
+	if File_rpc_get_teller_totals_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_get_teller_totals_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTellerTotalsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_teller_totals_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TellerTotal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_get_teller_totals_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTellerTotalsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_get_teller_totals_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_get_teller_totals_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_teller_totals_proto_goTypes,
+		DependencyIndexes: file_rpc_get_teller_totals_proto_depIdxs,
+		MessageInfos:      file_rpc_get_teller_totals_proto_msgTypes,
+	}.Build()
+	File_rpc_get_teller_totals_proto = out.File
+	file_rpc_get_teller_totals_proto_rawDesc = nil
+	file_rpc_get_teller_totals_proto_goTypes = nil
+	file_rpc_get_teller_totals_proto_depIdxs = nil
+}
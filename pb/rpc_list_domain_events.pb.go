@@ -0,0 +1,226 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_list_domain_events.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListDomainEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// events after this offset are returned, 0 replays the stream from the start
+	AfterOffset int64 `protobuf:"varint,1,opt,name=after_offset,json=afterOffset,proto3" json:"after_offset,omitempty"`
+	PageSize    int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListDomainEventsRequest) Reset() {
+	*x = ListDomainEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_domain_events_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDomainEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainEventsRequest) ProtoMessage() {}
+
+func (x *ListDomainEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_domain_events_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListDomainEventsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_domain_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListDomainEventsRequest) GetAfterOffset() int64 {
+	if x != nil {
+		return x.AfterOffset
+	}
+	return 0
+}
+
+func (x *ListDomainEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListDomainEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// in offset order, only events already published to the sink
+	Events []*DomainEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *ListDomainEventsResponse) Reset() {
+	*x = ListDomainEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_domain_events_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDomainEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDomainEventsResponse) ProtoMessage() {}
+
+func (x *ListDomainEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_domain_events_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDomainEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListDomainEventsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_domain_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListDomainEventsResponse) GetEvents() []*DomainEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_rpc_list_domain_events_proto protoreflect.FileDescriptor
+
+var file_rpc_list_domain_events_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x1a, 0x12, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x59, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x22, 0x43, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x62, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f,
+	0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63,
+	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_domain_events_proto_rawDescOnce sync.Once
+	file_rpc_list_domain_events_proto_rawDescData = file_rpc_list_domain_events_proto_rawDesc
+)
+
+func file_rpc_list_domain_events_proto_rawDescGZIP() []byte {
+	file_rpc_list_domain_events_proto_rawDescOnce.Do(func() {
+		file_rpc_list_domain_events_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_domain_events_proto_rawDescData)
+	})
+	return file_rpc_list_domain_events_proto_rawDescData
+}
+
+var file_rpc_list_domain_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_domain_events_proto_goTypes = []interface{}{
+	(*ListDomainEventsRequest)(nil),  // 0: pb.ListDomainEventsRequest
+	(*ListDomainEventsResponse)(nil), // 1: pb.ListDomainEventsResponse
+	(*DomainEvent)(nil),              // 2: pb.DomainEvent
+}
+var file_rpc_list_domain_events_proto_depIdxs = []int32{
+	2, // 0: pb.ListDomainEventsResponse.events:type_name -> pb.DomainEvent
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_domain_events_proto_init() }
+func file_rpc_list_domain_events_proto_init() {
+	if File_rpc_list_domain_events_proto != nil {
+		return
+	}
+	file_domain_event_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_domain_events_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDomainEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_domain_events_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDomainEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_domain_events_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_domain_events_proto_goTypes,
+		DependencyIndexes: file_rpc_list_domain_events_proto_depIdxs,
+		MessageInfos:      file_rpc_list_domain_events_proto_msgTypes,
+	}.Build()
+	File_rpc_list_domain_events_proto = out.File
+	file_rpc_list_domain_events_proto_rawDesc = nil
+	file_rpc_list_domain_events_proto_goTypes = nil
+	file_rpc_list_domain_events_proto_depIdxs = nil
+}
@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_list_standing_orders.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListStandingOrdersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageId   int32 `protobuf:"varint,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListStandingOrdersRequest) Reset() {
+	*x = ListStandingOrdersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_standing_orders_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStandingOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStandingOrdersRequest) ProtoMessage() {}
+
+func (x *ListStandingOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_standing_orders_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStandingOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListStandingOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_standing_orders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListStandingOrdersRequest) GetPageId() int32 {
+	if x != nil {
+		return x.PageId
+	}
+	return 0
+}
+
+func (x *ListStandingOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListStandingOrdersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StandingOrders []*StandingOrder `protobuf:"bytes,1,rep,name=standing_orders,json=standingOrders,proto3" json:"standing_orders,omitempty"`
+}
+
+func (x *ListStandingOrdersResponse) Reset() {
+	*x = ListStandingOrdersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_standing_orders_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStandingOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStandingOrdersResponse) ProtoMessage() {}
+
+func (x *ListStandingOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_standing_orders_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStandingOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListStandingOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_standing_orders_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListStandingOrdersResponse) GetStandingOrders() []*StandingOrder {
+	if x != nil {
+		return x.StandingOrders
+	}
+	return nil
+}
+
+var File_rpc_list_standing_orders_proto protoreflect.FileDescriptor
+
+var file_rpc_list_standing_orders_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x51, 0x0a, 0x19, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x61, 0x67, 0x65, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x58, 0x0a,
+	0x1a, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0f, 0x73,
+	0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67,
+	0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70,
+	0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_standing_orders_proto_rawDescOnce sync.Once
+	file_rpc_list_standing_orders_proto_rawDescData = file_rpc_list_standing_orders_proto_rawDesc
+)
+
+func file_rpc_list_standing_orders_proto_rawDescGZIP() []byte {
+	file_rpc_list_standing_orders_proto_rawDescOnce.Do(func() {
+		file_rpc_list_standing_orders_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_standing_orders_proto_rawDescData)
+	})
+	return file_rpc_list_standing_orders_proto_rawDescData
+}
+
+var file_rpc_list_standing_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_standing_orders_proto_goTypes = []interface{}{
+	(*ListStandingOrdersRequest)(nil),  // 0: pb.ListStandingOrdersRequest
+	(*ListStandingOrdersResponse)(nil), // 1: pb.ListStandingOrdersResponse
+	(*StandingOrder)(nil),              // 2: pb.StandingOrder
+}
+var file_rpc_list_standing_orders_proto_depIdxs = []int32{
+	2, // 0: pb.ListStandingOrdersResponse.standing_orders:type_name -> pb.StandingOrder
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_standing_orders_proto_init() }
+func file_rpc_list_standing_orders_proto_init() {
+	if File_rpc_list_standing_orders_proto != nil {
+		return
+	}
+	file_standing_order_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_standing_orders_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStandingOrdersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_standing_orders_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStandingOrdersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_standing_orders_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_standing_orders_proto_goTypes,
+		DependencyIndexes: file_rpc_list_standing_orders_proto_depIdxs,
+		MessageInfos:      file_rpc_list_standing_orders_proto_msgTypes,
+	}.Build()
+	File_rpc_list_standing_orders_proto = out.File
+	file_rpc_list_standing_orders_proto_rawDesc = nil
+	file_rpc_list_standing_orders_proto_goTypes = nil
+	file_rpc_list_standing_orders_proto_depIdxs = nil
+}
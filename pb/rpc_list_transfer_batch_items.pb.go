@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_list_transfer_batch_items.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListTransferBatchItemsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId  int64 `protobuf:"varint,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	PageId   int32 `protobuf:"varint,2,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListTransferBatchItemsRequest) Reset() {
+	*x = ListTransferBatchItemsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_transfer_batch_items_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransferBatchItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferBatchItemsRequest) ProtoMessage() {}
+
+func (x *ListTransferBatchItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_transfer_batch_items_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferBatchItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransferBatchItemsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_list_transfer_batch_items_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListTransferBatchItemsRequest) GetBatchId() int64 {
+	if x != nil {
+		return x.BatchId
+	}
+	return 0
+}
+
+func (x *ListTransferBatchItemsRequest) GetPageId() int32 {
+	if x != nil {
+		return x.PageId
+	}
+	return 0
+}
+
+func (x *ListTransferBatchItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListTransferBatchItemsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// in request order
+	Items []*TransferBatchItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ListTransferBatchItemsResponse) Reset() {
+	*x = ListTransferBatchItemsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_list_transfer_batch_items_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransferBatchItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferBatchItemsResponse) ProtoMessage() {}
+
+func (x *ListTransferBatchItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_list_transfer_batch_items_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferBatchItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransferBatchItemsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_list_transfer_batch_items_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTransferBatchItemsResponse) GetItems() []*TransferBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_rpc_list_transfer_batch_items_proto protoreflect.FileDescriptor
+
+var file_rpc_list_transfer_batch_items_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x72, 0x70, 0x63, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x70, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x61,
+	0x67, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x22, 0x4d, 0x0a, 0x1e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_list_transfer_batch_items_proto_rawDescOnce sync.Once
+	file_rpc_list_transfer_batch_items_proto_rawDescData = file_rpc_list_transfer_batch_items_proto_rawDesc
+)
+
+func file_rpc_list_transfer_batch_items_proto_rawDescGZIP() []byte {
+	file_rpc_list_transfer_batch_items_proto_rawDescOnce.Do(func() {
+		file_rpc_list_transfer_batch_items_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_list_transfer_batch_items_proto_rawDescData)
+	})
+	return file_rpc_list_transfer_batch_items_proto_rawDescData
+}
+
+var file_rpc_list_transfer_batch_items_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_list_transfer_batch_items_proto_goTypes = []interface{}{
+	(*ListTransferBatchItemsRequest)(nil),  // 0: pb.ListTransferBatchItemsRequest
+	(*ListTransferBatchItemsResponse)(nil), // 1: pb.ListTransferBatchItemsResponse
+	(*TransferBatchItem)(nil),              // 2: pb.TransferBatchItem
+}
+var file_rpc_list_transfer_batch_items_proto_depIdxs = []int32{
+	2, // 0: pb.ListTransferBatchItemsResponse.items:type_name -> pb.TransferBatchItem
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_list_transfer_batch_items_proto_init() }
+func file_rpc_list_transfer_batch_items_proto_init() {
+	if File_rpc_list_transfer_batch_items_proto != nil {
+		return
+	}
+	file_transfer_batch_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_list_transfer_batch_items_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTransferBatchItemsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_list_transfer_batch_items_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTransferBatchItemsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_list_transfer_batch_items_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_list_transfer_batch_items_proto_goTypes,
+		DependencyIndexes: file_rpc_list_transfer_batch_items_proto_depIdxs,
+		MessageInfos:      file_rpc_list_transfer_batch_items_proto_msgTypes,
+	}.Build()
+	File_rpc_list_transfer_batch_items_proto = out.File
+	file_rpc_list_transfer_batch_items_proto_rawDesc = nil
+	file_rpc_list_transfer_batch_items_proto_goTypes = nil
+	file_rpc_list_transfer_batch_items_proto_depIdxs = nil
+}
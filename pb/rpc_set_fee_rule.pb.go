@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_set_fee_rule.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetFeeRuleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// unset applies the rule to every account type without a rule of its own
+	AccountType *string `protobuf:"bytes,2,opt,name=account_type,json=accountType,proto3,oneof" json:"account_type,omitempty"`
+	// flat or percentage
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// required for flat rules
+	FlatAmount *Money `protobuf:"bytes,4,opt,name=flat_amount,json=flatAmount,proto3" json:"flat_amount,omitempty"`
+	// required for percentage rules
+	RateBps   int32  `protobuf:"varint,5,opt,name=rate_bps,json=rateBps,proto3" json:"rate_bps,omitempty"`
+	MinAmount *Money `protobuf:"bytes,6,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount *Money `protobuf:"bytes,7,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+}
+
+func (x *SetFeeRuleRequest) Reset() {
+	*x = SetFeeRuleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_fee_rule_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFeeRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeRuleRequest) ProtoMessage() {}
+
+func (x *SetFeeRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_fee_rule_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeRuleRequest.ProtoReflect.Descriptor instead.
+func (*SetFeeRuleRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_fee_rule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetFeeRuleRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SetFeeRuleRequest) GetAccountType() string {
+	if x != nil && x.AccountType != nil {
+		return *x.AccountType
+	}
+	return ""
+}
+
+func (x *SetFeeRuleRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SetFeeRuleRequest) GetFlatAmount() *Money {
+	if x != nil {
+		return x.FlatAmount
+	}
+	return nil
+}
+
+func (x *SetFeeRuleRequest) GetRateBps() int32 {
+	if x != nil {
+		return x.RateBps
+	}
+	return 0
+}
+
+func (x *SetFeeRuleRequest) GetMinAmount() *Money {
+	if x != nil {
+		return x.MinAmount
+	}
+	return nil
+}
+
+func (x *SetFeeRuleRequest) GetMaxAmount() *Money {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+type SetFeeRuleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule *FeeRule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *SetFeeRuleResponse) Reset() {
+	*x = SetFeeRuleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_fee_rule_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFeeRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeRuleResponse) ProtoMessage() {}
+
+func (x *SetFeeRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_fee_rule_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeRuleResponse.ProtoReflect.Descriptor instead.
+func (*SetFeeRuleResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_fee_rule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetFeeRuleResponse) GetRule() *FeeRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+var File_rpc_set_fee_rule_proto protoreflect.FileDescriptor
+
+var file_rpc_set_fee_rule_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x75,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0e, 0x66, 0x65,
+	0x65, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x6d, 0x6f,
+	0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97, 0x02, 0x0a, 0x11, 0x53, 0x65,
+	0x74, 0x46, 0x65, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0c, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x74, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70,
+	0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0a, 0x66, 0x6c, 0x61, 0x74, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x62, 0x70, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x61, 0x74, 0x65, 0x42, 0x70, 0x73, 0x12, 0x28,
+	0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x09, 0x6d,
+	0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70,
+	0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x22, 0x35, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x46, 0x65, 0x65, 0x52, 0x75, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65,
+	0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32,
+	0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d,
+	0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_set_fee_rule_proto_rawDescOnce sync.Once
+	file_rpc_set_fee_rule_proto_rawDescData = file_rpc_set_fee_rule_proto_rawDesc
+)
+
+func file_rpc_set_fee_rule_proto_rawDescGZIP() []byte {
+	file_rpc_set_fee_rule_proto_rawDescOnce.Do(func() {
+		file_rpc_set_fee_rule_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_fee_rule_proto_rawDescData)
+	})
+	return file_rpc_set_fee_rule_proto_rawDescData
+}
+
+var file_rpc_set_fee_rule_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_fee_rule_proto_goTypes = []interface{}{
+	(*SetFeeRuleRequest)(nil),  // 0: pb.SetFeeRuleRequest
+	(*SetFeeRuleResponse)(nil), // 1: pb.SetFeeRuleResponse
+	(*Money)(nil),              // 2: pb.Money
+	(*FeeRule)(nil),            // 3: pb.FeeRule
+}
+var file_rpc_set_fee_rule_proto_depIdxs = []int32{
+	2, // 0: pb.SetFeeRuleRequest.flat_amount:type_name -> pb.Money
+	2, // 1: pb.SetFeeRuleRequest.min_amount:type_name -> pb.Money
+	2, // 2: pb.SetFeeRuleRequest.max_amount:type_name -> pb.Money
+	3, // 3: pb.SetFeeRuleResponse.rule:type_name -> pb.FeeRule
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_fee_rule_proto_init() }
+func file_rpc_set_fee_rule_proto_init() {
+	if File_rpc_set_fee_rule_proto != nil {
+		return
+	}
+	file_fee_rule_proto_init()
+	file_money_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_fee_rule_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFeeRuleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_fee_rule_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFeeRuleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_set_fee_rule_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_fee_rule_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_fee_rule_proto_goTypes,
+		DependencyIndexes: file_rpc_set_fee_rule_proto_depIdxs,
+		MessageInfos:      file_rpc_set_fee_rule_proto_msgTypes,
+	}.Build()
+	File_rpc_set_fee_rule_proto = out.File
+	file_rpc_set_fee_rule_proto_rawDesc = nil
+	file_rpc_set_fee_rule_proto_goTypes = nil
+	file_rpc_set_fee_rule_proto_depIdxs = nil
+}
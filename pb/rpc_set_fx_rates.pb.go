@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_set_fx_rates.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FxRate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseCurrency  string `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	// mid market rate, 1 unit of base_currency in quote_currency
+	Rate      string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	SpreadBps int32                  `protobuf:"varint,4,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *FxRate) Reset() {
+	*x = FxRate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_fx_rates_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FxRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FxRate) ProtoMessage() {}
+
+func (x *FxRate) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_fx_rates_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FxRate.ProtoReflect.Descriptor instead.
+func (*FxRate) Descriptor() ([]byte, []int) {
+	return file_rpc_set_fx_rates_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FxRate) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *FxRate) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *FxRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *FxRate) GetSpreadBps() int32 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+func (x *FxRate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetFxRatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rates []*FxRate `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+}
+
+func (x *SetFxRatesRequest) Reset() {
+	*x = SetFxRatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_fx_rates_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFxRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFxRatesRequest) ProtoMessage() {}
+
+func (x *SetFxRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_fx_rates_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFxRatesRequest.ProtoReflect.Descriptor instead.
+func (*SetFxRatesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_fx_rates_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetFxRatesRequest) GetRates() []*FxRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type SetFxRatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rates []*FxRate `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+}
+
+func (x *SetFxRatesResponse) Reset() {
+	*x = SetFxRatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_fx_rates_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFxRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFxRatesResponse) ProtoMessage() {}
+
+func (x *SetFxRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_fx_rates_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFxRatesResponse.ProtoReflect.Descriptor instead.
+func (*SetFxRatesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_fx_rates_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetFxRatesResponse) GetRates() []*FxRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+var File_rpc_set_fx_rates_proto protoreflect.FileDescriptor
+
+var file_rpc_set_fx_rates_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x66, 0x78, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc2, 0x01,
+	0x0a, 0x06, 0x46, 0x78, 0x52, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x62, 0x61, 0x73, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x25, 0x0a,
+	0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x72, 0x65,
+	0x61, 0x64, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x70,
+	0x72, 0x65, 0x61, 0x64, 0x42, 0x70, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0x35, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x46, 0x78, 0x52, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x78, 0x52, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x12, 0x53, 0x65, 0x74,
+	0x46, 0x78, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x20, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a,
+	0x2e, 0x70, 0x62, 0x2e, 0x46, 0x78, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65,
+	0x73, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x61, 0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_set_fx_rates_proto_rawDescOnce sync.Once
+	file_rpc_set_fx_rates_proto_rawDescData = file_rpc_set_fx_rates_proto_rawDesc
+)
+
+func file_rpc_set_fx_rates_proto_rawDescGZIP() []byte {
+	file_rpc_set_fx_rates_proto_rawDescOnce.Do(func() {
+		file_rpc_set_fx_rates_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_fx_rates_proto_rawDescData)
+	})
+	return file_rpc_set_fx_rates_proto_rawDescData
+}
+
+var file_rpc_set_fx_rates_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rpc_set_fx_rates_proto_goTypes = []interface{}{
+	(*FxRate)(nil),                // 0: pb.FxRate
+	(*SetFxRatesRequest)(nil),     // 1: pb.SetFxRatesRequest
+	(*SetFxRatesResponse)(nil),    // 2: pb.SetFxRatesResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_rpc_set_fx_rates_proto_depIdxs = []int32{
+	3, // 0: pb.FxRate.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 1: pb.SetFxRatesRequest.rates:type_name -> pb.FxRate
+	0, // 2: pb.SetFxRatesResponse.rates:type_name -> pb.FxRate
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_fx_rates_proto_init() }
+func file_rpc_set_fx_rates_proto_init() {
+	if File_rpc_set_fx_rates_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_fx_rates_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FxRate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_fx_rates_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFxRatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_fx_rates_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFxRatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_fx_rates_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_fx_rates_proto_goTypes,
+		DependencyIndexes: file_rpc_set_fx_rates_proto_depIdxs,
+		MessageInfos:      file_rpc_set_fx_rates_proto_msgTypes,
+	}.Build()
+	File_rpc_set_fx_rates_proto = out.File
+	file_rpc_set_fx_rates_proto_rawDesc = nil
+	file_rpc_set_fx_rates_proto_goTypes = nil
+	file_rpc_set_fx_rates_proto_depIdxs = nil
+}
@@ -0,0 +1,238 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_set_overdraft.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetOverdraftRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountId int64 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// zero removes the overdraft
+	Limit       *Money `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	InterestBps int32  `protobuf:"varint,3,opt,name=interest_bps,json=interestBps,proto3" json:"interest_bps,omitempty"`
+}
+
+func (x *SetOverdraftRequest) Reset() {
+	*x = SetOverdraftRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_overdraft_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetOverdraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOverdraftRequest) ProtoMessage() {}
+
+func (x *SetOverdraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_overdraft_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOverdraftRequest.ProtoReflect.Descriptor instead.
+func (*SetOverdraftRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_overdraft_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetOverdraftRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SetOverdraftRequest) GetLimit() *Money {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *SetOverdraftRequest) GetInterestBps() int32 {
+	if x != nil {
+		return x.InterestBps
+	}
+	return 0
+}
+
+type SetOverdraftResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+}
+
+func (x *SetOverdraftResponse) Reset() {
+	*x = SetOverdraftResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_overdraft_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetOverdraftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOverdraftResponse) ProtoMessage() {}
+
+func (x *SetOverdraftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_overdraft_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOverdraftResponse.ProtoReflect.Descriptor instead.
+func (*SetOverdraftResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_overdraft_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetOverdraftResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+var File_rpc_set_overdraft_proto protoreflect.FileDescriptor
+
+var file_rpc_set_overdraft_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x64, 0x72,
+	0x61, 0x66, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0d, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x6d, 0x6f,
+	0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x78, 0x0a, 0x13, 0x53, 0x65, 0x74,
+	0x4f, 0x76, 0x65, 0x72, 0x64, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x70, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x42, 0x70, 0x73, 0x22, 0x3d, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x64, 0x72,
+	0x61, 0x66, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70,
+	0x62, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x61, 0x6e, 0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_set_overdraft_proto_rawDescOnce sync.Once
+	file_rpc_set_overdraft_proto_rawDescData = file_rpc_set_overdraft_proto_rawDesc
+)
+
+func file_rpc_set_overdraft_proto_rawDescGZIP() []byte {
+	file_rpc_set_overdraft_proto_rawDescOnce.Do(func() {
+		file_rpc_set_overdraft_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_overdraft_proto_rawDescData)
+	})
+	return file_rpc_set_overdraft_proto_rawDescData
+}
+
+var file_rpc_set_overdraft_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_overdraft_proto_goTypes = []interface{}{
+	(*SetOverdraftRequest)(nil),  // 0: pb.SetOverdraftRequest
+	(*SetOverdraftResponse)(nil), // 1: pb.SetOverdraftResponse
+	(*Money)(nil),                // 2: pb.Money
+	(*Account)(nil),              // 3: pb.Account
+}
+var file_rpc_set_overdraft_proto_depIdxs = []int32{
+	2, // 0: pb.SetOverdraftRequest.limit:type_name -> pb.Money
+	3, // 1: pb.SetOverdraftResponse.account:type_name -> pb.Account
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_overdraft_proto_init() }
+func file_rpc_set_overdraft_proto_init() {
+	if File_rpc_set_overdraft_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_money_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_overdraft_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetOverdraftRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_overdraft_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetOverdraftResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_overdraft_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_overdraft_proto_goTypes,
+		DependencyIndexes: file_rpc_set_overdraft_proto_depIdxs,
+		MessageInfos:      file_rpc_set_overdraft_proto_msgTypes,
+	}.Build()
+	File_rpc_set_overdraft_proto = out.File
+	file_rpc_set_overdraft_proto_rawDesc = nil
+	file_rpc_set_overdraft_proto_goTypes = nil
+	file_rpc_set_overdraft_proto_depIdxs = nil
+}
@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: rpc_set_transfer_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetTransferLimitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// exactly one of username and tier
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Tier     string `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// unset limits are removed
+	PerTransfer *Money `protobuf:"bytes,4,opt,name=per_transfer,json=perTransfer,proto3" json:"per_transfer,omitempty"`
+	Daily       *Money `protobuf:"bytes,5,opt,name=daily,proto3" json:"daily,omitempty"`
+	Monthly     *Money `protobuf:"bytes,6,opt,name=monthly,proto3" json:"monthly,omitempty"`
+	HourlyCount *int32 `protobuf:"varint,7,opt,name=hourly_count,json=hourlyCount,proto3,oneof" json:"hourly_count,omitempty"`
+}
+
+func (x *SetTransferLimitRequest) Reset() {
+	*x = SetTransferLimitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_transfer_limit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTransferLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitRequest) ProtoMessage() {}
+
+func (x *SetTransferLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetTransferLimitRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SetTransferLimitRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *SetTransferLimitRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SetTransferLimitRequest) GetPerTransfer() *Money {
+	if x != nil {
+		return x.PerTransfer
+	}
+	return nil
+}
+
+func (x *SetTransferLimitRequest) GetDaily() *Money {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *SetTransferLimitRequest) GetMonthly() *Money {
+	if x != nil {
+		return x.Monthly
+	}
+	return nil
+}
+
+func (x *SetTransferLimitRequest) GetHourlyCount() int32 {
+	if x != nil && x.HourlyCount != nil {
+		return *x.HourlyCount
+	}
+	return 0
+}
+
+type SetTransferLimitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit *TransferLimit `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SetTransferLimitResponse) Reset() {
+	*x = SetTransferLimitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_set_transfer_limit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetTransferLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitResponse) ProtoMessage() {}
+
+func (x *SetTransferLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetTransferLimitResponse) GetLimit() *TransferLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+var File_rpc_set_transfer_limit_proto protoreflect.FileDescriptor
+
+var file_rpc_set_transfer_limit_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x70, 0x62, 0x1a, 0x0b, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x02, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2c, 0x0a,
+	0x0c, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x0b,
+	0x70, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x05, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x07,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
+	0x70, 0x62, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x07, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c,
+	0x79, 0x12, 0x26, 0x0a, 0x0c, 0x68, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0b, 0x68, 0x6f, 0x75, 0x72, 0x6c,
+	0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x68, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x43, 0x0a, 0x18, 0x53, 0x65,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e,
+	0x69, 0x6c, 0x31, 0x32, 0x32, 0x36, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x62, 0x61, 0x6e, 0x6b, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_set_transfer_limit_proto_rawDescOnce sync.Once
+	file_rpc_set_transfer_limit_proto_rawDescData = file_rpc_set_transfer_limit_proto_rawDesc
+)
+
+func file_rpc_set_transfer_limit_proto_rawDescGZIP() []byte {
+	file_rpc_set_transfer_limit_proto_rawDescOnce.Do(func() {
+		file_rpc_set_transfer_limit_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_set_transfer_limit_proto_rawDescData)
+	})
+	return file_rpc_set_transfer_limit_proto_rawDescData
+}
+
+var file_rpc_set_transfer_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_transfer_limit_proto_goTypes = []interface{}{
+	(*SetTransferLimitRequest)(nil),  // 0: pb.SetTransferLimitRequest
+	(*SetTransferLimitResponse)(nil), // 1: pb.SetTransferLimitResponse
+	(*Money)(nil),                    // 2: pb.Money
+	(*TransferLimit)(nil),            // 3: pb.TransferLimit
+}
+var file_rpc_set_transfer_limit_proto_depIdxs = []int32{
+	2, // 0: pb.SetTransferLimitRequest.per_transfer:type_name -> pb.Money
+	2, // 1: pb.SetTransferLimitRequest.daily:type_name -> pb.Money
+	2, // 2: pb.SetTransferLimitRequest.monthly:type_name -> pb.Money
+	3, // 3: pb.SetTransferLimitResponse.limit:type_name -> pb.TransferLimit
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_transfer_limit_proto_init() }
+func file_rpc_set_transfer_limit_proto_init() {
+	if File_rpc_set_transfer_limit_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	file_transfer_limit_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_set_transfer_limit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTransferLimitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_set_transfer_limit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetTransferLimitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rpc_set_transfer_limit_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_set_transfer_limit_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_transfer_limit_proto_goTypes,
+		DependencyIndexes: file_rpc_set_transfer_limit_proto_depIdxs,
+		MessageInfos:      file_rpc_set_transfer_limit_proto_msgTypes,
+	}.Build()
+	File_rpc_set_transfer_limit_proto = out.File
+	file_rpc_set_transfer_limit_proto_rawDesc = nil
+	file_rpc_set_transfer_limit_proto_goTypes = nil
+	file_rpc_set_transfer_limit_proto_depIdxs = nil
+}
@@ -19,9 +19,62 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SimpleBank_CreateUser_FullMethodName = "/pb.SimpleBank/CreateUser"
-	SimpleBank_LoginUser_FullMethodName  = "/pb.SimpleBank/LoginUser"
-	SimpleBank_UpdateUser_FullMethodName = "/pb.SimpleBank/UpdateUser"
+	SimpleBank_CreateUser_FullMethodName                = "/pb.SimpleBank/CreateUser"
+	SimpleBank_LoginUser_FullMethodName                 = "/pb.SimpleBank/LoginUser"
+	SimpleBank_UpdateUser_FullMethodName                = "/pb.SimpleBank/UpdateUser"
+	SimpleBank_CreateCurrency_FullMethodName            = "/pb.SimpleBank/CreateCurrency"
+	SimpleBank_UpdateCurrency_FullMethodName            = "/pb.SimpleBank/UpdateCurrency"
+	SimpleBank_ListCurrencies_FullMethodName            = "/pb.SimpleBank/ListCurrencies"
+	SimpleBank_CreateTransfer_FullMethodName            = "/pb.SimpleBank/CreateTransfer"
+	SimpleBank_QuoteTransfer_FullMethodName             = "/pb.SimpleBank/QuoteTransfer"
+	SimpleBank_SetFxRates_FullMethodName                = "/pb.SimpleBank/SetFxRates"
+	SimpleBank_Deposit_FullMethodName                   = "/pb.SimpleBank/Deposit"
+	SimpleBank_Withdraw_FullMethodName                  = "/pb.SimpleBank/Withdraw"
+	SimpleBank_GetTellerTotals_FullMethodName           = "/pb.SimpleBank/GetTellerTotals"
+	SimpleBank_CreateStandingOrder_FullMethodName       = "/pb.SimpleBank/CreateStandingOrder"
+	SimpleBank_ListStandingOrders_FullMethodName        = "/pb.SimpleBank/ListStandingOrders"
+	SimpleBank_PauseStandingOrder_FullMethodName        = "/pb.SimpleBank/PauseStandingOrder"
+	SimpleBank_ResumeStandingOrder_FullMethodName       = "/pb.SimpleBank/ResumeStandingOrder"
+	SimpleBank_CancelStandingOrder_FullMethodName       = "/pb.SimpleBank/CancelStandingOrder"
+	SimpleBank_ListStandingOrderRuns_FullMethodName     = "/pb.SimpleBank/ListStandingOrderRuns"
+	SimpleBank_CreateHold_FullMethodName                = "/pb.SimpleBank/CreateHold"
+	SimpleBank_CaptureHold_FullMethodName               = "/pb.SimpleBank/CaptureHold"
+	SimpleBank_VoidHold_FullMethodName                  = "/pb.SimpleBank/VoidHold"
+	SimpleBank_ReverseTransfer_FullMethodName           = "/pb.SimpleBank/ReverseTransfer"
+	SimpleBank_GetTransfer_FullMethodName               = "/pb.SimpleBank/GetTransfer"
+	SimpleBank_ListTransfers_FullMethodName             = "/pb.SimpleBank/ListTransfers"
+	SimpleBank_FreezeAccount_FullMethodName             = "/pb.SimpleBank/FreezeAccount"
+	SimpleBank_UnfreezeAccount_FullMethodName           = "/pb.SimpleBank/UnfreezeAccount"
+	SimpleBank_CloseAccount_FullMethodName              = "/pb.SimpleBank/CloseAccount"
+	SimpleBank_SetOverdraft_FullMethodName              = "/pb.SimpleBank/SetOverdraft"
+	SimpleBank_ListDebitBalances_FullMethodName         = "/pb.SimpleBank/ListDebitBalances"
+	SimpleBank_SetTransferLimit_FullMethodName          = "/pb.SimpleBank/SetTransferLimit"
+	SimpleBank_SetUserTier_FullMethodName               = "/pb.SimpleBank/SetUserTier"
+	SimpleBank_CreateInterestProduct_FullMethodName     = "/pb.SimpleBank/CreateInterestProduct"
+	SimpleBank_ListInterestProducts_FullMethodName      = "/pb.SimpleBank/ListInterestProducts"
+	SimpleBank_SetInterestProduct_FullMethodName        = "/pb.SimpleBank/SetInterestProduct"
+	SimpleBank_ListInterestAccruals_FullMethodName      = "/pb.SimpleBank/ListInterestAccruals"
+	SimpleBank_SetFeeRule_FullMethodName                = "/pb.SimpleBank/SetFeeRule"
+	SimpleBank_ListFeeRules_FullMethodName              = "/pb.SimpleBank/ListFeeRules"
+	SimpleBank_DeleteFeeRule_FullMethodName             = "/pb.SimpleBank/DeleteFeeRule"
+	SimpleBank_SetAccountType_FullMethodName            = "/pb.SimpleBank/SetAccountType"
+	SimpleBank_ListAuditEvents_FullMethodName           = "/pb.SimpleBank/ListAuditEvents"
+	SimpleBank_ListDomainEvents_FullMethodName          = "/pb.SimpleBank/ListDomainEvents"
+	SimpleBank_CreateWebhookEndpoint_FullMethodName     = "/pb.SimpleBank/CreateWebhookEndpoint"
+	SimpleBank_ListWebhookEndpoints_FullMethodName      = "/pb.SimpleBank/ListWebhookEndpoints"
+	SimpleBank_DeleteWebhookEndpoint_FullMethodName     = "/pb.SimpleBank/DeleteWebhookEndpoint"
+	SimpleBank_RotateWebhookSecret_FullMethodName       = "/pb.SimpleBank/RotateWebhookSecret"
+	SimpleBank_ListWebhookDeliveries_FullMethodName     = "/pb.SimpleBank/ListWebhookDeliveries"
+	SimpleBank_RedeliverWebhook_FullMethodName          = "/pb.SimpleBank/RedeliverWebhook"
+	SimpleBank_CreateTransferBatch_FullMethodName       = "/pb.SimpleBank/CreateTransferBatch"
+	SimpleBank_CreateTransferBatchStream_FullMethodName = "/pb.SimpleBank/CreateTransferBatchStream"
+	SimpleBank_GetTransferBatch_FullMethodName          = "/pb.SimpleBank/GetTransferBatch"
+	SimpleBank_ListTransferBatchItems_FullMethodName    = "/pb.SimpleBank/ListTransferBatchItems"
+	SimpleBank_ListTransferHistory_FullMethodName       = "/pb.SimpleBank/ListTransferHistory"
+	SimpleBank_ListEntryHistory_FullMethodName          = "/pb.SimpleBank/ListEntryHistory"
+	SimpleBank_ExportTransferHistory_FullMethodName     = "/pb.SimpleBank/ExportTransferHistory"
+	SimpleBank_ExportEntryHistory_FullMethodName        = "/pb.SimpleBank/ExportEntryHistory"
+	SimpleBank_GetBalanceAsOf_FullMethodName            = "/pb.SimpleBank/GetBalanceAsOf"
 )
 
 // SimpleBankClient is the client API for SimpleBank service.
@@ -31,6 +84,61 @@ type SimpleBankClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	LoginUser(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	CreateCurrency(ctx context.Context, in *CreateCurrencyRequest, opts ...grpc.CallOption) (*CreateCurrencyResponse, error)
+	UpdateCurrency(ctx context.Context, in *UpdateCurrencyRequest, opts ...grpc.CallOption) (*UpdateCurrencyResponse, error)
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error)
+	QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*QuoteTransferResponse, error)
+	SetFxRates(ctx context.Context, in *SetFxRatesRequest, opts ...grpc.CallOption) (*SetFxRatesResponse, error)
+	Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
+	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
+	GetTellerTotals(ctx context.Context, in *GetTellerTotalsRequest, opts ...grpc.CallOption) (*GetTellerTotalsResponse, error)
+	CreateStandingOrder(ctx context.Context, in *CreateStandingOrderRequest, opts ...grpc.CallOption) (*CreateStandingOrderResponse, error)
+	ListStandingOrders(ctx context.Context, in *ListStandingOrdersRequest, opts ...grpc.CallOption) (*ListStandingOrdersResponse, error)
+	PauseStandingOrder(ctx context.Context, in *PauseStandingOrderRequest, opts ...grpc.CallOption) (*PauseStandingOrderResponse, error)
+	ResumeStandingOrder(ctx context.Context, in *ResumeStandingOrderRequest, opts ...grpc.CallOption) (*ResumeStandingOrderResponse, error)
+	CancelStandingOrder(ctx context.Context, in *CancelStandingOrderRequest, opts ...grpc.CallOption) (*CancelStandingOrderResponse, error)
+	ListStandingOrderRuns(ctx context.Context, in *ListStandingOrderRunsRequest, opts ...grpc.CallOption) (*ListStandingOrderRunsResponse, error)
+	CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error)
+	VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error)
+	ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*ReverseTransferResponse, error)
+	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*GetTransferResponse, error)
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*FreezeAccountResponse, error)
+	UnfreezeAccount(ctx context.Context, in *UnfreezeAccountRequest, opts ...grpc.CallOption) (*UnfreezeAccountResponse, error)
+	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error)
+	SetOverdraft(ctx context.Context, in *SetOverdraftRequest, opts ...grpc.CallOption) (*SetOverdraftResponse, error)
+	ListDebitBalances(ctx context.Context, in *ListDebitBalancesRequest, opts ...grpc.CallOption) (*ListDebitBalancesResponse, error)
+	SetTransferLimit(ctx context.Context, in *SetTransferLimitRequest, opts ...grpc.CallOption) (*SetTransferLimitResponse, error)
+	SetUserTier(ctx context.Context, in *SetUserTierRequest, opts ...grpc.CallOption) (*SetUserTierResponse, error)
+	CreateInterestProduct(ctx context.Context, in *CreateInterestProductRequest, opts ...grpc.CallOption) (*CreateInterestProductResponse, error)
+	ListInterestProducts(ctx context.Context, in *ListInterestProductsRequest, opts ...grpc.CallOption) (*ListInterestProductsResponse, error)
+	SetInterestProduct(ctx context.Context, in *SetInterestProductRequest, opts ...grpc.CallOption) (*SetInterestProductResponse, error)
+	ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error)
+	SetFeeRule(ctx context.Context, in *SetFeeRuleRequest, opts ...grpc.CallOption) (*SetFeeRuleResponse, error)
+	ListFeeRules(ctx context.Context, in *ListFeeRulesRequest, opts ...grpc.CallOption) (*ListFeeRulesResponse, error)
+	DeleteFeeRule(ctx context.Context, in *DeleteFeeRuleRequest, opts ...grpc.CallOption) (*DeleteFeeRuleResponse, error)
+	SetAccountType(ctx context.Context, in *SetAccountTypeRequest, opts ...grpc.CallOption) (*SetAccountTypeResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	ListDomainEvents(ctx context.Context, in *ListDomainEventsRequest, opts ...grpc.CallOption) (*ListDomainEventsResponse, error)
+	CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*CreateWebhookEndpointResponse, error)
+	ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error)
+	RotateWebhookSecret(ctx context.Context, in *RotateWebhookSecretRequest, opts ...grpc.CallOption) (*RotateWebhookSecretResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error)
+	CreateTransferBatch(ctx context.Context, in *CreateTransferBatchRequest, opts ...grpc.CallOption) (*CreateTransferBatchResponse, error)
+	// the gateway cannot stream requests, so this one is only served over grpc
+	CreateTransferBatchStream(ctx context.Context, opts ...grpc.CallOption) (SimpleBank_CreateTransferBatchStreamClient, error)
+	GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error)
+	ListTransferBatchItems(ctx context.Context, in *ListTransferBatchItemsRequest, opts ...grpc.CallOption) (*ListTransferBatchItemsResponse, error)
+	ListTransferHistory(ctx context.Context, in *ListTransferHistoryRequest, opts ...grpc.CallOption) (*ListTransferHistoryResponse, error)
+	ListEntryHistory(ctx context.Context, in *ListEntryHistoryRequest, opts ...grpc.CallOption) (*ListEntryHistoryResponse, error)
+	// the gateway cannot stream responses, so the exports are only served over grpc
+	ExportTransferHistory(ctx context.Context, in *ExportTransferHistoryRequest, opts ...grpc.CallOption) (SimpleBank_ExportTransferHistoryClient, error)
+	ExportEntryHistory(ctx context.Context, in *ExportEntryHistoryRequest, opts ...grpc.CallOption) (SimpleBank_ExportEntryHistoryClient, error)
+	GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error)
 }
 
 type simpleBankClient struct {
@@ -68,6 +176,554 @@ func (c *simpleBankClient) UpdateUser(ctx context.Context, in *UpdateUserRequest
 	return out, nil
 }
 
+func (c *simpleBankClient) CreateCurrency(ctx context.Context, in *CreateCurrencyRequest, opts ...grpc.CallOption) (*CreateCurrencyResponse, error) {
+	out := new(CreateCurrencyResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateCurrency_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) UpdateCurrency(ctx context.Context, in *UpdateCurrencyRequest, opts ...grpc.CallOption) (*UpdateCurrencyResponse, error) {
+	out := new(UpdateCurrencyResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_UpdateCurrency_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListCurrencies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*CreateTransferResponse, error) {
+	out := new(CreateTransferResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) QuoteTransfer(ctx context.Context, in *QuoteTransferRequest, opts ...grpc.CallOption) (*QuoteTransferResponse, error) {
+	out := new(QuoteTransferResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_QuoteTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetFxRates(ctx context.Context, in *SetFxRatesRequest, opts ...grpc.CallOption) (*SetFxRatesResponse, error) {
+	out := new(SetFxRatesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetFxRates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error) {
+	out := new(DepositResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_Deposit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error) {
+	out := new(WithdrawResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_Withdraw_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetTellerTotals(ctx context.Context, in *GetTellerTotalsRequest, opts ...grpc.CallOption) (*GetTellerTotalsResponse, error) {
+	out := new(GetTellerTotalsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetTellerTotals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateStandingOrder(ctx context.Context, in *CreateStandingOrderRequest, opts ...grpc.CallOption) (*CreateStandingOrderResponse, error) {
+	out := new(CreateStandingOrderResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateStandingOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListStandingOrders(ctx context.Context, in *ListStandingOrdersRequest, opts ...grpc.CallOption) (*ListStandingOrdersResponse, error) {
+	out := new(ListStandingOrdersResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListStandingOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) PauseStandingOrder(ctx context.Context, in *PauseStandingOrderRequest, opts ...grpc.CallOption) (*PauseStandingOrderResponse, error) {
+	out := new(PauseStandingOrderResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_PauseStandingOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ResumeStandingOrder(ctx context.Context, in *ResumeStandingOrderRequest, opts ...grpc.CallOption) (*ResumeStandingOrderResponse, error) {
+	out := new(ResumeStandingOrderResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ResumeStandingOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CancelStandingOrder(ctx context.Context, in *CancelStandingOrderRequest, opts ...grpc.CallOption) (*CancelStandingOrderResponse, error) {
+	out := new(CancelStandingOrderResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CancelStandingOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListStandingOrderRuns(ctx context.Context, in *ListStandingOrderRunsRequest, opts ...grpc.CallOption) (*ListStandingOrderRunsResponse, error) {
+	out := new(ListStandingOrderRunsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListStandingOrderRuns_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*CreateHoldResponse, error) {
+	out := new(CreateHoldResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateHold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error) {
+	out := new(CaptureHoldResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CaptureHold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error) {
+	out := new(VoidHoldResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_VoidHold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*ReverseTransferResponse, error) {
+	out := new(ReverseTransferResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ReverseTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*GetTransferResponse, error) {
+	out := new(GetTransferResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetTransfer_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListTransfers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*FreezeAccountResponse, error) {
+	out := new(FreezeAccountResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_FreezeAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) UnfreezeAccount(ctx context.Context, in *UnfreezeAccountRequest, opts ...grpc.CallOption) (*UnfreezeAccountResponse, error) {
+	out := new(UnfreezeAccountResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_UnfreezeAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*CloseAccountResponse, error) {
+	out := new(CloseAccountResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CloseAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetOverdraft(ctx context.Context, in *SetOverdraftRequest, opts ...grpc.CallOption) (*SetOverdraftResponse, error) {
+	out := new(SetOverdraftResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetOverdraft_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListDebitBalances(ctx context.Context, in *ListDebitBalancesRequest, opts ...grpc.CallOption) (*ListDebitBalancesResponse, error) {
+	out := new(ListDebitBalancesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListDebitBalances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetTransferLimit(ctx context.Context, in *SetTransferLimitRequest, opts ...grpc.CallOption) (*SetTransferLimitResponse, error) {
+	out := new(SetTransferLimitResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetTransferLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetUserTier(ctx context.Context, in *SetUserTierRequest, opts ...grpc.CallOption) (*SetUserTierResponse, error) {
+	out := new(SetUserTierResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetUserTier_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateInterestProduct(ctx context.Context, in *CreateInterestProductRequest, opts ...grpc.CallOption) (*CreateInterestProductResponse, error) {
+	out := new(CreateInterestProductResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateInterestProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListInterestProducts(ctx context.Context, in *ListInterestProductsRequest, opts ...grpc.CallOption) (*ListInterestProductsResponse, error) {
+	out := new(ListInterestProductsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListInterestProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetInterestProduct(ctx context.Context, in *SetInterestProductRequest, opts ...grpc.CallOption) (*SetInterestProductResponse, error) {
+	out := new(SetInterestProductResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetInterestProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListInterestAccruals(ctx context.Context, in *ListInterestAccrualsRequest, opts ...grpc.CallOption) (*ListInterestAccrualsResponse, error) {
+	out := new(ListInterestAccrualsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListInterestAccruals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetFeeRule(ctx context.Context, in *SetFeeRuleRequest, opts ...grpc.CallOption) (*SetFeeRuleResponse, error) {
+	out := new(SetFeeRuleResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetFeeRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListFeeRules(ctx context.Context, in *ListFeeRulesRequest, opts ...grpc.CallOption) (*ListFeeRulesResponse, error) {
+	out := new(ListFeeRulesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListFeeRules_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) DeleteFeeRule(ctx context.Context, in *DeleteFeeRuleRequest, opts ...grpc.CallOption) (*DeleteFeeRuleResponse, error) {
+	out := new(DeleteFeeRuleResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_DeleteFeeRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) SetAccountType(ctx context.Context, in *SetAccountTypeRequest, opts ...grpc.CallOption) (*SetAccountTypeResponse, error) {
+	out := new(SetAccountTypeResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_SetAccountType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListAuditEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListDomainEvents(ctx context.Context, in *ListDomainEventsRequest, opts ...grpc.CallOption) (*ListDomainEventsResponse, error) {
+	out := new(ListDomainEventsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListDomainEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*CreateWebhookEndpointResponse, error) {
+	out := new(CreateWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateWebhookEndpoint_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error) {
+	out := new(ListWebhookEndpointsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListWebhookEndpoints_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*DeleteWebhookEndpointResponse, error) {
+	out := new(DeleteWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_DeleteWebhookEndpoint_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RotateWebhookSecret(ctx context.Context, in *RotateWebhookSecretRequest, opts ...grpc.CallOption) (*RotateWebhookSecretResponse, error) {
+	out := new(RotateWebhookSecretResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_RotateWebhookSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error) {
+	out := new(RedeliverWebhookResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_RedeliverWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateTransferBatch(ctx context.Context, in *CreateTransferBatchRequest, opts ...grpc.CallOption) (*CreateTransferBatchResponse, error) {
+	out := new(CreateTransferBatchResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_CreateTransferBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) CreateTransferBatchStream(ctx context.Context, opts ...grpc.CallOption) (SimpleBank_CreateTransferBatchStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SimpleBank_ServiceDesc.Streams[0], SimpleBank_CreateTransferBatchStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simpleBankCreateTransferBatchStreamClient{stream}
+	return x, nil
+}
+
+type SimpleBank_CreateTransferBatchStreamClient interface {
+	Send(*CreateTransferBatchStreamRequest) error
+	CloseAndRecv() (*CreateTransferBatchResponse, error)
+	grpc.ClientStream
+}
+
+type simpleBankCreateTransferBatchStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *simpleBankCreateTransferBatchStreamClient) Send(m *CreateTransferBatchStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *simpleBankCreateTransferBatchStreamClient) CloseAndRecv() (*CreateTransferBatchResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CreateTransferBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *simpleBankClient) GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error) {
+	out := new(GetTransferBatchResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetTransferBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListTransferBatchItems(ctx context.Context, in *ListTransferBatchItemsRequest, opts ...grpc.CallOption) (*ListTransferBatchItemsResponse, error) {
+	out := new(ListTransferBatchItemsResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListTransferBatchItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListTransferHistory(ctx context.Context, in *ListTransferHistoryRequest, opts ...grpc.CallOption) (*ListTransferHistoryResponse, error) {
+	out := new(ListTransferHistoryResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListTransferHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ListEntryHistory(ctx context.Context, in *ListEntryHistoryRequest, opts ...grpc.CallOption) (*ListEntryHistoryResponse, error) {
+	out := new(ListEntryHistoryResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_ListEntryHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simpleBankClient) ExportTransferHistory(ctx context.Context, in *ExportTransferHistoryRequest, opts ...grpc.CallOption) (SimpleBank_ExportTransferHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SimpleBank_ServiceDesc.Streams[1], SimpleBank_ExportTransferHistory_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simpleBankExportTransferHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SimpleBank_ExportTransferHistoryClient interface {
+	Recv() (*ExportTransferHistoryResponse, error)
+	grpc.ClientStream
+}
+
+type simpleBankExportTransferHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *simpleBankExportTransferHistoryClient) Recv() (*ExportTransferHistoryResponse, error) {
+	m := new(ExportTransferHistoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *simpleBankClient) ExportEntryHistory(ctx context.Context, in *ExportEntryHistoryRequest, opts ...grpc.CallOption) (SimpleBank_ExportEntryHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SimpleBank_ServiceDesc.Streams[2], SimpleBank_ExportEntryHistory_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simpleBankExportEntryHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SimpleBank_ExportEntryHistoryClient interface {
+	Recv() (*ExportEntryHistoryResponse, error)
+	grpc.ClientStream
+}
+
+type simpleBankExportEntryHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *simpleBankExportEntryHistoryClient) Recv() (*ExportEntryHistoryResponse, error) {
+	m := new(ExportEntryHistoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *simpleBankClient) GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error) {
+	out := new(GetBalanceAsOfResponse)
+	err := c.cc.Invoke(ctx, SimpleBank_GetBalanceAsOf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SimpleBankServer is the server API for SimpleBank service.
 // All implementations must embed UnimplementedSimpleBankServer
 // for forward compatibility
@@ -75,85 +731,1267 @@ type SimpleBankServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	CreateCurrency(context.Context, *CreateCurrencyRequest) (*CreateCurrencyResponse, error)
+	UpdateCurrency(context.Context, *UpdateCurrencyRequest) (*UpdateCurrencyResponse, error)
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error)
+	QuoteTransfer(context.Context, *QuoteTransferRequest) (*QuoteTransferResponse, error)
+	SetFxRates(context.Context, *SetFxRatesRequest) (*SetFxRatesResponse, error)
+	Deposit(context.Context, *DepositRequest) (*DepositResponse, error)
+	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
+	GetTellerTotals(context.Context, *GetTellerTotalsRequest) (*GetTellerTotalsResponse, error)
+	CreateStandingOrder(context.Context, *CreateStandingOrderRequest) (*CreateStandingOrderResponse, error)
+	ListStandingOrders(context.Context, *ListStandingOrdersRequest) (*ListStandingOrdersResponse, error)
+	PauseStandingOrder(context.Context, *PauseStandingOrderRequest) (*PauseStandingOrderResponse, error)
+	ResumeStandingOrder(context.Context, *ResumeStandingOrderRequest) (*ResumeStandingOrderResponse, error)
+	CancelStandingOrder(context.Context, *CancelStandingOrderRequest) (*CancelStandingOrderResponse, error)
+	ListStandingOrderRuns(context.Context, *ListStandingOrderRunsRequest) (*ListStandingOrderRunsResponse, error)
+	CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error)
+	VoidHold(context.Context, *VoidHoldRequest) (*VoidHoldResponse, error)
+	ReverseTransfer(context.Context, *ReverseTransferRequest) (*ReverseTransferResponse, error)
+	GetTransfer(context.Context, *GetTransferRequest) (*GetTransferResponse, error)
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	FreezeAccount(context.Context, *FreezeAccountRequest) (*FreezeAccountResponse, error)
+	UnfreezeAccount(context.Context, *UnfreezeAccountRequest) (*UnfreezeAccountResponse, error)
+	CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error)
+	SetOverdraft(context.Context, *SetOverdraftRequest) (*SetOverdraftResponse, error)
+	ListDebitBalances(context.Context, *ListDebitBalancesRequest) (*ListDebitBalancesResponse, error)
+	SetTransferLimit(context.Context, *SetTransferLimitRequest) (*SetTransferLimitResponse, error)
+	SetUserTier(context.Context, *SetUserTierRequest) (*SetUserTierResponse, error)
+	CreateInterestProduct(context.Context, *CreateInterestProductRequest) (*CreateInterestProductResponse, error)
+	ListInterestProducts(context.Context, *ListInterestProductsRequest) (*ListInterestProductsResponse, error)
+	SetInterestProduct(context.Context, *SetInterestProductRequest) (*SetInterestProductResponse, error)
+	ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error)
+	SetFeeRule(context.Context, *SetFeeRuleRequest) (*SetFeeRuleResponse, error)
+	ListFeeRules(context.Context, *ListFeeRulesRequest) (*ListFeeRulesResponse, error)
+	DeleteFeeRule(context.Context, *DeleteFeeRuleRequest) (*DeleteFeeRuleResponse, error)
+	SetAccountType(context.Context, *SetAccountTypeRequest) (*SetAccountTypeResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	ListDomainEvents(context.Context, *ListDomainEventsRequest) (*ListDomainEventsResponse, error)
+	CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*CreateWebhookEndpointResponse, error)
+	ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error)
+	RotateWebhookSecret(context.Context, *RotateWebhookSecretRequest) (*RotateWebhookSecretResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error)
+	CreateTransferBatch(context.Context, *CreateTransferBatchRequest) (*CreateTransferBatchResponse, error)
+	// the gateway cannot stream requests, so this one is only served over grpc
+	CreateTransferBatchStream(SimpleBank_CreateTransferBatchStreamServer) error
+	GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error)
+	ListTransferBatchItems(context.Context, *ListTransferBatchItemsRequest) (*ListTransferBatchItemsResponse, error)
+	ListTransferHistory(context.Context, *ListTransferHistoryRequest) (*ListTransferHistoryResponse, error)
+	ListEntryHistory(context.Context, *ListEntryHistoryRequest) (*ListEntryHistoryResponse, error)
+	// the gateway cannot stream responses, so the exports are only served over grpc
+	ExportTransferHistory(*ExportTransferHistoryRequest, SimpleBank_ExportTransferHistoryServer) error
+	ExportEntryHistory(*ExportEntryHistoryRequest, SimpleBank_ExportEntryHistoryServer) error
+	GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error)
+	mustEmbedUnimplementedSimpleBankServer()
+}
+
+// UnimplementedSimpleBankServer must be embedded to have forward compatible implementations.
+type UnimplementedSimpleBankServer struct {
+}
+
+func (UnimplementedSimpleBankServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedSimpleBankServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+}
+func (UnimplementedSimpleBankServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateCurrency(context.Context, *CreateCurrencyRequest) (*CreateCurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCurrency not implemented")
+}
+func (UnimplementedSimpleBankServer) UpdateCurrency(context.Context, *UpdateCurrencyRequest) (*UpdateCurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCurrency not implemented")
+}
+func (UnimplementedSimpleBankServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateTransfer(context.Context, *CreateTransferRequest) (*CreateTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) QuoteTransfer(context.Context, *QuoteTransferRequest) (*QuoteTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) SetFxRates(context.Context, *SetFxRatesRequest) (*SetFxRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFxRates not implemented")
+}
+func (UnimplementedSimpleBankServer) Deposit(context.Context, *DepositRequest) (*DepositResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedSimpleBankServer) Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedSimpleBankServer) GetTellerTotals(context.Context, *GetTellerTotalsRequest) (*GetTellerTotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTellerTotals not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateStandingOrder(context.Context, *CreateStandingOrderRequest) (*CreateStandingOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStandingOrder not implemented")
+}
+func (UnimplementedSimpleBankServer) ListStandingOrders(context.Context, *ListStandingOrdersRequest) (*ListStandingOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStandingOrders not implemented")
+}
+func (UnimplementedSimpleBankServer) PauseStandingOrder(context.Context, *PauseStandingOrderRequest) (*PauseStandingOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseStandingOrder not implemented")
+}
+func (UnimplementedSimpleBankServer) ResumeStandingOrder(context.Context, *ResumeStandingOrderRequest) (*ResumeStandingOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeStandingOrder not implemented")
+}
+func (UnimplementedSimpleBankServer) CancelStandingOrder(context.Context, *CancelStandingOrderRequest) (*CancelStandingOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelStandingOrder not implemented")
+}
+func (UnimplementedSimpleBankServer) ListStandingOrderRuns(context.Context, *ListStandingOrderRunsRequest) (*ListStandingOrderRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStandingOrderRuns not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateHold(context.Context, *CreateHoldRequest) (*CreateHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHold not implemented")
+}
+func (UnimplementedSimpleBankServer) CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedSimpleBankServer) VoidHold(context.Context, *VoidHoldRequest) (*VoidHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidHold not implemented")
+}
+func (UnimplementedSimpleBankServer) ReverseTransfer(context.Context, *ReverseTransferRequest) (*ReverseTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) GetTransfer(context.Context, *GetTransferRequest) (*GetTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
+}
+func (UnimplementedSimpleBankServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedSimpleBankServer) FreezeAccount(context.Context, *FreezeAccountRequest) (*FreezeAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) UnfreezeAccount(context.Context, *UnfreezeAccountRequest) (*UnfreezeAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) CloseAccount(context.Context, *CloseAccountRequest) (*CloseAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedSimpleBankServer) SetOverdraft(context.Context, *SetOverdraftRequest) (*SetOverdraftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOverdraft not implemented")
+}
+func (UnimplementedSimpleBankServer) ListDebitBalances(context.Context, *ListDebitBalancesRequest) (*ListDebitBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDebitBalances not implemented")
+}
+func (UnimplementedSimpleBankServer) SetTransferLimit(context.Context, *SetTransferLimitRequest) (*SetTransferLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTransferLimit not implemented")
+}
+func (UnimplementedSimpleBankServer) SetUserTier(context.Context, *SetUserTierRequest) (*SetUserTierResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserTier not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateInterestProduct(context.Context, *CreateInterestProductRequest) (*CreateInterestProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInterestProduct not implemented")
+}
+func (UnimplementedSimpleBankServer) ListInterestProducts(context.Context, *ListInterestProductsRequest) (*ListInterestProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterestProducts not implemented")
+}
+func (UnimplementedSimpleBankServer) SetInterestProduct(context.Context, *SetInterestProductRequest) (*SetInterestProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterestProduct not implemented")
+}
+func (UnimplementedSimpleBankServer) ListInterestAccruals(context.Context, *ListInterestAccrualsRequest) (*ListInterestAccrualsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterestAccruals not implemented")
+}
+func (UnimplementedSimpleBankServer) SetFeeRule(context.Context, *SetFeeRuleRequest) (*SetFeeRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeRule not implemented")
+}
+func (UnimplementedSimpleBankServer) ListFeeRules(context.Context, *ListFeeRulesRequest) (*ListFeeRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeeRules not implemented")
+}
+func (UnimplementedSimpleBankServer) DeleteFeeRule(context.Context, *DeleteFeeRuleRequest) (*DeleteFeeRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeeRule not implemented")
+}
+func (UnimplementedSimpleBankServer) SetAccountType(context.Context, *SetAccountTypeRequest) (*SetAccountTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountType not implemented")
+}
+func (UnimplementedSimpleBankServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedSimpleBankServer) ListDomainEvents(context.Context, *ListDomainEventsRequest) (*ListDomainEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomainEvents not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*CreateWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookEndpoint not implemented")
+}
+func (UnimplementedSimpleBankServer) ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookEndpoints not implemented")
+}
+func (UnimplementedSimpleBankServer) DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*DeleteWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookEndpoint not implemented")
+}
+func (UnimplementedSimpleBankServer) RotateWebhookSecret(context.Context, *RotateWebhookSecretRequest) (*RotateWebhookSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateWebhookSecret not implemented")
+}
+func (UnimplementedSimpleBankServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedSimpleBankServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateTransferBatch(context.Context, *CreateTransferBatchRequest) (*CreateTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransferBatch not implemented")
+}
+func (UnimplementedSimpleBankServer) CreateTransferBatchStream(SimpleBank_CreateTransferBatchStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateTransferBatchStream not implemented")
+}
+func (UnimplementedSimpleBankServer) GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatch not implemented")
+}
+func (UnimplementedSimpleBankServer) ListTransferBatchItems(context.Context, *ListTransferBatchItemsRequest) (*ListTransferBatchItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferBatchItems not implemented")
+}
+func (UnimplementedSimpleBankServer) ListTransferHistory(context.Context, *ListTransferHistoryRequest) (*ListTransferHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferHistory not implemented")
+}
+func (UnimplementedSimpleBankServer) ListEntryHistory(context.Context, *ListEntryHistoryRequest) (*ListEntryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntryHistory not implemented")
+}
+func (UnimplementedSimpleBankServer) ExportTransferHistory(*ExportTransferHistoryRequest, SimpleBank_ExportTransferHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTransferHistory not implemented")
+}
+func (UnimplementedSimpleBankServer) ExportEntryHistory(*ExportEntryHistoryRequest, SimpleBank_ExportEntryHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEntryHistory not implemented")
+}
+func (UnimplementedSimpleBankServer) GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
+func (UnimplementedSimpleBankServer) mustEmbedUnimplementedSimpleBankServer() {}
+
+// UnsafeSimpleBankServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimpleBankServer will
+// result in compilation errors.
+type UnsafeSimpleBankServer interface {
 	mustEmbedUnimplementedSimpleBankServer()
 }
 
-// UnimplementedSimpleBankServer must be embedded to have forward compatible implementations.
-type UnimplementedSimpleBankServer struct {
+func RegisterSimpleBankServer(s grpc.ServiceRegistrar, srv SimpleBankServer) {
+	s.RegisterService(&SimpleBank_ServiceDesc, srv)
+}
+
+func _SimpleBank_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).LoginUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_LoginUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).LoginUser(ctx, req.(*LoginUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateCurrency(ctx, req.(*CreateCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_UpdateCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).UpdateCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_UpdateCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).UpdateCurrency(ctx, req.(*UpdateCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_QuoteTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).QuoteTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_QuoteTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).QuoteTransfer(ctx, req.(*QuoteTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetFxRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFxRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetFxRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetFxRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetFxRates(ctx, req.(*SetFxRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).Deposit(ctx, req.(*DepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_Withdraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).Withdraw(ctx, req.(*WithdrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetTellerTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTellerTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetTellerTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetTellerTotals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetTellerTotals(ctx, req.(*GetTellerTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateStandingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStandingOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateStandingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateStandingOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateStandingOrder(ctx, req.(*CreateStandingOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListStandingOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStandingOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListStandingOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListStandingOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListStandingOrders(ctx, req.(*ListStandingOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_PauseStandingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseStandingOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).PauseStandingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_PauseStandingOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).PauseStandingOrder(ctx, req.(*PauseStandingOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ResumeStandingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeStandingOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ResumeStandingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ResumeStandingOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ResumeStandingOrder(ctx, req.(*ResumeStandingOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CancelStandingOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelStandingOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CancelStandingOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CancelStandingOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CancelStandingOrder(ctx, req.(*CancelStandingOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListStandingOrderRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStandingOrderRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListStandingOrderRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListStandingOrderRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListStandingOrderRuns(ctx, req.(*ListStandingOrderRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateHold(ctx, req.(*CreateHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CaptureHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_VoidHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).VoidHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_VoidHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).VoidHold(ctx, req.(*VoidHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ReverseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ReverseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ReverseTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ReverseTransfer(ctx, req.(*ReverseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_GetTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetTransfer(ctx, req.(*GetTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_FreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).FreezeAccount(ctx, req.(*FreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_UnfreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).UnfreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_UnfreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).UnfreezeAccount(ctx, req.(*UnfreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CloseAccount(ctx, req.(*CloseAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetOverdraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOverdraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetOverdraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetOverdraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetOverdraft(ctx, req.(*SetOverdraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListDebitBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDebitBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListDebitBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListDebitBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListDebitBalances(ctx, req.(*ListDebitBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetTransferLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTransferLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetTransferLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetTransferLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetTransferLimit(ctx, req.(*SetTransferLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetUserTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserTierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetUserTier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetUserTier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetUserTier(ctx, req.(*SetUserTierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateInterestProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInterestProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateInterestProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateInterestProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateInterestProduct(ctx, req.(*CreateInterestProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListInterestProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterestProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListInterestProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListInterestProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListInterestProducts(ctx, req.(*ListInterestProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetInterestProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInterestProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetInterestProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetInterestProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetInterestProduct(ctx, req.(*SetInterestProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListInterestAccruals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterestAccrualsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListInterestAccruals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListInterestAccruals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListInterestAccruals(ctx, req.(*ListInterestAccrualsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetFeeRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetFeeRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetFeeRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetFeeRule(ctx, req.(*SetFeeRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListFeeRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeeRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListFeeRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListFeeRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListFeeRules(ctx, req.(*ListFeeRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_DeleteFeeRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFeeRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).DeleteFeeRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_DeleteFeeRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).DeleteFeeRule(ctx, req.(*DeleteFeeRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_SetAccountType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).SetAccountType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_SetAccountType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).SetAccountType(ctx, req.(*SetAccountTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListDomainEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListDomainEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListDomainEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListDomainEvents(ctx, req.(*ListDomainEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_CreateWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateWebhookEndpoint(ctx, req.(*CreateWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListWebhookEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListWebhookEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListWebhookEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListWebhookEndpoints(ctx, req.(*ListWebhookEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_DeleteWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).DeleteWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_DeleteWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).DeleteWebhookEndpoint(ctx, req.(*DeleteWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedSimpleBankServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func _SimpleBank_RotateWebhookSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateWebhookSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RotateWebhookSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RotateWebhookSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RotateWebhookSecret(ctx, req.(*RotateWebhookSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSimpleBankServer) LoginUser(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LoginUser not implemented")
+
+func _SimpleBank_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSimpleBankServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+
+func _SimpleBank_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_RedeliverWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSimpleBankServer) mustEmbedUnimplementedSimpleBankServer() {}
 
-// UnsafeSimpleBankServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to SimpleBankServer will
-// result in compilation errors.
-type UnsafeSimpleBankServer interface {
-	mustEmbedUnimplementedSimpleBankServer()
+func _SimpleBank_CreateTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).CreateTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_CreateTransferBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).CreateTransferBatch(ctx, req.(*CreateTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSimpleBankServer(s grpc.ServiceRegistrar, srv SimpleBankServer) {
-	s.RegisterService(&SimpleBank_ServiceDesc, srv)
+func _SimpleBank_CreateTransferBatchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SimpleBankServer).CreateTransferBatchStream(&simpleBankCreateTransferBatchStreamServer{stream})
 }
 
-func _SimpleBank_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+type SimpleBank_CreateTransferBatchStreamServer interface {
+	SendAndClose(*CreateTransferBatchResponse) error
+	Recv() (*CreateTransferBatchStreamRequest, error)
+	grpc.ServerStream
+}
+
+type simpleBankCreateTransferBatchStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *simpleBankCreateTransferBatchStreamServer) SendAndClose(m *CreateTransferBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *simpleBankCreateTransferBatchStreamServer) Recv() (*CreateTransferBatchStreamRequest, error) {
+	m := new(CreateTransferBatchStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SimpleBank_GetTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferBatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SimpleBankServer).CreateUser(ctx, in)
+		return srv.(SimpleBankServer).GetTransferBatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SimpleBank_CreateUser_FullMethodName,
+		FullMethod: SimpleBank_GetTransferBatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SimpleBankServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(SimpleBankServer).GetTransferBatch(ctx, req.(*GetTransferBatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SimpleBank_LoginUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginUserRequest)
+func _SimpleBank_ListTransferBatchItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferBatchItemsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SimpleBankServer).LoginUser(ctx, in)
+		return srv.(SimpleBankServer).ListTransferBatchItems(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SimpleBank_LoginUser_FullMethodName,
+		FullMethod: SimpleBank_ListTransferBatchItems_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SimpleBankServer).LoginUser(ctx, req.(*LoginUserRequest))
+		return srv.(SimpleBankServer).ListTransferBatchItems(ctx, req.(*ListTransferBatchItemsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SimpleBank_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _SimpleBank_ListTransferHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SimpleBankServer).UpdateUser(ctx, in)
+		return srv.(SimpleBankServer).ListTransferHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SimpleBank_UpdateUser_FullMethodName,
+		FullMethod: SimpleBank_ListTransferHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SimpleBankServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+		return srv.(SimpleBankServer).ListTransferHistory(ctx, req.(*ListTransferHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ListEntryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).ListEntryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_ListEntryHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).ListEntryHistory(ctx, req.(*ListEntryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimpleBank_ExportTransferHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTransferHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimpleBankServer).ExportTransferHistory(m, &simpleBankExportTransferHistoryServer{stream})
+}
+
+type SimpleBank_ExportTransferHistoryServer interface {
+	Send(*ExportTransferHistoryResponse) error
+	grpc.ServerStream
+}
+
+type simpleBankExportTransferHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *simpleBankExportTransferHistoryServer) Send(m *ExportTransferHistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SimpleBank_ExportEntryHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEntryHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimpleBankServer).ExportEntryHistory(m, &simpleBankExportEntryHistoryServer{stream})
+}
+
+type SimpleBank_ExportEntryHistoryServer interface {
+	Send(*ExportEntryHistoryResponse) error
+	grpc.ServerStream
+}
+
+type simpleBankExportEntryHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *simpleBankExportEntryHistoryServer) Send(m *ExportEntryHistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SimpleBank_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimpleBankServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimpleBank_GetBalanceAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimpleBankServer).GetBalanceAsOf(ctx, req.(*GetBalanceAsOfRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -177,7 +2015,223 @@ var SimpleBank_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUser",
 			Handler:    _SimpleBank_UpdateUser_Handler,
 		},
+		{
+			MethodName: "CreateCurrency",
+			Handler:    _SimpleBank_CreateCurrency_Handler,
+		},
+		{
+			MethodName: "UpdateCurrency",
+			Handler:    _SimpleBank_UpdateCurrency_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _SimpleBank_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _SimpleBank_CreateTransfer_Handler,
+		},
+		{
+			MethodName: "QuoteTransfer",
+			Handler:    _SimpleBank_QuoteTransfer_Handler,
+		},
+		{
+			MethodName: "SetFxRates",
+			Handler:    _SimpleBank_SetFxRates_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _SimpleBank_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _SimpleBank_Withdraw_Handler,
+		},
+		{
+			MethodName: "GetTellerTotals",
+			Handler:    _SimpleBank_GetTellerTotals_Handler,
+		},
+		{
+			MethodName: "CreateStandingOrder",
+			Handler:    _SimpleBank_CreateStandingOrder_Handler,
+		},
+		{
+			MethodName: "ListStandingOrders",
+			Handler:    _SimpleBank_ListStandingOrders_Handler,
+		},
+		{
+			MethodName: "PauseStandingOrder",
+			Handler:    _SimpleBank_PauseStandingOrder_Handler,
+		},
+		{
+			MethodName: "ResumeStandingOrder",
+			Handler:    _SimpleBank_ResumeStandingOrder_Handler,
+		},
+		{
+			MethodName: "CancelStandingOrder",
+			Handler:    _SimpleBank_CancelStandingOrder_Handler,
+		},
+		{
+			MethodName: "ListStandingOrderRuns",
+			Handler:    _SimpleBank_ListStandingOrderRuns_Handler,
+		},
+		{
+			MethodName: "CreateHold",
+			Handler:    _SimpleBank_CreateHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _SimpleBank_CaptureHold_Handler,
+		},
+		{
+			MethodName: "VoidHold",
+			Handler:    _SimpleBank_VoidHold_Handler,
+		},
+		{
+			MethodName: "ReverseTransfer",
+			Handler:    _SimpleBank_ReverseTransfer_Handler,
+		},
+		{
+			MethodName: "GetTransfer",
+			Handler:    _SimpleBank_GetTransfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _SimpleBank_ListTransfers_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _SimpleBank_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "UnfreezeAccount",
+			Handler:    _SimpleBank_UnfreezeAccount_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _SimpleBank_CloseAccount_Handler,
+		},
+		{
+			MethodName: "SetOverdraft",
+			Handler:    _SimpleBank_SetOverdraft_Handler,
+		},
+		{
+			MethodName: "ListDebitBalances",
+			Handler:    _SimpleBank_ListDebitBalances_Handler,
+		},
+		{
+			MethodName: "SetTransferLimit",
+			Handler:    _SimpleBank_SetTransferLimit_Handler,
+		},
+		{
+			MethodName: "SetUserTier",
+			Handler:    _SimpleBank_SetUserTier_Handler,
+		},
+		{
+			MethodName: "CreateInterestProduct",
+			Handler:    _SimpleBank_CreateInterestProduct_Handler,
+		},
+		{
+			MethodName: "ListInterestProducts",
+			Handler:    _SimpleBank_ListInterestProducts_Handler,
+		},
+		{
+			MethodName: "SetInterestProduct",
+			Handler:    _SimpleBank_SetInterestProduct_Handler,
+		},
+		{
+			MethodName: "ListInterestAccruals",
+			Handler:    _SimpleBank_ListInterestAccruals_Handler,
+		},
+		{
+			MethodName: "SetFeeRule",
+			Handler:    _SimpleBank_SetFeeRule_Handler,
+		},
+		{
+			MethodName: "ListFeeRules",
+			Handler:    _SimpleBank_ListFeeRules_Handler,
+		},
+		{
+			MethodName: "DeleteFeeRule",
+			Handler:    _SimpleBank_DeleteFeeRule_Handler,
+		},
+		{
+			MethodName: "SetAccountType",
+			Handler:    _SimpleBank_SetAccountType_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _SimpleBank_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ListDomainEvents",
+			Handler:    _SimpleBank_ListDomainEvents_Handler,
+		},
+		{
+			MethodName: "CreateWebhookEndpoint",
+			Handler:    _SimpleBank_CreateWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookEndpoints",
+			Handler:    _SimpleBank_ListWebhookEndpoints_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookEndpoint",
+			Handler:    _SimpleBank_DeleteWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "RotateWebhookSecret",
+			Handler:    _SimpleBank_RotateWebhookSecret_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _SimpleBank_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _SimpleBank_RedeliverWebhook_Handler,
+		},
+		{
+			MethodName: "CreateTransferBatch",
+			Handler:    _SimpleBank_CreateTransferBatch_Handler,
+		},
+		{
+			MethodName: "GetTransferBatch",
+			Handler:    _SimpleBank_GetTransferBatch_Handler,
+		},
+		{
+			MethodName: "ListTransferBatchItems",
+			Handler:    _SimpleBank_ListTransferBatchItems_Handler,
+		},
+		{
+			MethodName: "ListTransferHistory",
+			Handler:    _SimpleBank_ListTransferHistory_Handler,
+		},
+		{
+			MethodName: "ListEntryHistory",
+			Handler:    _SimpleBank_ListEntryHistory_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _SimpleBank_GetBalanceAsOf_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateTransferBatchStream",
+			Handler:       _SimpleBank_CreateTransferBatchStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportTransferHistory",
+			Handler:       _SimpleBank_ExportTransferHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportEntryHistory",
+			Handler:       _SimpleBank_ExportEntryHistory_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service_simple_bank.proto",
 }
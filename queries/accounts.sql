@@ -36,5 +36,75 @@ WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteAccount :exec
+-- only accounts without any entries can be deleted, close the others
 DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1 AND NOT EXISTS (
+  SELECT 1 FROM entries WHERE account_id = $1
+);
+
+-- name: GetAccountByOwnerAndCurrency :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 LIMIT 1;
+
+-- name: EnsureAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  $1, 0, $2
+) ON CONFLICT (owner, currency) DO NOTHING;
+
+-- name: AddAccountHeldBalance :one
+UPDATE accounts
+SET held_balance = held_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET
+  status = sqlc.arg(status),
+  status_reason = sqlc.arg(status_reason),
+  closed_at = sqlc.narg(closed_at)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: SetAccountOverdraft :one
+UPDATE accounts
+SET
+  overdraft_limit = $2,
+  overdraft_interest_bps = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListDebitBalances :many
+SELECT * FROM accounts
+WHERE balance < 0 AND NOT (owner = ANY(sqlc.arg(excluded_owners)::varchar[]))
+ORDER BY balance, id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListOverdrawnAccounts :many
+SELECT * FROM accounts
+WHERE balance < 0 AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: SetAccountInterestProduct :one
+UPDATE accounts
+SET interest_product_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInterestBearingAccounts :many
+SELECT * FROM accounts
+WHERE interest_product_id IS NOT NULL AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: SetAccountType :one
+UPDATE accounts
+SET account_type = $2
+WHERE id = $1
+RETURNING *;
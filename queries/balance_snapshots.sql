@@ -0,0 +1,27 @@
+-- name: CreateBalanceSnapshots :execrows
+-- CreateBalanceSnapshots snapshots the accounts in (after_id, after_id + batch_size] at as_of
+-- from their previous snapshot and the entries since. Existing snapshots are kept.
+INSERT INTO balance_snapshots (account_id, as_of, balance)
+SELECT a.id, sqlc.arg(as_of)::timestamptz, COALESCE(prev.balance, 0) + COALESCE((
+  SELECT SUM(e.amount) FROM entries e
+  WHERE e.account_id = a.id
+    AND e.created_at >= COALESCE(prev.as_of, '-infinity')
+    AND e.created_at < sqlc.arg(as_of)
+), 0)::bigint
+FROM accounts a
+LEFT JOIN LATERAL (
+  SELECT s.balance, s.as_of FROM balance_snapshots s
+  WHERE s.account_id = a.id AND s.as_of < sqlc.arg(as_of)
+  ORDER BY s.as_of DESC
+  LIMIT 1
+) prev ON true
+WHERE a.id > sqlc.arg(after_id) AND a.id <= sqlc.arg(after_id) + sqlc.arg(batch_size)::bigint
+ON CONFLICT DO NOTHING;
+
+-- name: GetLatestBalanceSnapshot :one
+-- GetLatestBalanceSnapshot returns the newest snapshot of the account taken at or before as_of.
+SELECT * FROM balance_snapshots
+WHERE account_id = $1 AND as_of <= $2
+ORDER BY as_of DESC
+LIMIT 1;
+
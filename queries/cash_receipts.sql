@@ -0,0 +1,24 @@
+-- name: CreateCashReceipt :one
+INSERT INTO cash_receipts (
+  kind,
+  account_id,
+  amount,
+  currency,
+  teller,
+  journal_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetCashReceipt :one
+SELECT * FROM cash_receipts
+WHERE id = $1 LIMIT 1;
+
+-- name: ListTellerTotals :many
+SELECT currency, kind, COUNT(*) AS count, SUM(amount)::bigint AS total
+FROM cash_receipts
+WHERE teller = sqlc.arg(teller)
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time)
+GROUP BY currency, kind
+ORDER BY currency, kind;
@@ -0,0 +1,39 @@
+-- name: CreateCurrency :one
+INSERT INTO currencies (
+  code,
+  minor_units,
+  symbol,
+  enabled
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetCurrency :one
+SELECT * FROM currencies
+WHERE code = $1 LIMIT 1;
+
+-- name: CurrencyHasAccounts :one
+SELECT EXISTS (
+  SELECT 1 FROM accounts WHERE currency = $1
+);
+
+-- name: GetCurrencyForUpdate :one
+-- GetCurrencyForUpdate also blocks accounts from being opened in the currency until the end of
+-- the transaction.
+SELECT * FROM currencies
+WHERE code = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: UpdateCurrency :one
+UPDATE currencies
+SET
+  minor_units = COALESCE(sqlc.narg(minor_units), minor_units),
+  symbol = COALESCE(sqlc.narg(symbol), symbol),
+  enabled = COALESCE(sqlc.narg(enabled), enabled)
+WHERE
+  code = sqlc.arg(code)
+RETURNING *;
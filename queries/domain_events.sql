@@ -0,0 +1,46 @@
+-- name: CreateDomainEvent :exec
+INSERT INTO domain_events (
+  event_id,
+  event_type,
+  version,
+  payload
+) VALUES (
+  $1, $2, $3, $4
+);
+
+-- name: LockDomainEventPublisher :exec
+-- LockDomainEventPublisher makes the publishers take turns until the end of the transaction,
+-- so a batch only gets offsets once the batch before it is published.
+SELECT pg_advisory_xact_lock(hashtext('domain_events'));
+
+-- name: ListPendingDomainEvents :many
+SELECT * FROM domain_events
+WHERE stream_offset IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: ListUnpublishedDomainEvents :many
+SELECT * FROM domain_events
+WHERE stream_offset IS NOT NULL AND published_at IS NULL
+ORDER BY stream_offset
+LIMIT $1;
+
+-- name: GetLastDomainEventOffset :one
+SELECT COALESCE(MAX(stream_offset), 0)::bigint FROM domain_events;
+
+-- name: SetDomainEventOffset :exec
+UPDATE domain_events
+SET stream_offset = sqlc.arg(stream_offset)::bigint
+WHERE id = sqlc.arg(id);
+
+-- name: MarkDomainEventPublished :execrows
+UPDATE domain_events
+SET published_at = now()
+WHERE stream_offset = sqlc.arg(stream_offset)::bigint AND published_at IS NULL;
+
+-- name: ListDomainEvents :many
+-- ListDomainEvents reads the stream as the sink got it, events waiting to be published are left out.
+SELECT * FROM domain_events
+WHERE stream_offset > sqlc.arg(after_offset)::bigint AND published_at IS NOT NULL
+ORDER BY stream_offset
+LIMIT sqlc.arg('limit');
@@ -1,9 +1,10 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  journal_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -15,4 +16,39 @@ SELECT * FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
-OFFSET $3;
\ No newline at end of file
+OFFSET $3;
+
+-- name: ListJournalEntries :many
+SELECT * FROM entries
+WHERE journal_id = $1
+ORDER BY id;
+
+-- name: SumEntriesSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint FROM entries
+WHERE account_id = $1 AND created_at >= $2;
+
+-- name: SumEntriesBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint FROM entries
+WHERE account_id = $1 AND created_at >= sqlc.arg(since) AND created_at < sqlc.arg(until);
+
+-- name: SumAccountEntries :one
+SELECT COALESCE(SUM(amount), 0)::bigint FROM entries
+WHERE account_id = $1;
+
+-- name: ListEntryHistory :many
+-- ListEntryHistory returns the entries of an account newest first after the (created_at, id) cursor, null filters match any entry.
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND (sqlc.narg(incoming)::bool IS NULL OR (amount > 0) = sqlc.narg(incoming))
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount))
+  AND (sqlc.narg(counterparty_id)::bigint IS NULL OR EXISTS (
+    SELECT 1 FROM transfers
+    WHERE transfers.journal_id = entries.journal_id
+      AND sqlc.narg(counterparty_id) IN (transfers.from_account_id, transfers.to_account_id)
+  ))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL OR (created_at, id) < (sqlc.narg(cursor_created_at), sqlc.arg(cursor_id)::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
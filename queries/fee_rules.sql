@@ -0,0 +1,42 @@
+-- name: SetFeeRule :one
+INSERT INTO fee_rules (
+  currency,
+  account_type,
+  kind,
+  flat_amount,
+  rate_bps,
+  min_amount,
+  max_amount
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (currency, COALESCE(account_type, '')) DO UPDATE SET
+  kind = EXCLUDED.kind,
+  flat_amount = EXCLUDED.flat_amount,
+  rate_bps = EXCLUDED.rate_bps,
+  min_amount = EXCLUDED.min_amount,
+  max_amount = EXCLUDED.max_amount,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetFeeRule :one
+-- GetFeeRule returns the rule for the account type, or the rule for any type when it has none.
+SELECT * FROM fee_rules
+WHERE currency = sqlc.arg(currency)
+  AND (account_type = sqlc.arg(account_type)::varchar OR account_type IS NULL)
+ORDER BY account_type NULLS LAST
+LIMIT 1;
+
+-- name: GetFeeRuleForUpdate :one
+SELECT * FROM fee_rules
+WHERE currency = $1 AND account_type IS NOT DISTINCT FROM $2
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListFeeRules :many
+SELECT * FROM fee_rules
+ORDER BY currency, account_type NULLS FIRST;
+
+-- name: DeleteFeeRule :one
+DELETE FROM fee_rules
+WHERE id = $1
+RETURNING *;
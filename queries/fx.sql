@@ -0,0 +1,50 @@
+-- name: UpsertFxRate :one
+INSERT INTO fx_rates (
+  base_currency,
+  quote_currency,
+  rate,
+  spread_bps
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (base_currency, quote_currency) DO UPDATE
+SET
+  rate = EXCLUDED.rate,
+  spread_bps = EXCLUDED.spread_bps,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetFxRate :one
+SELECT * FROM fx_rates
+WHERE base_currency = $1 AND quote_currency = $2 LIMIT 1;
+
+-- name: ListFxRates :many
+SELECT * FROM fx_rates
+ORDER BY base_currency, quote_currency;
+
+-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (
+  id,
+  username,
+  from_account_id,
+  to_account_id,
+  house_from_account_id,
+  house_to_account_id,
+  amount,
+  rate,
+  converted_amount,
+  spread_amount,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetFxQuoteForUpdate :one
+SELECT * FROM fx_quotes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: SetFxQuoteTransfer :one
+UPDATE fx_quotes
+SET transfer_id = sqlc.arg(transfer_id)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,40 @@
+-- name: CreateIdempotencyKey :execrows
+-- CreateIdempotencyKey claims a key, an expired key that was not purged yet is claimed again.
+INSERT INTO idempotency_keys (
+  username,
+  key,
+  request_hash,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (username, key) DO UPDATE
+SET
+  request_hash = EXCLUDED.request_hash,
+  response_code = 0,
+  response_body = '',
+  created_at = now(),
+  completed_at = NULL,
+  expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= now();
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+SET
+  response_code = sqlc.arg(response_code),
+  response_body = sqlc.arg(response_body),
+  completed_at = now()
+WHERE
+  username = sqlc.arg(username) AND key = sqlc.arg(key)
+RETURNING *;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE username = $1 AND key = $2 AND completed_at IS NULL;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
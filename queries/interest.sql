@@ -0,0 +1,82 @@
+-- name: CreateInterestProduct :one
+INSERT INTO interest_products (
+  name,
+  currency,
+  annual_rate_bps,
+  day_count
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetInterestProduct :one
+SELECT * FROM interest_products
+WHERE id = $1 LIMIT 1;
+
+-- name: ListInterestProducts :many
+SELECT * FROM interest_products
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrued_on,
+  product_id,
+  annual_rate_bps,
+  balance,
+  amount_micros
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetInterestAccrual :one
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND accrued_on = $2 LIMIT 1;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrued_on DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: SumInterestAccruals :one
+-- SumInterestAccruals adds up the accruals of the account before the date that are not capitalized yet.
+SELECT COUNT(*), COALESCE(SUM(amount_micros), 0)::bigint AS amount_micros FROM interest_accruals
+WHERE account_id = $1 AND accrued_on < $2 AND capitalization_id IS NULL;
+
+-- name: ListUncapitalizedInterestAccounts :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE capitalization_id IS NULL
+  AND accrued_on < sqlc.arg(before)
+  AND account_id > sqlc.arg(after_id)
+ORDER BY account_id
+LIMIT sqlc.arg('limit');
+
+-- name: CapitalizeInterestAccruals :exec
+UPDATE interest_accruals
+SET capitalization_id = sqlc.arg(capitalization_id)
+WHERE account_id = sqlc.arg(account_id) AND accrued_on < sqlc.arg(before) AND capitalization_id IS NULL;
+
+-- name: CreateInterestCapitalization :one
+INSERT INTO interest_capitalizations (
+  account_id,
+  period,
+  accrued_micros,
+  amount,
+  carried_micros,
+  journal_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = $1 AND period = $2 LIMIT 1;
+
+-- name: GetLastInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT 1;
@@ -0,0 +1,11 @@
+-- name: CreateJournal :one
+INSERT INTO journals (
+  kind,
+  reference
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetJournal :one
+SELECT * FROM journals
+WHERE id = $1 LIMIT 1;
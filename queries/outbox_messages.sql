@@ -0,0 +1,42 @@
+-- name: CreateOutboxMessage :exec
+-- CreateOutboxMessage skips messages whose task id is already pending.
+INSERT INTO outbox_messages (
+  task_type,
+  payload,
+  queue,
+  max_retry,
+  task_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (task_id) WHERE task_id <> '' AND published_at IS NULL DO NOTHING;
+
+-- name: ClaimOutboxMessages :many
+-- ClaimOutboxMessages leases due messages to the caller until leased_until. Messages that are
+-- neither published nor failed by then are claimed again.
+UPDATE outbox_messages
+SET next_attempt_at = sqlc.arg(leased_until)
+WHERE id IN (
+  SELECT id FROM outbox_messages
+  WHERE published_at IS NULL AND next_attempt_at <= now()
+  ORDER BY id
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxMessagePublished :exec
+UPDATE outbox_messages
+SET published_at = now()
+WHERE id = $1;
+
+-- name: MarkOutboxMessageFailed :exec
+UPDATE outbox_messages
+SET
+  attempts = attempts + 1,
+  last_error = $2,
+  next_attempt_at = $3
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxMessages :exec
+DELETE FROM outbox_messages
+WHERE published_at < sqlc.arg(published_before)::timestamptz;
@@ -0,0 +1,14 @@
+-- name: CreateOverdraftCharge :one
+INSERT INTO overdraft_charges (
+  account_id,
+  charged_on,
+  balance,
+  amount,
+  journal_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetOverdraftCharge :one
+SELECT * FROM overdraft_charges
+WHERE account_id = $1 AND charged_on = $2 LIMIT 1;
@@ -0,0 +1,67 @@
+-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_runs (
+  repair
+) VALUES (
+  $1
+) RETURNING *;
+
+-- name: FinishReconciliationRun :one
+UPDATE reconciliation_runs
+SET
+  last_account_id = $2,
+  last_journal_id = $3,
+  findings = $4,
+  finished_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetReconciliationRun :one
+SELECT * FROM reconciliation_runs
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateReconciliationFinding :one
+INSERT INTO reconciliation_findings (
+  run_id,
+  kind,
+  account_id,
+  journal_id,
+  currency,
+  expected,
+  actual,
+  repaired
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListReconciliationFindings :many
+SELECT * FROM reconciliation_findings
+WHERE run_id = $1
+ORDER BY id;
+
+-- name: GetLedgerBounds :one
+-- GetLedgerBounds returns the highest account and journal ids, reconciliation checks up to them.
+SELECT
+  (SELECT COALESCE(MAX(id), 0) FROM accounts)::bigint AS max_account_id,
+  (SELECT COALESCE(MAX(id), 0) FROM journals)::bigint AS max_journal_id;
+
+-- name: ListBalanceMismatches :many
+-- ListBalanceMismatches compares the balance of the accounts in (after_id, after_id + batch_size]
+-- with the sum of their entries. Both are read from the same snapshot.
+SELECT a.id, a.currency, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+WHERE a.id > sqlc.arg(after_id) AND a.id <= sqlc.arg(after_id) + sqlc.arg(batch_size)::bigint
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListUnbalancedJournals :many
+-- ListUnbalancedJournals returns the journals in (after_id, after_id + batch_size] whose
+-- entries don't sum to zero in a currency.
+SELECT e.journal_id::bigint AS journal_id, a.currency, SUM(e.amount)::bigint AS total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE e.journal_id > sqlc.arg(after_id) AND e.journal_id <= sqlc.arg(after_id) + sqlc.arg(batch_size)::bigint
+GROUP BY e.journal_id, a.currency
+HAVING SUM(e.amount) <> 0
+ORDER BY e.journal_id, a.currency;
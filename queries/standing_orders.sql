@@ -0,0 +1,93 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  schedule,
+  start_at,
+  end_at,
+  max_executions,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetStandingOrderForUpdate :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListStandingOrders :many
+SELECT * FROM standing_orders
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListDueStandingOrders :many
+SELECT * FROM standing_orders
+WHERE status = 'active' AND next_run_at <= $1
+ORDER BY next_run_at
+LIMIT $2;
+
+-- name: PauseStandingOrder :one
+UPDATE standing_orders
+SET status = 'paused'
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: ResumeStandingOrder :one
+UPDATE standing_orders
+SET
+  status = 'active',
+  next_run_at = $2
+WHERE id = $1 AND status = 'paused'
+RETURNING *;
+
+-- name: CancelStandingOrder :one
+UPDATE standing_orders
+SET
+  status = 'cancelled',
+  next_run_at = NULL
+WHERE id = $1 AND status IN ('active', 'paused')
+RETURNING *;
+
+-- name: CancelAccountStandingOrders :exec
+UPDATE standing_orders
+SET
+  status = 'cancelled',
+  next_run_at = NULL
+WHERE (from_account_id = $1 OR to_account_id = $1) AND status IN ('active', 'paused');
+
+-- name: AdvanceStandingOrder :one
+UPDATE standing_orders
+SET
+  status = $2,
+  executions = $3,
+  next_run_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateStandingOrderRun :one
+INSERT INTO standing_order_runs (
+  standing_order_id,
+  scheduled_at,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListStandingOrderRuns :many
+SELECT * FROM standing_order_runs
+WHERE standing_order_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
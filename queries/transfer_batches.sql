@@ -0,0 +1,80 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+  owner,
+  from_account_id,
+  currency,
+  mode,
+  item_count
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferBatchForUpdate :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: FinishTransferBatch :one
+UPDATE transfer_batches
+SET
+  status = $2,
+  succeeded_count = $3,
+  failed_count = $4,
+  finished_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (
+  batch_id,
+  position,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY position
+LIMIT $2
+OFFSET $3;
+
+-- name: ListPendingTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1 AND status = 'pending'
+ORDER BY position;
+
+-- name: GetTransferBatchItemForUpdate :one
+SELECT * FROM transfer_batch_items
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: SetTransferBatchItemResult :one
+UPDATE transfer_batch_items
+SET
+  status = $2,
+  transfer_id = $3,
+  error = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: FailPendingTransferBatchItems :exec
+-- FailPendingTransferBatchItems marks the items that were rolled back with an atomic batch.
+UPDATE transfer_batch_items
+SET
+  status = 'failed',
+  error = $2
+WHERE batch_id = $1 AND status = 'pending';
+
+-- name: CountTransferBatchItems :one
+SELECT
+  COUNT(*) FILTER (WHERE status = 'succeeded')::int AS succeeded,
+  COUNT(*) FILTER (WHERE status = 'failed')::int AS failed
+FROM transfer_batch_items
+WHERE batch_id = $1;
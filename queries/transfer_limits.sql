@@ -0,0 +1,46 @@
+-- name: SetTransferLimit :one
+INSERT INTO transfer_limits (
+  scope,
+  subject,
+  currency,
+  per_transfer,
+  daily,
+  monthly,
+  hourly_count
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (scope, subject, currency) DO UPDATE SET
+  per_transfer = EXCLUDED.per_transfer,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  hourly_count = EXCLUDED.hourly_count,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetTransferLimitForUpdate :one
+SELECT * FROM transfer_limits
+WHERE scope = $1 AND subject = $2 AND currency = $3
+FOR NO KEY UPDATE;
+
+-- name: ListTransferLimits :many
+-- ListTransferLimits returns the limits of the user and of its tier in the currency.
+SELECT * FROM transfer_limits
+WHERE currency = sqlc.arg(currency)
+  AND ((scope = 'user' AND subject = sqlc.arg(username))
+    OR (scope = 'tier' AND subject = sqlc.arg(tier)))
+ORDER BY scope;
+
+-- name: GetTransferUsage :one
+-- GetTransferUsage sums what the owner sent from its accounts in the currency. Reversals
+-- are sent back by the receiving side and don't count.
+SELECT
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint AS day_total,
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS month_total,
+  COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start)) AS hour_count,
+  COALESCE(MIN(t.created_at) FILTER (WHERE t.created_at >= sqlc.arg(hour_start)), sqlc.arg(hour_start))::timestamptz AS hour_first
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND a.currency = sqlc.arg(currency)
+  AND t.reversal_of IS NULL
+  AND t.created_at >= LEAST(sqlc.arg(month_start), sqlc.arg(hour_start));
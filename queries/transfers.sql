@@ -2,15 +2,23 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  journal_id,
+  reversal_of,
+  fee
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE 
@@ -19,3 +27,30 @@ WHERE
 ORDER BY id
 LIMIT $3
 OFFSET $4;
+
+-- name: ListReversals :many
+SELECT * FROM transfers
+WHERE reversal_of = $1
+ORDER BY id;
+
+-- name: AddTransferReversedAmount :one
+UPDATE transfers
+SET reversed_amount = reversed_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListTransferHistory :many
+-- ListTransferHistory returns the transfers of an account newest first after the (created_at, id) cursor, null filters match any transfer.
+SELECT * FROM transfers
+WHERE (
+    (from_account_id = sqlc.arg(account_id) AND sqlc.narg(incoming)::bool IS NOT TRUE) OR
+    (to_account_id = sqlc.arg(account_id) AND sqlc.narg(incoming) IS NOT FALSE)
+  )
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(counterparty_id)::bigint IS NULL OR sqlc.narg(counterparty_id) IN (from_account_id, to_account_id))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL OR (created_at, id) < (sqlc.narg(cursor_created_at), sqlc.arg(cursor_id)::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
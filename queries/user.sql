@@ -23,3 +23,14 @@ SET
 WHERE
   username = sqlc.arg(username)
 RETURNING *;
+
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: SetUserTier :one
+UPDATE users
+SET tier = $2
+WHERE username = $1
+RETURNING *;
@@ -0,0 +1,79 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+  owner,
+  url,
+  secret,
+  event_types
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListWebhookEndpointsForEvent :many
+-- ListWebhookEndpointsForEvent returns the endpoints of owner whose filter lets event_type through.
+SELECT * FROM webhook_endpoints
+WHERE owner = sqlc.arg(owner)
+  AND (cardinality(event_types) = 0 OR sqlc.arg(event_type)::varchar = ANY(event_types))
+ORDER BY id;
+
+-- name: DeleteWebhookEndpoint :one
+DELETE FROM webhook_endpoints
+WHERE id = $1
+RETURNING *;
+
+-- name: RotateWebhookSecret :one
+-- RotateWebhookSecret replaces the secret, the old one keeps signing until previous_secret_expires_at.
+UPDATE webhook_endpoints
+SET
+  previous_secret = secret,
+  previous_secret_expires_at = sqlc.arg(previous_secret_expires_at),
+  secret = sqlc.arg(secret)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+  endpoint_id,
+  event_id,
+  event_type,
+  payload
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: RecordWebhookAttempt :one
+UPDATE webhook_deliveries
+SET
+  status = $2,
+  response_code = $3,
+  last_error = $4,
+  attempts = attempts + 1,
+  last_attempt_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetWebhookDelivery :one
+-- ResetWebhookDelivery makes a delivery pending again before it is redelivered.
+UPDATE webhook_deliveries
+SET status = 'pending'
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozenAccount(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	frozen, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:           acc1.ID,
+		FromStatus:   AccountActive,
+		Status:       AccountFrozen,
+		StatusReason: "court order",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, frozen.Status)
+	require.Equal(t, "court order", frozen.StatusReason)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozen)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:         acc1.ID,
+		FromStatus: AccountActive,
+		Status:     AccountFrozen,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:           acc1.ID,
+		FromStatus:   AccountFrozen,
+		Status:       AccountActive,
+		StatusReason: "order lifted",
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := createRandomAccount(t)
+	acc2 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	_, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID: acc1.ID,
+		Reason:    "moving abroad",
+	})
+	require.ErrorIs(t, err, ErrSweepAccountNeeded)
+
+	res, err := store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      acc1.ID,
+		SweepAccountID: acc2.ID,
+		Reason:         "moving abroad",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, res.Account.Status)
+	require.True(t, res.Account.ClosedAt.Valid)
+	require.Zero(t, res.Account.Balance)
+	require.NotNil(t, res.Sweep)
+	require.Equal(t, acc2.Balance+acc1.Balance, res.Sweep.Accounts[acc2.ID].Balance)
+
+	// the history stays, but nothing can be posted anymore
+	entries, err := testQueries.ListJournalEntries(context.Background(), sql.NullInt64{Int64: res.Sweep.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc2.ID,
+		ToAccountID:   acc1.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = store.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      acc1.ID,
+		SweepAccountID: acc2.ID,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+}
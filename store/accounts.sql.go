@@ -7,13 +7,14 @@ package store
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
 `
 
 type AddAccountBalanceParams struct {
@@ -22,7 +23,7 @@ type AddAccountBalanceParams struct {
 }
 
 func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, addAccountBalance, arg.Amount, arg.ID)
+	row := q.db.QueryRow(ctx, addAccountBalance, arg.Amount, arg.ID)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -30,6 +31,49 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const addAccountHeldBalance = `-- name: AddAccountHeldBalance :one
+UPDATE accounts
+SET held_balance = held_balance + $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
+`
+
+type AddAccountHeldBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error) {
+	row := q.db.QueryRow(ctx, addAccountHeldBalance, arg.Amount, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -41,7 +85,7 @@ INSERT INTO accounts (
   currency
 ) VALUES (
   $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+) RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
 `
 
 type CreateAccountParams struct {
@@ -51,7 +95,7 @@ type CreateAccountParams struct {
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRow(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -59,27 +103,91 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
 	)
 	return i, err
 }
 
 const deleteAccount = `-- name: DeleteAccount :exec
 DELETE FROM accounts
-WHERE id = $1
+WHERE id = $1 AND NOT EXISTS (
+  SELECT 1 FROM entries WHERE account_id = $1
+)
 `
 
+// only accounts without any entries can be deleted, close the others
 func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
+	_, err := q.db.Exec(ctx, deleteAccount, id)
+	return err
+}
+
+const ensureAccount = `-- name: EnsureAccount :exec
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  $1, 0, $2
+) ON CONFLICT (owner, currency) DO NOTHING
+`
+
+type EnsureAccountParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) EnsureAccount(ctx context.Context, arg EnsureAccountParams) error {
+	_, err := q.db.Exec(ctx, ensureAccount, arg.Owner, arg.Currency)
 	return err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
-	row := q.db.QueryRowContext(ctx, getAccount, id)
+	row := q.db.QueryRow(ctx, getAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const getAccountByOwnerAndCurrency = `-- name: GetAccountByOwnerAndCurrency :one
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
+WHERE owner = $1 AND currency = $2 LIMIT 1
+`
+
+type GetAccountByOwnerAndCurrencyParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByOwnerAndCurrency, arg.Owner, arg.Currency)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -87,18 +195,27 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
 
 func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
-	row := q.db.QueryRowContext(ctx, getAccountForUpdate, id)
+	row := q.db.QueryRow(ctx, getAccountForUpdate, id)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -106,12 +223,21 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -125,7 +251,103 @@ type ListAccountsParams struct {
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.AvailableBalance,
+			&i.Status,
+			&i.StatusReason,
+			&i.ClosedAt,
+			&i.OverdraftLimit,
+			&i.OverdraftInterestBps,
+			&i.InterestProductID,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDebitBalances = `-- name: ListDebitBalances :many
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
+WHERE balance < 0 AND NOT (owner = ANY($1::varchar[]))
+ORDER BY balance, id
+LIMIT $2
+OFFSET $3
+`
+
+type ListDebitBalancesParams struct {
+	ExcludedOwners []string `json:"excluded_owners"`
+	Limit          int32    `json:"limit"`
+	Offset         int32    `json:"offset"`
+}
+
+func (q *Queries) ListDebitBalances(ctx context.Context, arg ListDebitBalancesParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listDebitBalances, arg.ExcludedOwners, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.AvailableBalance,
+			&i.Status,
+			&i.StatusReason,
+			&i.ClosedAt,
+			&i.OverdraftLimit,
+			&i.OverdraftInterestBps,
+			&i.InterestProductID,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingAccounts = `-- name: ListInterestBearingAccounts :many
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
+WHERE interest_product_id IS NOT NULL AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListInterestBearingAccountsParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+func (q *Queries) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listInterestBearingAccounts, arg.AfterID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -139,25 +361,183 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.AvailableBalance,
+			&i.Status,
+			&i.StatusReason,
+			&i.ClosedAt,
+			&i.OverdraftLimit,
+			&i.OverdraftInterestBps,
+			&i.InterestProductID,
+			&i.AccountType,
 		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdrawnAccounts = `-- name: ListOverdrawnAccounts :many
+SELECT id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type FROM accounts
+WHERE balance < 0 AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListOverdrawnAccountsParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+func (q *Queries) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listOverdrawnAccounts, arg.AfterID, arg.Limit)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.HeldBalance,
+			&i.AvailableBalance,
+			&i.Status,
+			&i.StatusReason,
+			&i.ClosedAt,
+			&i.OverdraftLimit,
+			&i.OverdraftInterestBps,
+			&i.InterestProductID,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
+const setAccountInterestProduct = `-- name: SetAccountInterestProduct :one
+UPDATE accounts
+SET interest_product_id = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
+`
+
+type SetAccountInterestProductParams struct {
+	ID                int64         `json:"id"`
+	InterestProductID sql.NullInt64 `json:"interest_product_id"`
+}
+
+func (q *Queries) SetAccountInterestProduct(ctx context.Context, arg SetAccountInterestProductParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountInterestProduct, arg.ID, arg.InterestProductID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const setAccountOverdraft = `-- name: SetAccountOverdraft :one
+UPDATE accounts
+SET
+  overdraft_limit = $2,
+  overdraft_interest_bps = $3
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
+`
+
+type SetAccountOverdraftParams struct {
+	ID                   int64 `json:"id"`
+	OverdraftLimit       int64 `json:"overdraft_limit"`
+	OverdraftInterestBps int32 `json:"overdraft_interest_bps"`
+}
+
+func (q *Queries) SetAccountOverdraft(ctx context.Context, arg SetAccountOverdraftParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountOverdraft, arg.ID, arg.OverdraftLimit, arg.OverdraftInterestBps)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const setAccountType = `-- name: SetAccountType :one
+UPDATE accounts
+SET account_type = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
+`
+
+type SetAccountTypeParams struct {
+	ID          int64  `json:"id"`
+	AccountType string `json:"account_type"`
+}
+
+func (q *Queries) SetAccountType(ctx context.Context, arg SetAccountTypeParams) (Account, error) {
+	row := q.db.QueryRow(ctx, setAccountType, arg.ID, arg.AccountType)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
 `
 
 type UpdateAccountParams struct {
@@ -166,7 +546,53 @@ type UpdateAccountParams struct {
 }
 
 func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, updateAccount, arg.ID, arg.Balance)
+	row := q.db.QueryRow(ctx, updateAccount, arg.ID, arg.Balance)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET
+  status = $1,
+  status_reason = $2,
+  closed_at = $3
+WHERE id = $4 AND status = $5
+RETURNING id, owner, balance, currency, created_at, held_balance, available_balance, status, status_reason, closed_at, overdraft_limit, overdraft_interest_bps, interest_product_id, account_type
+`
+
+type UpdateAccountStatusParams struct {
+	Status       string       `json:"status"`
+	StatusReason string       `json:"status_reason"`
+	ClosedAt     sql.NullTime `json:"closed_at"`
+	ID           int64        `json:"id"`
+	FromStatus   string       `json:"from_status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus,
+		arg.Status,
+		arg.StatusReason,
+		arg.ClosedAt,
+		arg.ID,
+		arg.FromStatus,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -174,6 +600,15 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldBalance,
+		&i.AvailableBalance,
+		&i.Status,
+		&i.StatusReason,
+		&i.ClosedAt,
+		&i.OverdraftLimit,
+		&i.OverdraftInterestBps,
+		&i.InterestProductID,
+		&i.AccountType,
 	)
 	return i, err
 }
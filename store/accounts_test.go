@@ -9,11 +9,15 @@ import (
 )
 
 func createRandomAccount(t *testing.T) Account {
+	return createRandomAccountWithCurrency(t, util.RandomCurrency())
+}
+
+func createRandomAccountWithCurrency(t *testing.T, currency string) Account {
 	user := createRandomUser(t)
 	arg := CreateAccountParams{
 		Owner:    user.Username,
 		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Currency: currency,
 	}
 
 	acc, err := testQueries.CreateAccount(context.Background(), arg)
@@ -23,6 +27,9 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, acc.Owner)
 	require.Equal(t, arg.Balance, acc.Balance)
 	require.Equal(t, arg.Currency, acc.Currency)
+	require.Zero(t, acc.HeldBalance)
+	require.Equal(t, arg.Balance, acc.AvailableBalance)
+	require.Equal(t, AccountActive, acc.Status)
 
 	require.NotZero(t, acc.ID)
 	require.NotZero(t, acc.CreatedAt)
@@ -30,6 +37,16 @@ func createRandomAccount(t *testing.T) Account {
 	return acc
 }
 
+// fundAccount sets the balance directly, random balances may be too small for a debit.
+func fundAccount(t *testing.T, acc Account, balance int64) Account {
+	acc, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      acc.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	return acc
+}
+
 func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// AuditActor is who makes a change and from where, as recorded in the audit log.
+type AuditActor struct {
+	Username  string
+	ClientIP  string
+	UserAgent string
+	RequestID string
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor returns a context whose changes are audited on behalf of actor.
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditRecord describes a change for the audit log. Before is nil for creations and After is
+// nil for deletions.
+type AuditRecord struct {
+	Action     string
+	EntityType string
+	EntityID   any
+	Before     any
+	After      any
+}
+
+// recordAudit appends the change to the audit log in the transaction of q. Changes made without
+// an actor in ctx, such as the ones of the background jobs, are not audited.
+func recordAudit(ctx context.Context, q *Queries, rec AuditRecord) error {
+	actor, ok := ctx.Value(auditActorKey{}).(AuditActor)
+	if !ok {
+		return nil
+	}
+	before, err := json.Marshal(redact(rec.Before))
+	if err != nil {
+		return err
+	}
+	after, err := json.Marshal(redact(rec.After))
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateAuditEvent(ctx, CreateAuditEventParams{
+		Actor:      actor.Username,
+		Action:     rec.Action,
+		EntityType: rec.EntityType,
+		EntityID:   fmt.Sprint(rec.EntityID),
+		Before:     before,
+		After:      after,
+		ClientIp:   actor.ClientIP,
+		UserAgent:  actor.UserAgent,
+		RequestID:  actor.RequestID,
+	})
+	return err
+}
+
+// redact keeps password hashes and webhook secrets out of the audit log.
+func redact(v any) any {
+	switch v := v.(type) {
+	case User:
+		v.HashedPassword = ""
+		return v
+	case WebhookEndpoint:
+		v.Secret = ""
+		v.PreviousSecret = ""
+		return v
+	}
+	return v
+}
+
+// AuditTx runs fn in a transaction and appends the change it describes to the audit log in the
+// same transaction, so a change is never committed without its event.
+func (s *SQLStore) AuditTx(ctx context.Context, fn func(q *Queries) (AuditRecord, error)) error {
+	return s.execTx(ctx, func(q *Queries) error {
+		rec, err := fn(q)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, rec)
+	})
+}
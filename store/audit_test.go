@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditTransfer(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 1000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	actor := AuditActor{
+		Username:  acc1.Owner,
+		ClientIP:  "127.0.0.1",
+		UserAgent: "test",
+		RequestID: util.RandomString(16),
+	}
+
+	ctx := WithAuditActor(context.Background(), actor)
+	res, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		RequestID: sql.NullString{String: actor.RequestID, Valid: true},
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	require.Equal(t, actor.Username, event.Actor)
+	require.Equal(t, "create_transfer", event.Action)
+	require.Equal(t, "transfer", event.EntityType)
+	require.Equal(t, fmt.Sprint(res.Transfer.ID), event.EntityID)
+	require.Equal(t, actor.ClientIP, event.ClientIp)
+	require.Equal(t, actor.UserAgent, event.UserAgent)
+	require.JSONEq(t, "null", string(event.Before))
+
+	var after Transfer
+	require.NoError(t, json.Unmarshal(event.After, &after))
+	require.Equal(t, res.Transfer.ID, after.ID)
+
+	// changes without an actor, like the ones of the background jobs, are not audited
+	res, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	events, err = testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		EntityType: sql.NullString{String: "transfer", Valid: true},
+		EntityID:   sql.NullString{String: fmt.Sprint(res.Transfer.ID), Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	store := NewStore(testDB)
+
+	actor := AuditActor{Username: util.RandomOwner(), RequestID: util.RandomString(16)}
+	ctx := WithAuditActor(context.Background(), actor)
+	err := store.AuditTx(ctx, func(q *Queries) (AuditRecord, error) {
+		return AuditRecord{Action: "test", EntityType: "test", EntityID: 1}, nil
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor: sql.NullString{String: actor.Username, Valid: true},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "1", events[0].EntityID)
+
+	_, err = testDB.Exec(context.Background(), "UPDATE audit_events SET actor = 'someone' WHERE id = $1", events[0].ID)
+	require.Error(t, err)
+	_, err = testDB.Exec(context.Background(), "DELETE FROM audit_events WHERE id = $1", events[0].ID)
+	require.Error(t, err)
+}
+
+func TestAuditRedactsPassword(t *testing.T) {
+	user := createRandomUser(t)
+	require.NotEmpty(t, user.HashedPassword)
+
+	ctx := WithAuditActor(context.Background(), AuditActor{Username: user.Username, RequestID: util.RandomString(16)})
+	err := NewStore(testDB).AuditTx(ctx, func(q *Queries) (AuditRecord, error) {
+		return AuditRecord{Action: "test", EntityType: "user", EntityID: user.Username, After: user}, nil
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor: sql.NullString{String: user.Username, Valid: true},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NotContains(t, string(events[0].After), user.HashedPassword)
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const snapshotBatchSize = 1000
+
+// SnapshotBalances takes the balance of every account at asOf, a midnight UTC. The accounts are
+// snapshotted in batches, each in a statement of its own, and snapshots that already exist are
+// kept, so a failed run can simply be repeated. It returns the number of snapshots taken.
+//
+// Entries are dated when they are inserted, the snapshot has to run once the transactions that
+// were open at asOf have committed.
+func (s *SQLStore) SnapshotBalances(ctx context.Context, asOf time.Time) (int64, error) {
+	bounds, err := s.GetLedgerBounds(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var taken int64
+	for afterID := int64(0); afterID < bounds.MaxAccountID; afterID += snapshotBatchSize {
+		n, err := s.CreateBalanceSnapshots(ctx, CreateBalanceSnapshotsParams{
+			AsOf:      asOf,
+			AfterID:   afterID,
+			BatchSize: snapshotBatchSize,
+		})
+		if err != nil {
+			return taken, err
+		}
+		taken += n
+	}
+	return taken, nil
+}
+
+type BalanceAsOf struct {
+	AccountID int64     `json:"account_id"`
+	At        time.Time `json:"at"`
+	// sum of the entries of the account created before At
+	Balance int64 `json:"balance"`
+	// the snapshot the balance was computed from, null when it was summed from all entries
+	SnapshotAt sql.NullTime `json:"snapshot_at"`
+}
+
+// GetBalanceAsOf returns the balance of the account at the given time from the newest snapshot
+// taken at or before it and the entries created since.
+func (s *SQLStore) GetBalanceAsOf(ctx context.Context, accountID int64, at time.Time) (BalanceAsOf, error) {
+	return readOnly(ctx, s, func(q *Queries) (BalanceAsOf, error) {
+		res := BalanceAsOf{AccountID: accountID, At: at}
+		snapshot, err := q.GetLatestBalanceSnapshot(ctx, GetLatestBalanceSnapshotParams{
+			AccountID: accountID,
+			AsOf:      at,
+		})
+		if err != nil && err != ErrRecordNotFound {
+			return res, err
+		}
+		// without a snapshot the sum starts at the first entry
+		if err == nil {
+			res.Balance = snapshot.Balance
+			res.SnapshotAt = sql.NullTime{Time: snapshot.AsOf, Valid: true}
+		}
+
+		since, err := q.SumEntriesBetween(ctx, SumEntriesBetweenParams{
+			AccountID: accountID,
+			Since:     res.SnapshotAt.Time,
+			Until:     at,
+		})
+		res.Balance += since
+		return res, err
+	})
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalanceAsOf(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccount(t), 100)
+	acc := createRandomAccountWithCurrency(t, from.Currency)
+
+	first, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: acc.ID, Amount: 10})
+	require.NoError(t, err)
+	before := first.ToEntry.CreatedAt
+	after := before.Add(time.Microsecond)
+
+	balance, err := store.GetBalanceAsOf(context.Background(), acc.ID, before)
+	require.NoError(t, err)
+	require.Zero(t, balance.Balance)
+
+	balance, err = store.GetBalanceAsOf(context.Background(), acc.ID, after)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), balance.Balance)
+	require.False(t, balance.SnapshotAt.Valid)
+
+	taken, err := store.SnapshotBalances(context.Background(), after)
+	require.NoError(t, err)
+	require.NotZero(t, taken)
+
+	second, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: from.ID, ToAccountID: acc.ID, Amount: 5})
+	require.NoError(t, err)
+
+	// the snapshot plus the entries since
+	balance, err = store.GetBalanceAsOf(context.Background(), acc.ID, second.ToEntry.CreatedAt.Add(time.Microsecond))
+	require.NoError(t, err)
+	require.Equal(t, int64(15), balance.Balance)
+	require.True(t, balance.SnapshotAt.Valid)
+	require.True(t, after.Equal(balance.SnapshotAt.Time))
+
+	// snapshots that exist are kept
+	_, err = store.SnapshotBalances(context.Background(), after)
+	require.NoError(t, err)
+	snapshot, err := testQueries.GetLatestBalanceSnapshot(context.Background(), GetLatestBalanceSnapshotParams{
+		AccountID: acc.ID,
+		AsOf:      after,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), snapshot.Balance)
+}
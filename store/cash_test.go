@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCashTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	teller := createRandomUser(t)
+	acc := createRandomAccountWithCurrency(t, util.USD)
+
+	deposit, err := store.CashTx(context.Background(), CashTxParams{
+		Kind:      JournalDeposit,
+		AccountID: acc.ID,
+		Amount:    500,
+		Teller:    teller.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, JournalDeposit, deposit.Receipt.Kind)
+	require.Equal(t, int64(500), deposit.Receipt.Amount)
+	require.Equal(t, util.USD, deposit.Receipt.Currency)
+	require.Equal(t, teller.Username, deposit.Receipt.Teller)
+	require.Equal(t, int64(500), deposit.Entry.Amount)
+	require.Equal(t, acc.Balance+500, deposit.Account.Balance)
+
+	withdrawal, err := store.CashTx(context.Background(), CashTxParams{
+		Kind:      JournalWithdrawal,
+		AccountID: acc.ID,
+		Amount:    200,
+		Teller:    teller.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(200), withdrawal.Receipt.Amount)
+	require.Equal(t, int64(-200), withdrawal.Entry.Amount)
+	require.Equal(t, acc.Balance+300, withdrawal.Account.Balance)
+
+	_, err = store.CashTx(context.Background(), CashTxParams{
+		Kind:      JournalWithdrawal,
+		AccountID: acc.ID,
+		Amount:    withdrawal.Account.Balance + 1,
+		Teller:    teller.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	totals, err := testQueries.ListTellerTotals(context.Background(), ListTellerTotalsParams{
+		Teller:   teller.Username,
+		FromTime: time.Now().Add(-time.Hour),
+		ToTime:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListTellerTotalsRow{
+		{Currency: util.USD, Kind: JournalDeposit, Count: 1, Total: 500},
+		{Currency: util.USD, Kind: JournalWithdrawal, Count: 1, Total: 200},
+	}, totals)
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// ErrCurrencyInUse is returned for changing the minor units of a currency accounts are open in,
+// their balances would silently change value.
+var ErrCurrencyInUse = errors.New("minor units cannot change once accounts use the currency")
+
+// LoadCurrencies replaces the cached util.Currencies registry with the currencies in the db.
+func LoadCurrencies(ctx context.Context, q Querier) error {
+	currencies, err := q.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	registry := make([]util.Currency, 0, len(currencies))
+	for _, c := range currencies {
+		registry = append(registry, util.Currency{
+			Code:       c.Code,
+			MinorUnits: c.MinorUnits,
+			Symbol:     c.Symbol,
+			Enabled:    c.Enabled,
+		})
+	}
+	util.Currencies.Set(registry)
+	return nil
+}
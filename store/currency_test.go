@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrencies(t *testing.T) {
+	currencies, err := testQueries.ListCurrencies(context.Background())
+	require.NoError(t, err)
+
+	codes := make([]string, 0, len(currencies))
+	for _, c := range currencies {
+		codes = append(codes, c.Code)
+	}
+	require.Contains(t, codes, util.USD)
+	require.Contains(t, codes, util.INR)
+}
+
+func TestUpdateCurrency(t *testing.T) {
+	usd, err := testQueries.GetCurrency(context.Background(), util.USD)
+	require.NoError(t, err)
+
+	updated, err := testQueries.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Code:   util.USD,
+		Symbol: sql.NullString{String: "US$", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "US$", updated.Symbol)
+	require.Equal(t, usd.MinorUnits, updated.MinorUnits)
+	require.Equal(t, usd.Enabled, updated.Enabled)
+
+	_, err = testQueries.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Code:   util.USD,
+		Symbol: sql.NullString{String: usd.Symbol, Valid: true},
+	})
+	require.NoError(t, err)
+}
+
+func TestCurrencyHasAccounts(t *testing.T) {
+	acc := createRandomAccountWithCurrency(t, util.USD)
+
+	inUse, err := testQueries.CurrencyHasAccounts(context.Background(), acc.Currency)
+	require.NoError(t, err)
+	require.True(t, inUse)
+
+	inUse, err = testQueries.CurrencyHasAccounts(context.Background(), "XXX")
+	require.NoError(t, err)
+	require.False(t, inUse)
+}
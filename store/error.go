@@ -0,0 +1,28 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes the callers of the store react to.
+const (
+	ForeignKeyViolation  = "23503"
+	UniqueViolation      = "23505"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+)
+
+// ErrRecordNotFound is returned by the :one queries that match no row.
+var ErrRecordNotFound = pgx.ErrNoRows
+
+// ErrorCode returns the postgres error code of err, or "" when err doesn't come from postgres.
+func ErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Domain event types and the version of their payload.
+const (
+	EventTransferCompleted = "TransferCompleted"
+	EventJournalPosted     = "JournalPosted"
+	EventAccountCreated    = "AccountCreated"
+	EventUserUpdated       = "UserUpdated"
+
+	transferCompletedVersion = 1
+	journalPostedVersion     = 1
+	accountCreatedVersion    = 1
+	userUpdatedVersion       = 1
+)
+
+// EmitEvent writes a domain event. Call it on the queries of a transaction so the event is only
+// published when the change it describes is committed.
+func (q *Queries) EmitEvent(ctx context.Context, event *pb.DomainEvent) error {
+	id := uuid.New()
+	event.Id = id.String()
+	event.OccurredAt = timestamppb.Now()
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return q.CreateDomainEvent(ctx, CreateDomainEventParams{
+		EventID:   id,
+		EventType: event.Type,
+		Version:   event.Version,
+		Payload:   payload,
+	})
+}
+
+func transferCompletedEvent(res TransferTxResult) *pb.DomainEvent {
+	transfer := &pb.TransferCompleted{
+		TransferId:       res.Transfer.ID,
+		FromAccountId:    res.Transfer.FromAccountID,
+		ToAccountId:      res.Transfer.ToAccountID,
+		Amount:           res.Transfer.Amount,
+		Currency:         res.FromAccount.Currency,
+		CreditedAmount:   res.ToEntry.Amount,
+		CreditedCurrency: res.ToAccount.Currency,
+		Fee:              res.Transfer.Fee,
+	}
+	if res.Transfer.ReversalOf.Valid {
+		transfer.ReversalOf = &res.Transfer.ReversalOf.Int64
+	}
+	return &pb.DomainEvent{
+		Type:    EventTransferCompleted,
+		Version: transferCompletedVersion,
+		Payload: &pb.DomainEvent_TransferCompleted{TransferCompleted: transfer},
+	}
+}
+
+func journalPostedEvent(res PostJournalResult) *pb.DomainEvent {
+	entries := make([]*pb.JournalPostedEntry, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		acc := res.Accounts[e.AccountID]
+		entries = append(entries, &pb.JournalPostedEntry{
+			EntryId:   e.ID,
+			AccountId: e.AccountID,
+			Amount:    e.Amount,
+			Currency:  acc.Currency,
+			Balance:   acc.Balance,
+		})
+	}
+	return &pb.DomainEvent{
+		Type:    EventJournalPosted,
+		Version: journalPostedVersion,
+		Payload: &pb.DomainEvent_JournalPosted{JournalPosted: &pb.JournalPosted{
+			JournalId: res.Journal.ID,
+			Kind:      res.Journal.Kind,
+			Reference: res.Journal.Reference,
+			Entries:   entries,
+		}},
+	}
+}
+
+func accountCreatedEvent(acc Account) *pb.DomainEvent {
+	return &pb.DomainEvent{
+		Type:    EventAccountCreated,
+		Version: accountCreatedVersion,
+		Payload: &pb.DomainEvent_AccountCreated{AccountCreated: &pb.AccountCreated{
+			AccountId:   acc.ID,
+			Owner:       acc.Owner,
+			Currency:    acc.Currency,
+			AccountType: acc.AccountType,
+		}},
+	}
+}
+
+// UserUpdatedEvent describes the user after a change of its profile or tier.
+func UserUpdatedEvent(user User) *pb.DomainEvent {
+	return &pb.DomainEvent{
+		Type:    EventUserUpdated,
+		Version: userUpdatedVersion,
+		Payload: &pb.DomainEvent_UserUpdated{UserUpdated: &pb.UserUpdated{
+			Username:          user.Username,
+			FullName:          user.FullName,
+			Email:             user.Email,
+			Tier:              user.Tier,
+			PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
+		}},
+	}
+}
+
+// DecodeDomainEvent returns the event stored in e, with its offset once it is published.
+func DecodeDomainEvent(e DomainEvent) (*pb.DomainEvent, error) {
+	event := &pb.DomainEvent{}
+	if err := proto.Unmarshal(e.Payload, event); err != nil {
+		return nil, err
+	}
+	event.Offset = e.StreamOffset.Int64
+	return event, nil
+}
+
+// NextDomainEventsTx returns the next events to hand to the sink, in offset order. Events that
+// got their offsets before but were never marked published come first with the same offsets.
+// Pending events only get offsets once those are published, so the sink sees the stream in order.
+func (s *SQLStore) NextDomainEventsTx(ctx context.Context, limit int32) ([]*pb.DomainEvent, error) {
+	var events []*pb.DomainEvent
+	err := s.execTx(ctx, func(q *Queries) error {
+		events = nil
+		err := q.LockDomainEventPublisher(ctx)
+		if err != nil {
+			return err
+		}
+		unpublished, err := q.ListUnpublishedDomainEvents(ctx, limit)
+		if err != nil {
+			return err
+		}
+		if len(unpublished) > 0 {
+			events, err = decodeDomainEvents(unpublished)
+			return err
+		}
+
+		pending, err := q.ListPendingDomainEvents(ctx, limit)
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+		offset, err := q.GetLastDomainEventOffset(ctx)
+		if err != nil {
+			return err
+		}
+		for i, e := range pending {
+			offset++
+			err := q.SetDomainEventOffset(ctx, SetDomainEventOffsetParams{
+				StreamOffset: offset,
+				ID:           e.ID,
+			})
+			if err != nil {
+				return err
+			}
+			pending[i].StreamOffset = sql.NullInt64{Int64: offset, Valid: true}
+		}
+		events, err = decodeDomainEvents(pending)
+		return err
+	})
+	return events, err
+}
+
+// MarkDomainEventsPublishedTx records that the sink took events and hands the ones nobody marked
+// before to published, with the queries of the transaction for the changes that go with
+// publishing. Events that concurrent publishers both sent to the sink are handed over once.
+func (s *SQLStore) MarkDomainEventsPublishedTx(ctx context.Context, events []*pb.DomainEvent, published func(q *Queries, events []*pb.DomainEvent) error) error {
+	return s.execTx(ctx, func(q *Queries) error {
+		marked := make([]*pb.DomainEvent, 0, len(events))
+		for _, e := range events {
+			n, err := q.MarkDomainEventPublished(ctx, e.Offset)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				marked = append(marked, e)
+			}
+		}
+		if len(marked) == 0 {
+			return nil
+		}
+		return published(q, marked)
+	})
+}
+
+func decodeDomainEvents(stored []DomainEvent) ([]*pb.DomainEvent, error) {
+	events := make([]*pb.DomainEvent, 0, len(stored))
+	for _, e := range stored {
+		event, err := DecodeDomainEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
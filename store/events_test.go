@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+// publishAllEvents publishes the pending events and returns them.
+func publishAllEvents(t *testing.T, store *SQLStore) []*pb.DomainEvent {
+	var published []*pb.DomainEvent
+	for {
+		events, err := store.NextDomainEventsTx(context.Background(), 100)
+		require.NoError(t, err)
+		if len(events) == 0 {
+			return published
+		}
+		err = store.MarkDomainEventsPublishedTx(context.Background(), events, func(q *Queries, events []*pb.DomainEvent) error {
+			published = append(published, events...)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestTransferCompletedEvent(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+	publishAllEvents(t, store)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 1000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	res, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// failed transfers don't emit anything
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc2.ID,
+		ToAccountID:   acc1.ID,
+		Amount:        1000,
+	})
+	require.Error(t, err)
+
+	events := publishAllEvents(t, store)
+	var found *pb.TransferCompleted
+	for _, e := range events {
+		if transfer := e.GetTransferCompleted(); transfer != nil && transfer.FromAccountId == acc2.ID {
+			require.Fail(t, "event of a failed transfer")
+		}
+		if transfer := e.GetTransferCompleted(); transfer != nil && transfer.TransferId == res.Transfer.ID {
+			require.Equal(t, EventTransferCompleted, e.Type)
+			require.Equal(t, int32(1), e.Version)
+			require.NotEmpty(t, e.Id)
+			found = transfer
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, acc1.ID, found.FromAccountId)
+	require.Equal(t, acc2.ID, found.ToAccountId)
+	require.Equal(t, int64(10), found.Amount)
+	require.Equal(t, util.USD, found.Currency)
+	require.Equal(t, int64(10), found.CreditedAmount)
+	require.Nil(t, found.ReversalOf)
+}
+
+func TestJournalPostedEvent(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+	publishAllEvents(t, store)
+
+	acc := createRandomAccountWithCurrency(t, util.USD)
+	res, err := store.CashTx(context.Background(), CashTxParams{
+		Kind:      JournalDeposit,
+		AccountID: acc.ID,
+		Amount:    250,
+		Teller:    createRandomUser(t).Username,
+	})
+	require.NoError(t, err)
+
+	var found *pb.JournalPosted
+	for _, e := range publishAllEvents(t, store) {
+		if journal := e.GetJournalPosted(); journal != nil && journal.JournalId == res.Entry.JournalID.Int64 {
+			require.Equal(t, EventJournalPosted, e.Type)
+			require.Equal(t, int32(1), e.Version)
+			found = journal
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, JournalDeposit, found.Kind)
+	require.Len(t, found.Entries, 2)
+	credit := found.Entries[1]
+	require.Equal(t, res.Entry.ID, credit.EntryId)
+	require.Equal(t, acc.ID, credit.AccountId)
+	require.Equal(t, int64(250), credit.Amount)
+	require.Equal(t, util.USD, credit.Currency)
+	require.Equal(t, res.Account.Balance, credit.Balance)
+}
+
+func TestPublishDomainEvents(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+	publishAllEvents(t, store)
+
+	for i := 0; i < 3; i++ {
+		err := testQueries.EmitEvent(context.Background(), UserUpdatedEvent(createRandomUser(t)))
+		require.NoError(t, err)
+	}
+
+	// events the sink didn't take come back with the same offsets
+	unpublished, err := store.NextDomainEventsTx(context.Background(), 100)
+	require.NoError(t, err)
+	require.Len(t, unpublished, 3)
+
+	// consumers don't see events before the sink took them
+	stored, err := testQueries.ListDomainEvents(context.Background(), ListDomainEventsParams{
+		AfterOffset: unpublished[0].Offset - 1,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	events := publishAllEvents(t, store)
+	require.Len(t, events, 3)
+	for i, e := range events {
+		require.Equal(t, unpublished[i].Id, e.Id)
+		require.Equal(t, unpublished[i].Offset, e.Offset)
+	}
+
+	// publishing the same events again hands none of them over
+	err = store.MarkDomainEventsPublishedTx(context.Background(), events, func(q *Queries, events []*pb.DomainEvent) error {
+		require.Fail(t, "events handed over twice")
+		return nil
+	})
+	require.NoError(t, err)
+	for i := 1; i < len(events); i++ {
+		require.Equal(t, events[i-1].Offset+1, events[i].Offset)
+	}
+
+	stored, err = testQueries.ListDomainEvents(context.Background(), ListDomainEventsParams{
+		AfterOffset: events[0].Offset - 1,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+	for i, e := range stored {
+		event, err := DecodeDomainEvent(e)
+		require.NoError(t, err)
+		require.Equal(t, events[i].Offset, event.Offset)
+		require.Equal(t, events[i].Id, event.Id)
+		require.Equal(t, EventUserUpdated, event.Type)
+	}
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createFeeRule sets a rule for business accounts only, so it doesn't charge the other tests.
+func createFeeRule(t *testing.T, arg SetFeeRuleParams) FeeRule {
+	arg.AccountType = sql.NullString{String: AccountBusiness, Valid: true}
+	rule, err := testQueries.SetFeeRule(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Currency, rule.Currency)
+	require.Equal(t, arg.AccountType, rule.AccountType)
+	require.Equal(t, arg.Kind, rule.Kind)
+	t.Cleanup(func() {
+		_, err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+		require.NoError(t, err)
+	})
+	return rule
+}
+
+func createBusinessAccount(t *testing.T, balance int64) Account {
+	acc := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), balance)
+	acc, err := testQueries.SetAccountType(context.Background(), SetAccountTypeParams{
+		ID:          acc.ID,
+		AccountType: AccountBusiness,
+	})
+	require.NoError(t, err)
+	return acc
+}
+
+func TestComputeFee(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rule   FeeRule
+		amount int64
+		fee    int64
+	}{
+		{"flat", FeeRule{Kind: FeeFlat, FlatAmount: 50}, 1000, 50},
+		{"percentage", FeeRule{Kind: FeePercentage, RateBps: 150}, 1000, 15},
+		{"rounds half up", FeeRule{Kind: FeePercentage, RateBps: 150}, 1010, 15},
+		{"rounds half up at half", FeeRule{Kind: FeePercentage, RateBps: 50}, 100, 1},
+		{"minimum", FeeRule{Kind: FeePercentage, RateBps: 100, MinAmount: sql.NullInt64{Int64: 25, Valid: true}}, 1000, 25},
+		{"maximum", FeeRule{Kind: FeePercentage, RateBps: 100, MaxAmount: sql.NullInt64{Int64: 300, Valid: true}}, 100000, 300},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := computeFee(tc.rule, tc.amount)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee)
+		})
+	}
+}
+
+func TestGetFeeRule(t *testing.T) {
+	rule := createFeeRule(t, SetFeeRuleParams{Currency: util.USD, Kind: FeeFlat, FlatAmount: 30})
+
+	got, err := testQueries.GetFeeRule(context.Background(), GetFeeRuleParams{
+		Currency:    util.USD,
+		AccountType: AccountBusiness,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rule.ID, got.ID)
+
+	// setting the rule again updates it in place
+	updated := createFeeRule(t, SetFeeRuleParams{Currency: util.USD, Kind: FeePercentage, RateBps: 20})
+	require.Equal(t, rule.ID, updated.ID)
+	require.Equal(t, int64(0), updated.FlatAmount)
+	require.Equal(t, int32(20), updated.RateBps)
+}
+
+func TestTransferFee(t *testing.T) {
+	store := NewStore(testDB)
+	createFeeRule(t, SetFeeRuleParams{
+		Currency:  util.USD,
+		Kind:      FeePercentage,
+		RateBps:   100,
+		MinAmount: sql.NullInt64{Int64: 5, Valid: true},
+	})
+
+	acc1 := createBusinessAccount(t, 1000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	fees, err := SystemAccount(context.Background(), testQueries, SystemFees, util.USD)
+	require.NoError(t, err)
+
+	res, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        200,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), res.Transfer.Fee)
+	require.Equal(t, acc1.Balance-205, res.FromAccount.Balance)
+	require.Equal(t, int64(200), res.ToEntry.Amount)
+
+	require.Len(t, res.FeeEntries, 2)
+	require.Equal(t, acc1.ID, res.FeeEntries[0].AccountID)
+	require.Equal(t, int64(-5), res.FeeEntries[0].Amount)
+	require.Equal(t, fees.ID, res.FeeEntries[1].AccountID)
+	require.Equal(t, int64(5), res.FeeEntries[1].Amount)
+	require.Equal(t, res.FromEntry.JournalID, res.FeeEntries[1].JournalID)
+
+	updatedFees, err := testQueries.GetAccount(context.Background(), fees.ID)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, updatedFees.Balance, fees.Balance+5)
+
+	// the fee counts towards the funds the sender needs
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        res.FromAccount.Balance,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// accounts of other types aren't charged
+	acc3 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 1000)
+	res, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc3.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        200,
+	})
+	require.NoError(t, err)
+	require.Zero(t, res.Transfer.Fee)
+	require.Empty(t, res.FeeEntries)
+}
+
+func TestCaptureHoldFee(t *testing.T) {
+	store := NewStore(testDB)
+	createFeeRule(t, SetFeeRuleParams{Currency: util.USD, Kind: FeeFlat, FlatAmount: 5})
+
+	acc1 := createBusinessAccount(t, 1000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	hold, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   acc1.ID,
+		ToAccountID: acc2.ID,
+		Amount:      200,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	res, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.Hold.ID, Amount: 200})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), res.Transfer.Transfer.Fee)
+	require.Len(t, res.Transfer.FeeEntries, 2)
+	require.Equal(t, acc1.Balance-205, res.Transfer.FromAccount.Balance)
+	require.Zero(t, res.Transfer.FromAccount.HeldBalance)
+
+	// the fee isn't part of the hold, the available balance has to cover it
+	hold, err = store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   acc1.ID,
+		ToAccountID: acc2.ID,
+		Amount:      res.Transfer.FromAccount.Balance,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.Hold.ID, Amount: hold.Hold.Amount})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
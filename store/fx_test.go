@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomFxQuote(t *testing.T, from Account, to Account, houseFrom Account, houseTo Account, expiresAt time.Time) FxQuote {
+	arg := CreateFxQuoteParams{
+		ID:                 uuid.New(),
+		Username:           from.Owner,
+		FromAccountID:      from.ID,
+		ToAccountID:        to.ID,
+		HouseFromAccountID: houseFrom.ID,
+		HouseToAccountID:   houseTo.ID,
+		Amount:             100,
+		Rate:               "82.5",
+		ConvertedAmount:    8250,
+		SpreadAmount:       62,
+		ExpiresAt:          expiresAt,
+	}
+	quote, err := testQueries.CreateFxQuote(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, quote.ID)
+	require.False(t, quote.TransferID.Valid)
+	return quote
+}
+
+func TestFxTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to := createRandomAccountWithCurrency(t, util.INR)
+	houseFrom := createRandomAccountWithCurrency(t, util.USD)
+	houseTo := createRandomAccountWithCurrency(t, util.INR)
+	quote := createRandomFxQuote(t, from, to, houseFrom, houseTo, time.Now().Add(time.Minute))
+
+	arg := TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        quote.Amount,
+		QuoteID:       uuid.NullUUID{UUID: quote.ID, Valid: true},
+	}
+	res, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, -quote.Amount, res.FromEntry.Amount)
+	require.Equal(t, quote.ConvertedAmount, res.ToEntry.Amount)
+	require.Equal(t, from.Balance-quote.Amount, res.FromAccount.Balance)
+	require.Equal(t, to.Balance+quote.ConvertedAmount, res.ToAccount.Balance)
+	require.NotNil(t, res.Quote)
+	require.Equal(t, res.Transfer.ID, res.Quote.TransferID.Int64)
+	require.Len(t, res.FxEntries, 3)
+
+	// the spread is income of the bank, not part of its FX position
+	income, err := SystemAccount(context.Background(), testQueries, SystemFxIncome, util.INR)
+	require.NoError(t, err)
+	require.Equal(t, income.ID, res.FxEntries[2].AccountID)
+	require.Equal(t, quote.SpreadAmount, res.FxEntries[2].Amount)
+
+	// every currency nets to zero
+	sums := map[int64]int64{}
+	for _, e := range append(res.FxEntries, res.FromEntry, res.ToEntry) {
+		sums[e.AccountID] += e.Amount
+	}
+	require.Zero(t, sums[from.ID]+sums[houseFrom.ID])
+	require.Zero(t, sums[to.ID]+sums[houseTo.ID]+sums[income.ID])
+	require.Equal(t, -(quote.ConvertedAmount + quote.SpreadAmount), sums[houseTo.ID])
+
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUsed)
+}
+
+func TestFxTransferTxExpiredQuote(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := createRandomAccountWithCurrency(t, util.USD)
+	to := createRandomAccountWithCurrency(t, util.INR)
+	quote := createRandomFxQuote(t, from, to, from, to, time.Now().Add(-time.Second))
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        quote.Amount,
+		QuoteID:       uuid.NullUUID{UUID: quote.ID, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrQuoteExpired)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        quote.Amount,
+		QuoteID:       uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	})
+	require.ErrorIs(t, err, ErrQuoteNotFound)
+}
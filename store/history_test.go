@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTransferHistory(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := fundAccount(t, createRandomAccountWithCurrency(t, acc1.Currency), 100)
+	acc3 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	var transfers []Transfer
+	for _, arg := range []TransferTxParams{
+		{FromAccountID: acc1.ID, ToAccountID: acc2.ID, Amount: 10},
+		{FromAccountID: acc1.ID, ToAccountID: acc3.ID, Amount: 20},
+		{FromAccountID: acc2.ID, ToAccountID: acc1.ID, Amount: 5},
+	} {
+		res, err := store.TransferTx(context.Background(), arg)
+		require.NoError(t, err)
+		transfers = append(transfers, res.Transfer)
+	}
+
+	all, err := testQueries.ListTransferHistory(context.Background(), ListTransferHistoryParams{
+		AccountID: acc1.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	// newest first
+	require.Equal(t, transfers[2].ID, all[0].ID)
+	require.Equal(t, transfers[0].ID, all[2].ID)
+
+	incoming, err := testQueries.ListTransferHistory(context.Background(), ListTransferHistoryParams{
+		AccountID: acc1.ID,
+		Incoming:  sql.NullBool{Bool: true, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	require.Equal(t, transfers[2].ID, incoming[0].ID)
+
+	large, err := testQueries.ListTransferHistory(context.Background(), ListTransferHistoryParams{
+		AccountID: acc1.ID,
+		Incoming:  sql.NullBool{Bool: false, Valid: true},
+		MinAmount: sql.NullInt64{Int64: 15, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, large, 1)
+	require.Equal(t, transfers[1].ID, large[0].ID)
+
+	withAcc2, err := testQueries.ListTransferHistory(context.Background(), ListTransferHistoryParams{
+		AccountID:      acc1.ID,
+		CounterpartyID: sql.NullInt64{Int64: acc2.ID, Valid: true},
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, withAcc2, 2)
+
+	// the cursor continues after the last row of the previous page
+	page, err := testQueries.ListTransferHistory(context.Background(), ListTransferHistoryParams{
+		AccountID:       acc1.ID,
+		CursorCreatedAt: sql.NullTime{Time: all[1].CreatedAt, Valid: true},
+		CursorID:        all[1].ID,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, all[2].ID, page[0].ID)
+}
+
+func TestListEntryHistory(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := fundAccount(t, createRandomAccountWithCurrency(t, acc1.Currency), 100)
+	acc3 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: acc1.ID, ToAccountID: acc3.ID, Amount: 20})
+	require.NoError(t, err)
+	res, err := store.TransferTx(context.Background(), TransferTxParams{FromAccountID: acc2.ID, ToAccountID: acc1.ID, Amount: 5})
+	require.NoError(t, err)
+
+	credits, err := testQueries.ListEntryHistory(context.Background(), ListEntryHistoryParams{
+		AccountID: acc1.ID,
+		Incoming:  sql.NullBool{Bool: true, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, credits, 1)
+	require.Equal(t, res.ToEntry.ID, credits[0].ID)
+
+	withAcc3, err := testQueries.ListEntryHistory(context.Background(), ListEntryHistoryParams{
+		AccountID:      acc1.ID,
+		CounterpartyID: sql.NullInt64{Int64: acc3.ID, Valid: true},
+		MinAmount:      sql.NullInt64{Int64: 20, Valid: true},
+		MaxAmount:      sql.NullInt64{Int64: 20, Valid: true},
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, withAcc3, 1)
+	require.Equal(t, int64(-20), withAcc3[0].Amount)
+}
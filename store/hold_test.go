@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomHold(t *testing.T, store Store, amount int64, expiresAt time.Time) (Hold, Account, Account) {
+	acc1 := createRandomAccount(t)
+	acc2 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	acc1 = fundAccount(t, acc1, 100)
+
+	res, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   acc1.ID,
+		ToAccountID: acc2.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, res.Hold.Status)
+	require.Equal(t, amount, res.Hold.Amount)
+	require.Equal(t, acc1.Currency, res.Hold.Currency)
+
+	// a hold only lowers the available balance
+	require.Equal(t, acc1.Balance, res.Account.Balance)
+	require.Equal(t, amount, res.Account.HeldBalance)
+	require.Equal(t, acc1.Balance-amount, res.Account.AvailableBalance)
+	return res.Hold, acc1, acc2
+}
+
+func TestCreateHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	hold, acc, _ := createRandomHold(t, store, 10, time.Now().Add(time.Hour))
+
+	_, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   acc.ID,
+		ToAccountID: hold.ToAccountID,
+		Amount:      acc.Balance - 10 + 1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	acc, err = testQueries.GetAccount(context.Background(), acc.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), acc.HeldBalance)
+}
+
+func TestHoldTransferLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 10000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	createTransferLimit(t, SetTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Subject:     acc1.Owner,
+		Currency:    util.USD,
+		PerTransfer: sql.NullInt64{Int64: 500, Valid: true},
+		Daily:       sql.NullInt64{Int64: 800, Valid: true},
+	})
+
+	arg := CreateHoldTxParams{
+		AccountID:   acc1.ID,
+		ToAccountID: acc2.ID,
+		Amount:      600,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	_, err := store.CreateHoldTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	arg.Amount = 500
+	res, err := store.CreateHoldTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// the hold doesn't use up the limit until it's captured
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        400,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: res.Hold.ID, Amount: 500})
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitDaily, limitErr.Limit)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: res.Hold.ID, Amount: 400})
+	require.NoError(t, err)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	hold, acc1, acc2 := createRandomHold(t, store, 10, time.Now().Add(time.Hour))
+
+	res, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.ID, Amount: 4})
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, res.Hold.Status)
+	require.Equal(t, int64(4), res.Hold.CapturedAmount)
+	require.Equal(t, int64(4), res.Transfer.Transfer.Amount)
+	require.Equal(t, acc1.Balance-4, res.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(6), res.Transfer.FromAccount.HeldBalance)
+	require.Equal(t, acc1.Balance-10, res.Transfer.FromAccount.AvailableBalance)
+	require.Equal(t, acc2.Balance+4, res.Transfer.ToAccount.Balance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.ID, Amount: 7})
+	require.ErrorIs(t, err, ErrHoldAmountExceeded)
+
+	// the final capture releases the rest of the hold
+	res, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.ID, Amount: 2, Final: true})
+	require.NoError(t, err)
+	require.Equal(t, HoldCaptured, res.Hold.Status)
+	require.Equal(t, int64(6), res.Hold.CapturedAmount)
+	require.Equal(t, acc1.Balance-6, res.Transfer.FromAccount.Balance)
+	require.Zero(t, res.Transfer.FromAccount.HeldBalance)
+	require.Equal(t, acc1.Balance-6, res.Transfer.FromAccount.AvailableBalance)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.ID, Amount: 1})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	hold, acc, _ := createRandomHold(t, store, 10, time.Now().Add(time.Hour))
+
+	_, err := store.ExpireHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotExpired)
+
+	res, err := store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldVoided, res.Hold.Status)
+	require.Zero(t, res.Account.HeldBalance)
+	require.Equal(t, acc.Balance, res.Account.AvailableBalance)
+
+	_, err = store.VoidHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+	hold, acc, _ := createRandomHold(t, store, 10, time.Now().Add(-time.Second))
+
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{ID: hold.ID, Amount: 10})
+	require.ErrorIs(t, err, ErrHoldExpired)
+
+	holds, err := testQueries.ListExpiredHolds(context.Background(), ListExpiredHoldsParams{
+		ExpiresAt: time.Now(),
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	var ids []int64
+	for _, h := range holds {
+		ids = append(ids, h.ID)
+	}
+	require.Contains(t, ids, hold.ID)
+
+	res, err := store.ExpireHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, res.Hold.Status)
+	require.Equal(t, acc.Balance, res.Account.AvailableBalance)
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	key := util.RandomString(16)
+
+	calls := 0
+	arg := IdempotencyTxParams{
+		Username:    user.Username,
+		Key:         key,
+		RequestHash: util.RandomString(32),
+		ExpiresAt:   time.Now().Add(time.Hour),
+		Handle: func() (int32, []byte, error) {
+			calls++
+			return 200, []byte(`{"id":1}`), nil
+		},
+	}
+
+	res1, err := store.IdempotencyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, res1.Replayed)
+	require.Equal(t, int32(200), res1.ResponseCode)
+
+	res2, err := store.IdempotencyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, res2.Replayed)
+	require.Equal(t, res1.ResponseCode, res2.ResponseCode)
+	require.Equal(t, res1.ResponseBody, res2.ResponseBody)
+	require.Equal(t, 1, calls)
+
+	arg.RequestHash = util.RandomString(32)
+	_, err = store.IdempotencyTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	require.Equal(t, 1, calls)
+}
+
+func TestIdempotencyTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	key := util.RandomString(16)
+	hash := util.RandomString(32)
+
+	n := 5
+	calls := make(chan struct{}, n)
+	results := make(chan IdempotencyTxResult)
+	errs := make(chan error)
+
+	for range n {
+		go func() {
+			res, err := store.IdempotencyTx(context.Background(), IdempotencyTxParams{
+				Username:    user.Username,
+				Key:         key,
+				RequestHash: hash,
+				ExpiresAt:   time.Now().Add(time.Hour),
+				Handle: func() (int32, []byte, error) {
+					calls <- struct{}{}
+					return 200, []byte(`{}`), nil
+				},
+			})
+			errs <- err
+			results <- res
+		}()
+	}
+
+	// duplicates either replay the response or find the first request still in progress
+	for range n {
+		if err := <-errs; err != nil {
+			require.ErrorIs(t, err, ErrIdempotencyKeyInProgress)
+		}
+		<-results
+	}
+	require.Len(t, calls, 1)
+
+	res, err := store.IdempotencyTx(context.Background(), IdempotencyTxParams{
+		Username:    user.Username,
+		Key:         key,
+		RequestHash: hash,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.True(t, res.Replayed)
+}
+
+func TestIdempotencyTxHandlerError(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	arg := IdempotencyTxParams{
+		Username:    user.Username,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(32),
+		ExpiresAt:   time.Now().Add(time.Hour),
+		Handle: func() (int32, []byte, error) {
+			return 0, nil, errors.New("rejected")
+		},
+	}
+	_, err := store.IdempotencyTx(context.Background(), arg)
+	require.EqualError(t, err, "rejected")
+
+	// the claim is released so the request can be retried
+	arg.Handle = func() (int32, []byte, error) {
+		return 200, []byte(`{}`), nil
+	}
+	res, err := store.IdempotencyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, res.Replayed)
+}
+
+func TestIdempotencyKeyExpiry(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	calls := 0
+	arg := IdempotencyTxParams{
+		Username:    user.Username,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(32),
+		ExpiresAt:   time.Now().Add(-time.Second),
+		Handle: func() (int32, []byte, error) {
+			calls++
+			return 200, []byte(`{}`), nil
+		},
+	}
+	_, err := store.IdempotencyTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// an expired key is claimed again, even for a different request
+	arg.RequestHash = util.RandomString(32)
+	res, err := store.IdempotencyTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, res.Replayed)
+	require.Equal(t, 2, calls)
+
+	n, err := testQueries.DeleteExpiredIdempotencyKeys(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, n, int64(1))
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: user.Username,
+		Key:      arg.Key,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+}
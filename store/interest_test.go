@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createInterestAccount(t *testing.T, balance int64, rateBps int32) Account {
+	product, err := testQueries.CreateInterestProduct(context.Background(), CreateInterestProductParams{
+		Name:          "savings " + util.RandomString(8),
+		Currency:      util.USD,
+		AnnualRateBps: rateBps,
+		DayCount:      util.DayCountActual365,
+	})
+	require.NoError(t, err)
+
+	acc := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), balance)
+	acc, err = testQueries.SetAccountInterestProduct(context.Background(), SetAccountInterestProductParams{
+		ID:                acc.ID,
+		InterestProductID: sql.NullInt64{Int64: product.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, acc.InterestProductID.Int64)
+	return acc
+}
+
+func TestInterest(t *testing.T) {
+	store := NewStore(testDB)
+
+	// 3.65% on 1000.00 earns 10 cents a day
+	acc := createInterestAccount(t, 100000, 365)
+	day := time.Now().UTC()
+
+	accrued, err := store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: acc.ID,
+		Day:       day,
+	})
+	require.NoError(t, err)
+	require.False(t, accrued.Skipped)
+	require.Equal(t, int64(100000), accrued.Accrual.Balance)
+	require.Equal(t, int64(10*util.MicrosPerUnit), accrued.Accrual.AmountMicros)
+
+	// accruing the same day again is a no-op
+	accrued, err = store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: acc.ID,
+		Day:       day,
+	})
+	require.NoError(t, err)
+	require.True(t, accrued.Skipped)
+
+	capitalized, err := store.CapitalizeInterestTx(context.Background(), CapitalizeInterestTxParams{
+		AccountID: acc.ID,
+		Period:    day,
+	})
+	require.NoError(t, err)
+	require.False(t, capitalized.Skipped)
+	require.Equal(t, int64(10), capitalized.Capitalization.Amount)
+	require.Zero(t, capitalized.Capitalization.CarriedMicros)
+	require.True(t, capitalized.Capitalization.JournalID.Valid)
+	require.Equal(t, int64(100010), capitalized.Account.Balance)
+
+	capitalized, err = store.CapitalizeInterestTx(context.Background(), CapitalizeInterestTxParams{
+		AccountID: acc.ID,
+		Period:    day,
+	})
+	require.NoError(t, err)
+	require.True(t, capitalized.Skipped)
+
+	accruals, err := testQueries.ListInterestAccruals(context.Background(), ListInterestAccrualsParams{
+		AccountID: acc.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	require.Equal(t, capitalized.Capitalization.ID, accruals[0].CapitalizationID.Int64)
+}
+
+func TestInterestCarriedOver(t *testing.T) {
+	store := NewStore(testDB)
+
+	// a cent earns a fraction of a cent, nothing is posted until it adds up to a whole cent
+	acc := createInterestAccount(t, 1, 100)
+	day := time.Now().UTC()
+
+	accrued, err := store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: acc.ID,
+		Day:       day,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(27), accrued.Accrual.AmountMicros)
+
+	capitalized, err := store.CapitalizeInterestTx(context.Background(), CapitalizeInterestTxParams{
+		AccountID: acc.ID,
+		Period:    day,
+	})
+	require.NoError(t, err)
+	require.False(t, capitalized.Skipped)
+	require.Zero(t, capitalized.Capitalization.Amount)
+	require.Equal(t, int64(27), capitalized.Capitalization.CarriedMicros)
+	require.False(t, capitalized.Capitalization.JournalID.Valid)
+	require.Equal(t, int64(1), capitalized.Account.Balance)
+}
+
+func TestAccrueInterestWithoutProduct(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc := createRandomAccount(t)
+	accrued, err := store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: acc.ID,
+		Day:       time.Now(),
+	})
+	require.NoError(t, err)
+	require.True(t, accrued.Skipped)
+}
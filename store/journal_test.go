@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemAccount(t *testing.T) {
+	acc1, err := SystemAccount(context.Background(), testQueries, SystemCash, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, SystemCash, acc1.Owner)
+	require.Equal(t, util.USD, acc1.Currency)
+	require.True(t, IsSystemAccount(acc1))
+
+	acc2, err := SystemAccount(context.Background(), testQueries, SystemCash, util.USD)
+	require.NoError(t, err)
+	require.Equal(t, acc1.ID, acc2.ID)
+
+	require.False(t, IsSystemAccount(createRandomAccount(t)))
+}
+
+func TestPostJournal(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc := createRandomAccountWithCurrency(t, util.USD)
+	cash, err := SystemAccount(context.Background(), testQueries, SystemCash, util.USD)
+	require.NoError(t, err)
+	amount := util.RandomMoney()
+
+	res, err := store.PostJournal(context.Background(), PostJournalParams{
+		Kind:      "deposit",
+		Reference: "test deposit",
+		Postings: []Posting{
+			{AccountID: cash.ID, Amount: -amount},
+			{AccountID: acc.ID, Amount: amount},
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, res.Journal.ID)
+	require.Equal(t, "deposit", res.Journal.Kind)
+	require.Equal(t, "test deposit", res.Journal.Reference)
+
+	require.Len(t, res.Entries, 2)
+	require.Equal(t, cash.ID, res.Entries[0].AccountID)
+	require.Equal(t, -amount, res.Entries[0].Amount)
+	require.Equal(t, acc.ID, res.Entries[1].AccountID)
+	require.Equal(t, amount, res.Entries[1].Amount)
+	require.Equal(t, acc.Balance+amount, res.Accounts[acc.ID].Balance)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), sql.NullInt64{Int64: res.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, res.Entries, entries)
+}
+
+func TestPostJournalRejected(t *testing.T) {
+	store := NewStore(testDB)
+
+	usd1 := createRandomAccountWithCurrency(t, util.USD)
+	usd2 := createRandomAccountWithCurrency(t, util.USD)
+	inr := createRandomAccountWithCurrency(t, util.INR)
+
+	testCases := []struct {
+		name     string
+		postings []Posting
+		err      error
+	}{
+		{
+			name:     "TooShort",
+			postings: []Posting{{AccountID: usd1.ID, Amount: 10}},
+			err:      ErrJournalTooShort,
+		},
+		{
+			name:     "ZeroAmount",
+			postings: []Posting{{AccountID: usd1.ID, Amount: 0}, {AccountID: usd2.ID, Amount: 0}},
+			err:      ErrJournalTooShort,
+		},
+		{
+			name:     "Unbalanced",
+			postings: []Posting{{AccountID: usd1.ID, Amount: -10}, {AccountID: usd2.ID, Amount: 5}},
+			err:      ErrJournalUnbalanced,
+		},
+		{
+			name:     "CurrencyMismatch",
+			postings: []Posting{{AccountID: usd1.ID, Amount: -10}, {AccountID: inr.ID, Amount: 10}},
+			err:      ErrJournalUnbalanced,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.PostJournal(context.Background(), PostJournalParams{
+				Kind:     JournalTransfer,
+				Postings: tc.postings,
+			})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	acc, err := testQueries.GetAccount(context.Background(), usd1.ID)
+	require.NoError(t, err)
+	require.Equal(t, usd1.Balance, acc.Balance)
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTransferLimit(t *testing.T, arg SetTransferLimitParams) TransferLimit {
+	limit, err := testQueries.SetTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Scope, limit.Scope)
+	require.Equal(t, arg.Subject, limit.Subject)
+	require.Equal(t, arg.PerTransfer, limit.PerTransfer)
+	require.Equal(t, arg.Daily, limit.Daily)
+	require.Equal(t, arg.Monthly, limit.Monthly)
+	require.Equal(t, arg.HourlyCount, limit.HourlyCount)
+	return limit
+}
+
+func TestTransferLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 10000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	createTransferLimit(t, SetTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Subject:     acc1.Owner,
+		Currency:    util.USD,
+		PerTransfer: sql.NullInt64{Int64: 500, Valid: true},
+		Daily:       sql.NullInt64{Int64: 800, Valid: true},
+	})
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: acc1.ID,
+			ToAccountID:   acc2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	err := transfer(600)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitPerTransfer, limitErr.Limit)
+	require.True(t, limitErr.ResetsAt.IsZero())
+
+	require.NoError(t, transfer(400))
+	require.NoError(t, transfer(400))
+
+	err = transfer(100)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitDaily, limitErr.Limit)
+	require.Equal(t, int64(800), limitErr.Max)
+	now := time.Now().UTC()
+	require.Equal(t, time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC), limitErr.ResetsAt)
+
+	// the limits only apply to the currency they're set for
+	acc3, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    acc1.Owner,
+		Balance:  10000,
+		Currency: util.INR,
+	})
+	require.NoError(t, err)
+	acc4 := createRandomAccountWithCurrency(t, util.INR)
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc3.ID,
+		ToAccountID:   acc4.ID,
+		Amount:        600,
+	})
+	require.NoError(t, err)
+}
+
+func TestHourlyTransferLimit(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 10000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	createTransferLimit(t, SetTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Subject:     acc1.Owner,
+		Currency:    util.USD,
+		HourlyCount: sql.NullInt32{Int32: 2, Valid: true},
+	})
+
+	var first Transfer
+	for i := 0; i < 2; i++ {
+		res, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: acc1.ID,
+			ToAccountID:   acc2.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+		if i == 0 {
+			first = res.Transfer
+		}
+	}
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitHourlyCount, limitErr.Limit)
+	require.WithinDuration(t, first.CreatedAt.Add(time.Hour), limitErr.ResetsAt, time.Second)
+}
+
+func TestTierTransferLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 10000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+
+	tier := "t_" + util.RandomString(8)
+	user, err := testQueries.SetUserTier(context.Background(), SetUserTierParams{
+		Username: acc1.Owner,
+		Tier:     tier,
+	})
+	require.NoError(t, err)
+	require.Equal(t, tier, user.Tier)
+
+	createTransferLimit(t, SetTransferLimitParams{
+		Scope:       LimitScopeTier,
+		Subject:     tier,
+		Currency:    util.USD,
+		PerTransfer: sql.NullInt64{Int64: 100, Valid: true},
+		Daily:       sql.NullInt64{Int64: 300, Valid: true},
+	})
+	// the user limit raises the per transfer limit, the daily limit still comes from the tier
+	createTransferLimit(t, SetTransferLimitParams{
+		Scope:       LimitScopeUser,
+		Subject:     acc1.Owner,
+		Currency:    util.USD,
+		PerTransfer: sql.NullInt64{Int64: 200, Valid: true},
+	})
+
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: acc1.ID,
+			ToAccountID:   acc2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	var limitErr *LimitError
+	require.ErrorAs(t, transfer(250), &limitErr)
+	require.Equal(t, LimitPerTransfer, limitErr.Limit)
+	require.Equal(t, int64(200), limitErr.Max)
+
+	require.NoError(t, transfer(150))
+	require.NoError(t, transfer(150))
+
+	require.ErrorAs(t, transfer(50), &limitErr)
+	require.Equal(t, LimitDaily, limitErr.Limit)
+	require.Equal(t, int64(300), limitErr.Max)
+}
+
+func TestMergeTransferLimits(t *testing.T) {
+	limit := mergeTransferLimits([]TransferLimit{
+		{
+			Scope:       LimitScopeTier,
+			PerTransfer: sql.NullInt64{Int64: 100, Valid: true},
+			Monthly:     sql.NullInt64{Int64: 1000, Valid: true},
+		},
+		{
+			Scope:       LimitScopeUser,
+			PerTransfer: sql.NullInt64{Int64: 200, Valid: true},
+			HourlyCount: sql.NullInt32{Int32: 5, Valid: true},
+		},
+	})
+	require.Equal(t, int64(200), limit.PerTransfer.Int64)
+	require.False(t, limit.Daily.Valid)
+	require.Equal(t, int64(1000), limit.Monthly.Int64)
+	require.Equal(t, int32(5), limit.HourlyCount.Int32)
+}
@@ -1,17 +1,17 @@
 package store
 
 import (
-	"database/sql"
+	"context"
 	"log"
 	"os"
 	"testing"
 
 	"github.com/anil1226/go-simplebank-grpc/util"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var testQueries *Queries
-var testDB *sql.DB
+var testDB *pgxpool.Pool
 
 func TestMain(m *testing.M) {
 	var err error
@@ -19,7 +19,7 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatal("not able to load config")
 	}
-	testDB, err = sql.Open(config.DbDriver, config.DbSource)
+	testDB, err = NewConnPool(context.Background(), config)
 	if err != nil {
 		log.Fatal("not able to connect to db")
 	}
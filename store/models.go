@@ -5,25 +5,297 @@
 package store
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
+	ID    int64  `json:"id"`
+	Owner string `json:"owner"`
+	// ledger balance, in minor units of currency
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// sum of the active holds, in minor units of currency
+	HeldBalance int64 `json:"held_balance"`
+	// balance minus held_balance
+	AvailableBalance int64 `json:"available_balance"`
+	// active, frozen or closed
+	Status string `json:"status"`
+	// why the account was last frozen, unfrozen or closed
+	StatusReason string       `json:"status_reason"`
+	ClosedAt     sql.NullTime `json:"closed_at"`
+	// how far balance may go below zero, in minor units of currency
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// yearly interest on a negative balance, in basis points
+	OverdraftInterestBps int32         `json:"overdraft_interest_bps"`
+	InterestProductID    sql.NullInt64 `json:"interest_product_id"`
+	// checking, savings or business
+	AccountType string `json:"account_type"`
+}
+
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// username of the caller, the new user for CreateUser
+	Actor string `json:"actor"`
+	// create_transfer, freeze_account, ...
+	Action     string `json:"action"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	// entity before the change, JSON null when it was created
+	Before json.RawMessage `json:"before"`
+	// entity after the change, JSON null when it was deleted
+	After     json.RawMessage `json:"after"`
+	ClientIp  string          `json:"client_ip"`
+	UserAgent string          `json:"user_agent"`
+	RequestID string          `json:"request_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	AccountID int64 `json:"account_id"`
+	// midnight UTC that ends the day of the snapshot
+	AsOf time.Time `json:"as_of"`
+	// sum of the entries of the account created before as_of
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CashReceipt struct {
+	ID int64 `json:"id"`
+	// deposit or withdrawal
+	Kind      string `json:"kind"`
+	AccountID int64  `json:"account_id"`
+	// cash handed over, in minor units of currency, always positive
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// username of the teller who handled the cash
+	Teller    string    `json:"teller"`
+	JournalID int64     `json:"journal_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 alphabetic code
+	Code string `json:"code"`
+	// ISO 4217 exponent, 2 means amounts are stored in cents
+	MinorUnits int32     `json:"minor_units"`
+	Symbol     string    `json:"symbol"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DomainEvent struct {
+	ID        int64     `json:"id"`
+	EventID   uuid.UUID `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Version   int32     `json:"version"`
+	// protobuf encoded pb.DomainEvent, without its offset
+	Payload []byte `json:"payload"`
+	// assigned in publishing order, null while the event is pending
+	StreamOffset sql.NullInt64 `json:"stream_offset"`
+	// set once the sink took the event, null while it has to be published again
+	PublishedAt sql.NullTime `json:"published_at"`
+	CreatedAt   time.Time    `json:"created_at"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
-	// can be negative or positive
+	// in minor units of the account currency, can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// null for entries posted before journals existed
+	JournalID sql.NullInt64 `json:"journal_id"`
+}
+
+type FeeRule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	// type of the sending account, null for any type without a rule of its own
+	AccountType sql.NullString `json:"account_type"`
+	// flat or percentage
+	Kind string `json:"kind"`
+	// fee of a flat rule, in minor units of currency
+	FlatAmount int64 `json:"flat_amount"`
+	// fee of a percentage rule, in basis points of the amount
+	RateBps int32 `json:"rate_bps"`
+	// lowest fee charged, in minor units of currency
+	MinAmount sql.NullInt64 `json:"min_amount"`
+	// highest fee charged, in minor units of currency
+	MaxAmount sql.NullInt64 `json:"max_amount"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type FxQuote struct {
+	ID                 uuid.UUID `json:"id"`
+	Username           string    `json:"username"`
+	FromAccountID      int64     `json:"from_account_id"`
+	ToAccountID        int64     `json:"to_account_id"`
+	HouseFromAccountID int64     `json:"house_from_account_id"`
+	HouseToAccountID   int64     `json:"house_to_account_id"`
+	// debited from from_account_id, in its minor units
+	Amount int64 `json:"amount"`
+	// quoted rate after the spread
+	Rate string `json:"rate"`
+	// credited to to_account_id, in its minor units
+	ConvertedAmount int64 `json:"converted_amount"`
+	// booked to the fx income system account, in the minor units of to_account_id
+	SpreadAmount int64         `json:"spread_amount"`
+	TransferID   sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type FxRate struct {
+	BaseCurrency  string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+	// mid market rate, 1 unit of base_currency in quote_currency
+	Rate      string    `json:"rate"`
+	SpreadBps int32     `json:"spread_bps"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// authorized amount, in minor units of currency
+	Amount int64 `json:"amount"`
+	// part of amount already transferred to to_account_id
+	CapturedAmount int64  `json:"captured_amount"`
+	Currency       string `json:"currency"`
+	// active, captured, voided or expired
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	// empty for unauthenticated requests
+	Username     string    `json:"username"`
+	Key          string    `json:"key"`
+	RequestHash  string    `json:"request_hash"`
+	ResponseCode int32     `json:"response_code"`
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
+	// null while the request is in progress
+	CompletedAt sql.NullTime `json:"completed_at"`
+	// the key can be used again and is purged after this time
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type InterestAccrual struct {
+	AccountID     int64     `json:"account_id"`
+	AccruedOn     time.Time `json:"accrued_on"`
+	ProductID     int64     `json:"product_id"`
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	// closing balance of the day the interest accrued on
+	Balance int64 `json:"balance"`
+	// in millionths of a minor unit of the account currency
+	AmountMicros     int64         `json:"amount_micros"`
+	CapitalizationID sql.NullInt64 `json:"capitalization_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type InterestCapitalization struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was accrued in
+	Period time.Time `json:"period"`
+	// accruals of the period plus what the previous period carried, in millionths of a minor unit
+	AccruedMicros int64 `json:"accrued_micros"`
+	// whole minor units posted to the account
+	Amount int64 `json:"amount"`
+	// fraction of a minor unit left for the next period
+	CarriedMicros int64 `json:"carried_micros"`
+	// null when less than a minor unit accrued
+	JournalID sql.NullInt64 `json:"journal_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type InterestProduct struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	// yearly interest paid on a positive balance, in basis points
+	AnnualRateBps int32 `json:"annual_rate_bps"`
+	// act/365, act/360, act/act or 30/360
+	DayCount  string    `json:"day_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Journal struct {
+	ID int64 `json:"id"`
+	// transfer, fx_transfer, ...
+	Kind string `json:"kind"`
+	// free text describing the business event
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OutboxMessage struct {
+	ID       int64           `json:"id"`
+	TaskType string          `json:"task_type"`
+	Payload  json.RawMessage `json:"payload"`
+	Queue    string          `json:"queue"`
+	MaxRetry int32           `json:"max_retry"`
+	// asynq task id, empty for tasks that may be enqueued more than once
+	TaskID string `json:"task_id"`
+	// failed attempts to publish the message
+	Attempts      int32     `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error"`
+	// null while the message is pending
+	PublishedAt sql.NullTime `json:"published_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type OverdraftCharge struct {
+	AccountID int64     `json:"account_id"`
+	ChargedOn time.Time `json:"charged_on"`
+	// negative balance the interest was charged on
+	Balance   int64     `json:"balance"`
+	Amount    int64     `json:"amount"`
+	JournalID int64     `json:"journal_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReconciliationFinding struct {
+	ID    int64 `json:"id"`
+	RunID int64 `json:"run_id"`
+	// balance_mismatch or unbalanced_journal
+	Kind string `json:"kind"`
+	// set for balance_mismatch
+	AccountID sql.NullInt64 `json:"account_id"`
+	// set for unbalanced_journal
+	JournalID sql.NullInt64 `json:"journal_id"`
+	Currency  string        `json:"currency"`
+	// sum of the entries of the account, or zero for a journal
+	Expected int64 `json:"expected"`
+	// balance of the account, or sum of the journal entries in currency
+	Actual    int64     `json:"actual"`
+	Repaired  bool      `json:"repaired"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReconciliationRun struct {
+	ID int64 `json:"id"`
+	// whether mismatched balances were rebuilt from their entries
+	Repair bool `json:"repair"`
+	// accounts up to this id were checked
+	LastAccountID int64 `json:"last_account_id"`
+	// journals up to this id were checked
+	LastJournalID int64     `json:"last_journal_id"`
+	Findings      int64     `json:"findings"`
+	StartedAt     time.Time `json:"started_at"`
+	// null while the run is in progress or when it failed
+	FinishedAt sql.NullTime `json:"finished_at"`
 }
 
 type Session struct {
@@ -37,13 +309,102 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// in minor units of currency, must be positive
+	Amount int64 `json:"amount"`
+	// currency of both accounts
+	Currency string `json:"currency"`
+	// cron expression, descriptor such as @monthly or interval such as @every 168h
+	Schedule string `json:"schedule"`
+	// active, paused, cancelled or completed
+	Status  string       `json:"status"`
+	StartAt time.Time    `json:"start_at"`
+	EndAt   sql.NullTime `json:"end_at"`
+	// null for no limit
+	MaxExecutions sql.NullInt32 `json:"max_executions"`
+	// number of successful runs
+	Executions int32 `json:"executions"`
+	// null once the order is cancelled or completed
+	NextRunAt sql.NullTime `json:"next_run_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type StandingOrderRun struct {
+	ID              int64     `json:"id"`
+	StandingOrderID int64     `json:"standing_order_id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	// succeeded or failed
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Error      string        `json:"error"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	// must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	// in minor units of the from account currency, must be positive
+	Amount    int64         `json:"amount"`
+	CreatedAt time.Time     `json:"created_at"`
+	JournalID sql.NullInt64 `json:"journal_id"`
+	// transfer this one reverses, in full or in part
+	ReversalOf sql.NullInt64 `json:"reversal_of"`
+	// part of amount already reversed
+	ReversedAmount int64 `json:"reversed_amount"`
+	// charged to the sender on top of amount, in minor units of the from account currency
+	Fee int64 `json:"fee"`
+}
+
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	// currency of the source account and of every item
+	Currency string `json:"currency"`
+	// atomic or best_effort
+	Mode string `json:"mode"`
+	// pending, completed, partially_completed or failed
+	Status         string       `json:"status"`
+	ItemCount      int32        `json:"item_count"`
+	SucceededCount int32        `json:"succeeded_count"`
+	FailedCount    int32        `json:"failed_count"`
+	CreatedAt      time.Time    `json:"created_at"`
+	FinishedAt     sql.NullTime `json:"finished_at"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// index of the item in the request
+	Position    int32 `json:"position"`
+	ToAccountID int64 `json:"to_account_id"`
+	// in minor units of currency, must be positive
+	Amount int64 `json:"amount"`
+	// pending, succeeded or failed
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	Error      string        `json:"error"`
+}
+
+type TransferLimit struct {
+	// user or tier
+	Scope string `json:"scope"`
+	// username or tier the limits apply to
+	Subject     string        `json:"subject"`
+	Currency    string        `json:"currency"`
+	PerTransfer sql.NullInt64 `json:"per_transfer"`
+	// total sent per UTC day, in minor units of currency
+	Daily sql.NullInt64 `json:"daily"`
+	// total sent per UTC calendar month, in minor units of currency
+	Monthly sql.NullInt64 `json:"monthly"`
+	// number of transfers in any rolling hour
+	HourlyCount sql.NullInt32 `json:"hourly_count"`
+	UpdatedAt   time.Time     `json:"updated_at"`
 }
 
 type User struct {
@@ -53,4 +414,36 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+	Tier              string    `json:"tier"`
+}
+
+type WebhookDelivery struct {
+	ID         int64     `json:"id"`
+	EndpointID int64     `json:"endpoint_id"`
+	EventID    uuid.UUID `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	// request body, the same for every attempt
+	Payload json.RawMessage `json:"payload"`
+	// pending, succeeded or failed
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// HTTP status of the last attempt, 0 when there was no response
+	ResponseCode  int32        `json:"response_code"`
+	LastError     string       `json:"last_error"`
+	LastAttemptAt sql.NullTime `json:"last_attempt_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type WebhookEndpoint struct {
+	ID     int64  `json:"id"`
+	Owner  string `json:"owner"`
+	Url    string `json:"url"`
+	Secret string `json:"secret"`
+	// signs deliveries next to secret until previous_secret_expires_at
+	PreviousSecret          string       `json:"previous_secret"`
+	PreviousSecretExpiresAt sql.NullTime `json:"previous_secret_expires_at"`
+	// event types delivered to the endpoint, empty for all
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
 }
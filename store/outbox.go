@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// OutboxTask is a background task for the worker. It is written to the outbox next to the
+// change that produces it and only published once that change is committed.
+type OutboxTask struct {
+	Type     string
+	Payload  any
+	Queue    string
+	MaxRetry int32
+	// TaskID keeps the task from being written again while it is pending, empty allows duplicates
+	TaskID string
+}
+
+// EnqueueTask writes task to the outbox. Call it on the queries of a transaction to enqueue the
+// task only when the transaction commits.
+func (q *Queries) EnqueueTask(ctx context.Context, task OutboxTask) error {
+	payload, err := json.Marshal(task.Payload)
+	if err != nil {
+		return err
+	}
+	return q.CreateOutboxMessage(ctx, CreateOutboxMessageParams{
+		TaskType: task.Type,
+		Payload:  payload,
+		Queue:    task.Queue,
+		MaxRetry: task.MaxRetry,
+		TaskID:   task.TaskID,
+	})
+}
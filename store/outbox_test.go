@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+// claimOutboxMessage claims the due messages until it finds the one of taskID. Messages of
+// other tests are made due again.
+func claimOutboxMessage(t *testing.T, taskID string) (OutboxMessage, bool) {
+	msgs, err := testQueries.ClaimOutboxMessages(context.Background(), ClaimOutboxMessagesParams{
+		LeasedUntil: time.Now().Add(time.Minute),
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+
+	var found OutboxMessage
+	ok := false
+	for _, msg := range msgs {
+		if msg.TaskID == taskID {
+			found, ok = msg, true
+			continue
+		}
+		_, err := testDB.Exec(context.Background(), "UPDATE outbox_messages SET next_attempt_at = now() WHERE id = $1", msg.ID)
+		require.NoError(t, err)
+	}
+	return found, ok
+}
+
+func TestEnqueueTask(t *testing.T) {
+	task := OutboxTask{
+		Type:     "task:test",
+		Payload:  map[string]string{"key": "value"},
+		Queue:    "default",
+		MaxRetry: 3,
+		TaskID:   util.RandomString(16),
+	}
+	require.NoError(t, testQueries.EnqueueTask(context.Background(), task))
+	// still pending, so it is skipped
+	require.NoError(t, testQueries.EnqueueTask(context.Background(), task))
+
+	msg, ok := claimOutboxMessage(t, task.TaskID)
+	require.True(t, ok)
+	require.Equal(t, task.Type, msg.TaskType)
+	require.JSONEq(t, `{"key":"value"}`, string(msg.Payload))
+	require.Equal(t, task.Queue, msg.Queue)
+	require.Equal(t, task.MaxRetry, msg.MaxRetry)
+	require.Zero(t, msg.Attempts)
+	require.False(t, msg.PublishedAt.Valid)
+
+	// leased messages are not claimed twice
+	_, ok = claimOutboxMessage(t, task.TaskID)
+	require.False(t, ok)
+
+	err := testQueries.MarkOutboxMessageFailed(context.Background(), MarkOutboxMessageFailedParams{
+		ID:            msg.ID,
+		LastError:     "redis is down",
+		NextAttemptAt: time.Now(),
+	})
+	require.NoError(t, err)
+	msg, ok = claimOutboxMessage(t, task.TaskID)
+	require.True(t, ok)
+	require.Equal(t, int32(1), msg.Attempts)
+	require.Equal(t, "redis is down", msg.LastError)
+
+	require.NoError(t, testQueries.MarkOutboxMessagePublished(context.Background(), msg.ID))
+	_, err = testDB.Exec(context.Background(), "UPDATE outbox_messages SET next_attempt_at = now() WHERE id = $1", msg.ID)
+	require.NoError(t, err)
+	_, ok = claimOutboxMessage(t, task.TaskID)
+	require.False(t, ok)
+
+	// once published, the task can be written again
+	require.NoError(t, testQueries.EnqueueTask(context.Background(), task))
+	_, ok = claimOutboxMessage(t, task.TaskID)
+	require.True(t, ok)
+}
+
+func TestCreateUserTxEnqueuesTasks(t *testing.T) {
+	store := NewStore(testDB)
+
+	taskID := util.RandomString(16)
+	arg := CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: util.RandomString(16),
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+		Tasks: []OutboxTask{{Type: "task:test", Payload: nil, Queue: "default", TaskID: taskID}},
+	}
+	_, err := store.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	_, ok := claimOutboxMessage(t, taskID)
+	require.True(t, ok)
+
+	// the task is rolled back with the user
+	arg.Tasks[0].TaskID = util.RandomString(16)
+	_, err = store.CreateUserTx(context.Background(), arg)
+	require.Error(t, err)
+	_, ok = claimOutboxMessage(t, arg.Tasks[0].TaskID)
+	require.False(t, ok)
+}
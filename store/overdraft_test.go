@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        150,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	acc1, err = testQueries.SetAccountOverdraft(context.Background(), SetAccountOverdraftParams{
+		ID:                   acc1.ID,
+		OverdraftLimit:       50,
+		OverdraftInterestBps: 1825,
+	})
+	require.NoError(t, err)
+
+	res, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        150,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), res.FromAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	debits, err := testQueries.ListDebitBalances(context.Background(), ListDebitBalancesParams{
+		ExcludedOwners: SystemOwners(),
+		Limit:          1000,
+	})
+	require.NoError(t, err)
+	var ids []int64
+	for _, acc := range debits {
+		require.Negative(t, acc.Balance)
+		require.False(t, IsSystemAccount(acc))
+		ids = append(ids, acc.ID)
+	}
+	require.Contains(t, ids, acc1.ID)
+}
+
+func TestChargeOverdraftInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc := createRandomAccountWithCurrency(t, util.USD)
+	acc = fundAccount(t, acc, -20000)
+	acc, err := testQueries.SetAccountOverdraft(context.Background(), SetAccountOverdraftParams{
+		ID:                   acc.ID,
+		OverdraftLimit:       50000,
+		OverdraftInterestBps: 1825,
+	})
+	require.NoError(t, err)
+
+	// 18.25% a year is 0.05% a day
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	res, err := store.ChargeOverdraftInterestTx(context.Background(), ChargeOverdraftInterestTxParams{
+		AccountID: acc.ID,
+		Day:       day,
+	})
+	require.NoError(t, err)
+	require.False(t, res.Skipped)
+	require.Equal(t, int64(10), res.Charge.Amount)
+	require.Equal(t, int64(-20000), res.Charge.Balance)
+	require.Equal(t, int64(-20010), res.Account.Balance)
+
+	// the same day is charged once
+	res, err = store.ChargeOverdraftInterestTx(context.Background(), ChargeOverdraftInterestTxParams{
+		AccountID: acc.ID,
+		Day:       day,
+	})
+	require.NoError(t, err)
+	require.True(t, res.Skipped)
+	require.Equal(t, int64(-20010), res.Account.Balance)
+}
+
+func TestDailyInterest(t *testing.T) {
+	amount, err := dailyInterest(20000, 1825)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), amount)
+
+	amount, err = dailyInterest(100, 1000)
+	require.NoError(t, err)
+	require.Zero(t, amount)
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// queryExecModes maps DB_STATEMENT_CACHE_MODE to how pgx prepares statements. The modes that
+// skip the statement cache suit poolers such as pgbouncer in transaction mode.
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// NewConnPool opens a pool of connections to the database of config. Unset pool settings keep
+// the defaults of pgxpool.
+func NewConnPool(ctx context.Context, config util.Config) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(config.DbSource)
+	if err != nil {
+		return nil, err
+	}
+	if config.DbMaxConns > 0 {
+		poolConfig.MaxConns = config.DbMaxConns
+	}
+	if config.DbMinConns > 0 {
+		poolConfig.MinConns = config.DbMinConns
+	}
+	if config.DbMaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.DbMaxConnLifetime
+	}
+	if config.DbMaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.DbMaxConnIdleTime
+	}
+	if config.DbStatementCacheMode != "" {
+		mode, ok := queryExecModes[config.DbStatementCacheMode]
+		if !ok {
+			return nil, fmt.Errorf("unknown statement cache mode %q", config.DbStatementCacheMode)
+		}
+		poolConfig.ConnConfig.DefaultQueryExecMode = mode
+	}
+	return pgxpool.NewWithConfig(ctx, poolConfig)
+}
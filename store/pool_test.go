@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConnPool(t *testing.T) {
+	config, err := util.LoadConfig("../")
+	require.NoError(t, err)
+	config.DbMaxConns = 7
+	config.DbMaxConnLifetime = 5 * time.Minute
+	config.DbStatementCacheMode = "describe_exec"
+
+	pool, err := NewConnPool(context.Background(), config)
+	require.NoError(t, err)
+	defer pool.Close()
+	require.Equal(t, int32(7), pool.Config().MaxConns)
+	require.Equal(t, 5*time.Minute, pool.Config().MaxConnLifetime)
+	require.Equal(t, pgx.QueryExecModeDescribeExec, pool.Config().ConnConfig.DefaultQueryExecMode)
+	require.NoError(t, pool.Ping(context.Background()))
+
+	config.DbStatementCacheMode = "prepared"
+	_, err = NewConnPool(context.Background(), config)
+	require.Error(t, err)
+}
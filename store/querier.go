@@ -6,29 +6,192 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldBalance(ctx context.Context, arg AddAccountHeldBalanceParams) (Account, error)
+	AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error)
+	AdvanceStandingOrder(ctx context.Context, arg AdvanceStandingOrderParams) (StandingOrder, error)
+	CancelAccountStandingOrders(ctx context.Context, fromAccountID int64) error
+	CancelStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	CapitalizeInterestAccruals(ctx context.Context, arg CapitalizeInterestAccrualsParams) error
+	// ClaimOutboxMessages leases due messages to the caller until leased_until. Messages that are
+	// neither published nor failed by then are claimed again.
+	ClaimOutboxMessages(ctx context.Context, arg ClaimOutboxMessagesParams) ([]OutboxMessage, error)
+	CountTransferBatchItems(ctx context.Context, batchID int64) (CountTransferBatchItemsRow, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	// CreateBalanceSnapshots snapshots the accounts in (after_id, after_id + batch_size] at as_of
+	// from their previous snapshot and the entries since. Existing snapshots are kept.
+	CreateBalanceSnapshots(ctx context.Context, arg CreateBalanceSnapshotsParams) (int64, error)
+	CreateCashReceipt(ctx context.Context, arg CreateCashReceiptParams) (CashReceipt, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
+	CreateDomainEvent(ctx context.Context, arg CreateDomainEventParams) error
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFxQuote(ctx context.Context, arg CreateFxQuoteParams) (FxQuote, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	// CreateIdempotencyKey claims a key, an expired key that was not purged yet is claimed again.
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (int64, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error)
+	CreateInterestProduct(ctx context.Context, arg CreateInterestProductParams) (InterestProduct, error)
+	CreateJournal(ctx context.Context, arg CreateJournalParams) (Journal, error)
+	// CreateOutboxMessage skips messages whose task id is already pending.
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error
+	CreateOverdraftCharge(ctx context.Context, arg CreateOverdraftChargeParams) (OverdraftCharge, error)
+	CreateReconciliationFinding(ctx context.Context, arg CreateReconciliationFindingParams) (ReconciliationFinding, error)
+	CreateReconciliationRun(ctx context.Context, repair bool) (ReconciliationRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
+	CreateStandingOrderRun(ctx context.Context, arg CreateStandingOrderRunParams) (StandingOrderRun, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
+	CurrencyHasAccounts(ctx context.Context, currency string) (bool, error)
+	// only accounts without any entries can be deleted, close the others
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteFeeRule(ctx context.Context, id int64) (FeeRule, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeletePublishedOutboxMessages(ctx context.Context, publishedBefore time.Time) error
+	DeleteWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
+	EnsureAccount(ctx context.Context, arg EnsureAccountParams) error
+	// FailPendingTransferBatchItems marks the items that were rolled back with an atomic batch.
+	FailPendingTransferBatchItems(ctx context.Context, arg FailPendingTransferBatchItemsParams) error
+	FinishReconciliationRun(ctx context.Context, arg FinishReconciliationRunParams) (ReconciliationRun, error)
+	FinishTransferBatch(ctx context.Context, arg FinishTransferBatchParams) (TransferBatch, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetCashReceipt(ctx context.Context, id int64) (CashReceipt, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	// GetCurrencyForUpdate also blocks accounts from being opened in the currency until the end of
+	// the transaction.
+	GetCurrencyForUpdate(ctx context.Context, code string) (Currency, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	// GetFeeRule returns the rule for the account type, or the rule for any type when it has none.
+	GetFeeRule(ctx context.Context, arg GetFeeRuleParams) (FeeRule, error)
+	GetFeeRuleForUpdate(ctx context.Context, arg GetFeeRuleForUpdateParams) (FeeRule, error)
+	GetFxQuoteForUpdate(ctx context.Context, id uuid.UUID) (FxQuote, error)
+	GetFxRate(ctx context.Context, arg GetFxRateParams) (FxRate, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetInterestAccrual(ctx context.Context, arg GetInterestAccrualParams) (InterestAccrual, error)
+	GetInterestCapitalization(ctx context.Context, arg GetInterestCapitalizationParams) (InterestCapitalization, error)
+	GetInterestProduct(ctx context.Context, id int64) (InterestProduct, error)
+	GetJournal(ctx context.Context, id int64) (Journal, error)
+	GetLastDomainEventOffset(ctx context.Context) (int64, error)
+	GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error)
+	// GetLatestBalanceSnapshot returns the newest snapshot of the account taken at or before as_of.
+	GetLatestBalanceSnapshot(ctx context.Context, arg GetLatestBalanceSnapshotParams) (BalanceSnapshot, error)
+	// GetLedgerBounds returns the highest account and journal ids, reconciliation checks up to them.
+	GetLedgerBounds(ctx context.Context) (GetLedgerBoundsRow, error)
+	GetOverdraftCharge(ctx context.Context, arg GetOverdraftChargeParams) (OverdraftCharge, error)
+	GetReconciliationRun(ctx context.Context, id int64) (ReconciliationRun, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	GetStandingOrderForUpdate(ctx context.Context, id int64) (StandingOrder, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferBatchForUpdate(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferBatchItemForUpdate(ctx context.Context, id int64) (TransferBatchItem, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimitForUpdate(ctx context.Context, arg GetTransferLimitForUpdateParams) (TransferLimit, error)
+	// GetTransferUsage sums what the owner sent from its accounts in the currency. Reversals
+	// are sent back by the receiving side and don't count.
+	GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookEndpoint(ctx context.Context, id int64) (WebhookEndpoint, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	// ListAuditEvents returns the newest events first, null filters match any event.
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	// ListBalanceMismatches compares the balance of the accounts in (after_id, after_id + batch_size]
+	// with the sum of their entries. Both are read from the same snapshot.
+	ListBalanceMismatches(ctx context.Context, arg ListBalanceMismatchesParams) ([]ListBalanceMismatchesRow, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDebitBalances(ctx context.Context, arg ListDebitBalancesParams) ([]Account, error)
+	// ListDomainEvents reads the stream as the sink got it, events waiting to be published are left out.
+	ListDomainEvents(ctx context.Context, arg ListDomainEventsParams) ([]DomainEvent, error)
+	ListDueStandingOrders(ctx context.Context, arg ListDueStandingOrdersParams) ([]StandingOrder, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	// ListEntryHistory returns the entries of an account newest first after the (created_at, id) cursor, null filters match any entry.
+	ListEntryHistory(ctx context.Context, arg ListEntryHistoryParams) ([]Entry, error)
+	ListExpiredHolds(ctx context.Context, arg ListExpiredHoldsParams) ([]Hold, error)
+	ListFeeRules(ctx context.Context) ([]FeeRule, error)
+	ListFxRates(ctx context.Context) ([]FxRate, error)
+	ListHolds(ctx context.Context, arg ListHoldsParams) ([]Hold, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error)
+	ListInterestProducts(ctx context.Context, arg ListInterestProductsParams) ([]InterestProduct, error)
+	ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error)
+	ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error)
+	ListPendingDomainEvents(ctx context.Context, limit int32) ([]DomainEvent, error)
+	ListPendingTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListReconciliationFindings(ctx context.Context, runID int64) ([]ReconciliationFinding, error)
+	ListReversals(ctx context.Context, reversalOf sql.NullInt64) ([]Transfer, error)
+	ListStandingOrderRuns(ctx context.Context, arg ListStandingOrderRunsParams) ([]StandingOrderRun, error)
+	ListStandingOrders(ctx context.Context, arg ListStandingOrdersParams) ([]StandingOrder, error)
+	ListTellerTotals(ctx context.Context, arg ListTellerTotalsParams) ([]ListTellerTotalsRow, error)
+	ListTransferBatchItems(ctx context.Context, arg ListTransferBatchItemsParams) ([]TransferBatchItem, error)
+	// ListTransferHistory returns the transfers of an account newest first after the (created_at, id) cursor, null filters match any transfer.
+	ListTransferHistory(ctx context.Context, arg ListTransferHistoryParams) ([]Transfer, error)
+	// ListTransferLimits returns the limits of the user and of its tier in the currency.
+	ListTransferLimits(ctx context.Context, arg ListTransferLimitsParams) ([]TransferLimit, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	// ListUnbalancedJournals returns the journals in (after_id, after_id + batch_size] whose
+	// entries don't sum to zero in a currency.
+	ListUnbalancedJournals(ctx context.Context, arg ListUnbalancedJournalsParams) ([]ListUnbalancedJournalsRow, error)
+	ListUncapitalizedInterestAccounts(ctx context.Context, arg ListUncapitalizedInterestAccountsParams) ([]int64, error)
+	ListUnpublishedDomainEvents(ctx context.Context, limit int32) ([]DomainEvent, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookEndpoints(ctx context.Context, owner string) ([]WebhookEndpoint, error)
+	// ListWebhookEndpointsForEvent returns the endpoints of owner whose filter lets event_type through.
+	ListWebhookEndpointsForEvent(ctx context.Context, arg ListWebhookEndpointsForEventParams) ([]WebhookEndpoint, error)
+	// LockDomainEventPublisher makes the publishers take turns until the end of the transaction,
+	// so a batch only gets offsets once the batch before it is published.
+	LockDomainEventPublisher(ctx context.Context) error
+	MarkDomainEventPublished(ctx context.Context, streamOffset int64) (int64, error)
+	MarkOutboxMessageFailed(ctx context.Context, arg MarkOutboxMessageFailedParams) error
+	MarkOutboxMessagePublished(ctx context.Context, id int64) error
+	PauseStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAttemptParams) (WebhookDelivery, error)
+	// ResetWebhookDelivery makes a delivery pending again before it is redelivered.
+	ResetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	ResumeStandingOrder(ctx context.Context, arg ResumeStandingOrderParams) (StandingOrder, error)
+	// RotateWebhookSecret replaces the secret, the old one keeps signing until previous_secret_expires_at.
+	RotateWebhookSecret(ctx context.Context, arg RotateWebhookSecretParams) (WebhookEndpoint, error)
+	SetAccountInterestProduct(ctx context.Context, arg SetAccountInterestProductParams) (Account, error)
+	SetAccountOverdraft(ctx context.Context, arg SetAccountOverdraftParams) (Account, error)
+	SetAccountType(ctx context.Context, arg SetAccountTypeParams) (Account, error)
+	SetDomainEventOffset(ctx context.Context, arg SetDomainEventOffsetParams) error
+	SetFeeRule(ctx context.Context, arg SetFeeRuleParams) (FeeRule, error)
+	SetFxQuoteTransfer(ctx context.Context, arg SetFxQuoteTransferParams) (FxQuote, error)
+	SetTransferBatchItemResult(ctx context.Context, arg SetTransferBatchItemResultParams) (TransferBatchItem, error)
+	SetTransferLimit(ctx context.Context, arg SetTransferLimitParams) (TransferLimit, error)
+	SetUserTier(ctx context.Context, arg SetUserTierParams) (User, error)
+	SumAccountEntries(ctx context.Context, accountID int64) (int64, error)
+	SumEntriesBetween(ctx context.Context, arg SumEntriesBetweenParams) (int64, error)
+	SumEntriesSince(ctx context.Context, arg SumEntriesSinceParams) (int64, error)
+	// SumInterestAccruals adds up the accruals of the account before the date that are not capitalized yet.
+	SumInterestAccruals(ctx context.Context, arg SumInterestAccrualsParams) (SumInterestAccrualsRow, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error)
+	UpdateHold(ctx context.Context, arg UpdateHoldParams) (Hold, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpsertFxRate(ctx context.Context, arg UpsertFxRateParams) (FxRate, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileLedger(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 0)
+	acc2 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 1000)
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc2.ID,
+		ToAccountID:   acc1.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	// nothing posted these 50
+	fundAccount(t, acc1, 150)
+
+	res, err := store.ReconcileLedger(context.Background(), ReconcileLedgerParams{})
+	require.NoError(t, err)
+	require.True(t, res.Run.FinishedAt.Valid)
+	require.False(t, res.Run.Repair)
+	require.GreaterOrEqual(t, res.Run.LastAccountID, acc2.ID)
+	require.Equal(t, int64(len(res.Findings)), res.Run.Findings)
+
+	var found *ReconciliationFinding
+	for i, f := range res.Findings {
+		require.Equal(t, res.Run.ID, f.RunID)
+		if f.AccountID.Int64 == acc1.ID {
+			found = &res.Findings[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, FindingBalanceMismatch, found.Kind)
+	require.Equal(t, int64(100), found.Expected)
+	require.Equal(t, int64(150), found.Actual)
+	require.False(t, found.Repaired)
+
+	findings, err := testQueries.ListReconciliationFindings(context.Background(), res.Run.ID)
+	require.NoError(t, err)
+	require.Equal(t, res.Findings, findings)
+
+	repaired, err := store.repairAccountBalance(context.Background(), acc1.ID)
+	require.NoError(t, err)
+	require.True(t, repaired)
+	acc1, err = testQueries.GetAccount(context.Background(), acc1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), acc1.Balance)
+
+	repaired, err = store.repairAccountBalance(context.Background(), acc1.ID)
+	require.NoError(t, err)
+	require.False(t, repaired)
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"expvar"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaRetryAfter is how long reads stay on the primary after the replica failed.
+const replicaRetryAfter = 10 * time.Second
+
+// replicaMetrics counts the reads served by the replica and the ones that fell back to the
+// primary, exported on /debug/vars.
+var replicaMetrics = expvar.NewMap("store_replica")
+
+// replica serves the read-only queries that tolerate replication lag.
+type replica struct {
+	*Queries
+	// unhealthyUntil is the unix time in nanoseconds before which reads skip the replica
+	unhealthyUntil atomic.Int64
+}
+
+func newReplica(pool *pgxpool.Pool) *replica {
+	return &replica{Queries: New(pool)}
+}
+
+func (r *replica) healthy() bool {
+	return time.Now().UnixNano() >= r.unhealthyUntil.Load()
+}
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites returns a context whose reads go to the primary, so they see the changes
+// the caller just made.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+func readsOwnWrites(ctx context.Context) bool {
+	ryw, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return ryw
+}
+
+// replicaFailed reports whether err means the replica can't serve reads, as opposed to an
+// error the primary would return as well.
+func replicaFailed(err error) bool {
+	if err == nil || err == ErrRecordNotFound {
+		return false
+	}
+	code := ErrorCode(err)
+	// queries cancelled by a conflict with recovery fail as serialization failures
+	return code == "" || code == SerializationFailure
+}
+
+// readOnly runs query on the replica when there is a healthy one and ctx doesn't ask to read
+// its own writes. When the replica fails, it is skipped for a while and the query goes to the
+// primary.
+func readOnly[T any](ctx context.Context, s *SQLStore, query func(q *Queries) (T, error)) (T, error) {
+	if s.replica == nil || readsOwnWrites(ctx) || !s.replica.healthy() {
+		return query(s.Queries)
+	}
+
+	res, err := query(s.replica.Queries)
+	if !replicaFailed(err) || ctx.Err() != nil {
+		replicaMetrics.Add("reads", 1)
+		return res, err
+	}
+	s.replica.unhealthyUntil.Store(time.Now().Add(replicaRetryAfter).UnixNano())
+	replicaMetrics.Add("fallbacks", 1)
+	return query(s.Queries)
+}
+
+func (s *SQLStore) GetAccount(ctx context.Context, id int64) (Account, error) {
+	return readOnly(ctx, s, func(q *Queries) (Account, error) {
+		return q.GetAccount(ctx, id)
+	})
+}
+
+func (s *SQLStore) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	return readOnly(ctx, s, func(q *Queries) ([]Account, error) {
+		return q.ListAccounts(ctx, arg)
+	})
+}
+
+func (s *SQLStore) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	return readOnly(ctx, s, func(q *Queries) (Entry, error) {
+		return q.GetEntry(ctx, id)
+	})
+}
+
+func (s *SQLStore) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
+	return readOnly(ctx, s, func(q *Queries) ([]Entry, error) {
+		return q.ListEntries(ctx, arg)
+	})
+}
+
+func (s *SQLStore) ListEntryHistory(ctx context.Context, arg ListEntryHistoryParams) ([]Entry, error) {
+	return readOnly(ctx, s, func(q *Queries) ([]Entry, error) {
+		return q.ListEntryHistory(ctx, arg)
+	})
+}
+
+func (s *SQLStore) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	return readOnly(ctx, s, func(q *Queries) (Transfer, error) {
+		return q.GetTransfer(ctx, id)
+	})
+}
+
+func (s *SQLStore) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	return readOnly(ctx, s, func(q *Queries) ([]Transfer, error) {
+		return q.ListTransfers(ctx, arg)
+	})
+}
+
+func (s *SQLStore) ListTransferHistory(ctx context.Context, arg ListTransferHistoryParams) ([]Transfer, error) {
+	return readOnly(ctx, s, func(q *Queries) ([]Transfer, error) {
+		return q.ListTransferHistory(ctx, arg)
+	})
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicaReads(t *testing.T) {
+	config, err := util.LoadConfig("../")
+	require.NoError(t, err)
+	replicaPool, err := NewConnPool(context.Background(), config)
+	require.NoError(t, err)
+	defer replicaPool.Close()
+
+	store := NewStore(testDB, replicaPool).(*SQLStore)
+	acc := createRandomAccount(t)
+
+	got, err := store.GetAccount(context.Background(), acc.ID)
+	require.NoError(t, err)
+	require.Equal(t, acc.ID, got.ID)
+	require.True(t, store.replica.healthy())
+
+	// missing rows are an answer of the replica, not a failure
+	_, err = store.GetTransfer(context.Background(), -1)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	require.True(t, store.replica.healthy())
+}
+
+func TestReplicaFallback(t *testing.T) {
+	config, err := util.LoadConfig("../")
+	require.NoError(t, err)
+	replicaPool, err := NewConnPool(context.Background(), config)
+	require.NoError(t, err)
+	replicaPool.Close()
+
+	store := NewStore(testDB, replicaPool).(*SQLStore)
+	acc := createRandomAccount(t)
+
+	// reads of a context that reads its own writes never try the replica
+	got, err := store.GetAccount(WithReadYourWrites(context.Background()), acc.ID)
+	require.NoError(t, err)
+	require.Equal(t, acc.ID, got.ID)
+	require.True(t, store.replica.healthy())
+
+	got, err = store.GetAccount(context.Background(), acc.ID)
+	require.NoError(t, err)
+	require.Equal(t, acc.ID, got.ID)
+	require.False(t, store.replica.healthy())
+
+	accounts, err := store.ListAccounts(context.Background(), ListAccountsParams{
+		Owner:  acc.Owner,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
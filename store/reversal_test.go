@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := createRandomAccountWithCurrency(t, acc1.Currency)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: acc1.ID,
+		ToAccountID:   acc2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferCompleted, TransferStatus(transfer.Transfer))
+
+	res, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, acc2.ID, res.Transfer.FromAccountID)
+	require.Equal(t, acc1.ID, res.Transfer.ToAccountID)
+	require.Equal(t, int64(4), res.Transfer.Amount)
+	require.Equal(t, sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}, res.Transfer.ReversalOf)
+	require.Equal(t, int64(4), res.Original.ReversedAmount)
+	require.Equal(t, TransferPartiallyReversed, TransferStatus(res.Original))
+	require.Equal(t, int64(-4), res.FromEntry.Amount)
+	require.Equal(t, acc1.Balance-6, res.ToAccount.Balance)
+	require.Equal(t, acc2.Balance+6, res.FromAccount.Balance)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     7,
+	})
+	require.ErrorIs(t, err, ErrReversalExceeded)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: res.Transfer.ID,
+		Amount:     1,
+	})
+	require.ErrorIs(t, err, ErrTransferIsReversal)
+
+	res, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     6,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferReversed, TransferStatus(res.Original))
+
+	reversals, err := testQueries.ListReversals(context.Background(), sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, reversals, 2)
+}
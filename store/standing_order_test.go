@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStandingOrder(t *testing.T, from Account, to Account, amount int64, maxExecutions sql.NullInt32) StandingOrder {
+	now := time.Now()
+	arg := CreateStandingOrderParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Schedule:      "@every 24h",
+		StartAt:       now,
+		MaxExecutions: maxExecutions,
+		NextRunAt:     sql.NullTime{Time: now, Valid: true},
+	}
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderActive, order.Status)
+	require.Zero(t, order.Executions)
+	return order
+}
+
+func TestExecuteStandingOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to := createRandomAccountWithCurrency(t, util.USD)
+	order := createRandomStandingOrder(t, from, to, 10, sql.NullInt32{})
+
+	arg := ExecuteStandingOrderTxParams{ID: order.ID, ScheduledAt: order.NextRunAt.Time}
+	res, err := store.ExecuteStandingOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, res.Skipped)
+	require.Equal(t, RunSucceeded, res.Run.Status)
+	require.True(t, res.Run.TransferID.Valid)
+	require.Equal(t, int32(1), res.Order.Executions)
+	require.Equal(t, StandingOrderActive, res.Order.Status)
+	require.True(t, res.Order.NextRunAt.Time.After(order.NextRunAt.Time))
+
+	acc, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-10, acc.Balance)
+
+	// the same run again is a no-op
+	res, err = store.ExecuteStandingOrderTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, res.Skipped)
+
+	acc, err = testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-10, acc.Balance)
+}
+
+func TestExecuteStandingOrderTxFailure(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := createRandomAccountWithCurrency(t, util.USD)
+	to := createRandomAccountWithCurrency(t, util.USD)
+	order := createRandomStandingOrder(t, from, to, from.Balance+1, sql.NullInt32{})
+
+	res, err := store.ExecuteStandingOrderTx(context.Background(), ExecuteStandingOrderTxParams{
+		ID:          order.ID,
+		ScheduledAt: order.NextRunAt.Time,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RunFailed, res.Run.Status)
+	require.False(t, res.Run.TransferID.Valid)
+	require.Equal(t, ErrInsufficientFunds.Error(), res.Run.Error)
+	require.Zero(t, res.Order.Executions)
+	require.Equal(t, StandingOrderActive, res.Order.Status)
+
+	acc, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, acc.Balance)
+
+	runs, err := testQueries.ListStandingOrderRuns(context.Background(), ListStandingOrderRunsParams{
+		StandingOrderID: order.ID,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+}
+
+func TestExecuteStandingOrderTxCompleted(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to := createRandomAccountWithCurrency(t, util.USD)
+	order := createRandomStandingOrder(t, from, to, 10, sql.NullInt32{Int32: 1, Valid: true})
+
+	res, err := store.ExecuteStandingOrderTx(context.Background(), ExecuteStandingOrderTxParams{
+		ID:          order.ID,
+		ScheduledAt: order.NextRunAt.Time,
+	})
+	require.NoError(t, err)
+	require.Equal(t, RunSucceeded, res.Run.Status)
+	require.Equal(t, StandingOrderCompleted, res.Order.Status)
+	require.False(t, res.Order.NextRunAt.Valid)
+}
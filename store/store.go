@@ -2,30 +2,117 @@ package store
 
 import (
 	"context"
-	"database/sql"
+	"expvar"
 	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Store interface {
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	IdempotencyTx(ctx context.Context, arg IdempotencyTxParams) (IdempotencyTxResult, error)
+	CashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error)
+	PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error)
+	CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (HoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, id int64) (HoldTxResult, error)
+	ExpireHoldTx(ctx context.Context, id int64) (HoldTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	ChargeOverdraftInterestTx(ctx context.Context, arg ChargeOverdraftInterestTxParams) (ChargeOverdraftInterestTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error)
+	CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error)
+	ReconcileLedger(ctx context.Context, arg ReconcileLedgerParams) (ReconcileLedgerResult, error)
+	AuditTx(ctx context.Context, fn func(q *Queries) (AuditRecord, error)) error
+	EnqueueTask(ctx context.Context, task OutboxTask) error
+	NextDomainEventsTx(ctx context.Context, limit int32) ([]*pb.DomainEvent, error)
+	MarkDomainEventsPublishedTx(ctx context.Context, events []*pb.DomainEvent, published func(q *Queries, events []*pb.DomainEvent) error) error
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	ExecuteTransferBatchTx(ctx context.Context, id int64) (ExecuteTransferBatchTxResult, error)
+	SnapshotBalances(ctx context.Context, asOf time.Time) (int64, error)
+	GetBalanceAsOf(ctx context.Context, accountID int64, at time.Time) (BalanceAsOf, error)
 	Querier
 }
 
+// SQLStore runs queries and transactions on the primary. The read-only queries of replica.go
+// go to the replica when there is one.
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	connPool *pgxpool.Pool
+	replica  *replica
 }
 
-func NewStore(db *sql.DB) Store {
-	return &SQLStore{
-		db:      db,
-		Queries: New(db),
+// NewStore returns a store on connPool. An optional replicaPool takes the reads that tolerate
+// replication lag off the primary.
+func NewStore(connPool *pgxpool.Pool, replicaPool ...*pgxpool.Pool) Store {
+	s := &SQLStore{
+		connPool: connPool,
+		Queries:  New(connPool),
+	}
+	if len(replicaPool) > 0 && replicaPool[0] != nil {
+		s.replica = newReplica(replicaPool[0])
 	}
+	return s
+}
+
+// Retry policy of transactions that fail on a serialization failure or a deadlock.
+const (
+	maxTxAttempts    = 8
+	txRetryBaseDelay = 10 * time.Millisecond
+	txRetryMaxDelay  = time.Second
+)
+
+// txMetrics counts retried transactions, exported on /debug/vars.
+var txMetrics = expvar.NewMap("store_tx")
+
+type isolationLevelKey struct{}
+
+// WithIsolationLevel returns a context whose transactions run at level instead of the default
+// of the database.
+func WithIsolationLevel(ctx context.Context, level pgx.TxIsoLevel) context.Context {
+	return context.WithValue(ctx, isolationLevelKey{}, level)
 }
 
+// execTx runs fn in a transaction at the isolation level of ctx. The transaction is retried
+// with jittered backoff when postgres aborts it on a serialization failure or a deadlock, so fn
+// may run several times. It must only change state outside the transaction through assignments
+// it repeats on every try, side effects like calling a sink or a request handler belong after
+// execTx returns.
 func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	var opts pgx.TxOptions
+	if level, ok := ctx.Value(isolationLevelKey{}).(pgx.TxIsoLevel); ok {
+		opts.IsoLevel = level
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.tryTx(ctx, opts, fn)
+		code, retryable := retryableTxError(err)
+		if !retryable {
+			return err
+		}
+		txMetrics.Add(code, 1)
+		if attempt == maxTxAttempts {
+			txMetrics.Add("exhausted", 1)
+			return fmt.Errorf("transaction failed after %d attempts: %w", attempt, err)
+		}
+		txMetrics.Add("retries", 1)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(txRetryDelay(attempt)):
+		}
+	}
+}
+
+func (s *SQLStore) tryTx(ctx context.Context, opts pgx.TxOptions, fn func(*Queries) error) error {
+	tx, err := s.connPool.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -33,10 +120,32 @@ func (s *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
 	q := New(tx)
 	err = fn(q)
 	if err != nil {
-		if rberr := tx.Rollback(); rberr != nil {
-			return fmt.Errorf(err.Error(), rberr)
+		if rberr := tx.Rollback(ctx); rberr != nil {
+			return fmt.Errorf("%w, rollback failed: %v", err, rberr)
 		}
 		return err
 	}
-	return tx.Commit()
+	return tx.Commit(ctx)
+}
+
+// retryableTxError reports whether err aborted the transaction on a serialization failure or a
+// deadlock, and names the metric it is counted under.
+func retryableTxError(err error) (string, bool) {
+	switch ErrorCode(err) {
+	case SerializationFailure:
+		return "serialization_failures", true
+	case DeadlockDetected:
+		return "deadlocks", true
+	}
+	return "", false
+}
+
+// txRetryDelay waits a random time up to an exponential bound, so transactions that conflicted
+// don't collide again.
+func txRetryDelay(attempt int) time.Duration {
+	bound := txRetryMaxDelay
+	if attempt < 10 {
+		bound = min(txRetryBaseDelay<<attempt, txRetryMaxDelay)
+	}
+	return time.Duration(rand.Int64N(int64(bound)))
 }
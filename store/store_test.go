@@ -2,17 +2,21 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
 	store := NewStore(testDB)
 
-	acc1 := createRandomAccount(t)
-	acc2 := createRandomAccount(t)
+	acc1 := fundAccount(t, createRandomAccount(t), 100)
+	acc2 := createRandomAccountWithCurrency(t, acc1.Currency)
 
 	println("bal before:", acc1.Balance, acc2.Balance)
 
@@ -22,10 +26,10 @@ func TestTransferTx(t *testing.T) {
 	errs := make(chan error)
 	rests := make(chan TransferTxResult)
 
-	for i := range n {
-		txName := fmt.Sprintf("tx %d", i+1)
+	// concurrent transfers from one account conflict at serializable isolation and are retried
+	ctx := WithIsolationLevel(context.Background(), pgx.Serializable)
+	for range n {
 		go func() {
-			ctx := context.WithValue(context.Background(), txKey, txName)
 			res, err := store.TransferTx(ctx, TransferTxParams{
 				FromAccountID: acc1.ID,
 				ToAccountID:   acc2.ID,
@@ -53,6 +57,7 @@ func TestTransferTx(t *testing.T) {
 		require.Equal(t, amount, trans.Amount)
 		require.NotZero(t, trans.ID)
 		require.NotZero(t, trans.CreatedAt)
+		require.True(t, trans.JournalID.Valid)
 
 		fromacc := res.FromAccount
 		require.NotEmpty(t, fromacc)
@@ -84,5 +89,59 @@ func TestTransferTx(t *testing.T) {
 
 	require.Equal(t, updacc1.Balance, acc1.Balance-int64(n)*amount)
 	require.Equal(t, updacc2.Balance, acc2.Balance+int64(n)*amount)
+}
+
+func TestIsTransferFailure(t *testing.T) {
+	require.True(t, isTransferFailure(fmt.Errorf("item 1: %w", ErrAccountNotFound)))
+	require.True(t, isTransferFailure(fmt.Errorf("item 1: %w", ErrInsufficientFunds)))
+	require.False(t, isTransferFailure(ErrRecordNotFound))
+	require.False(t, isTransferFailure(fmt.Errorf("fee rule: %w", pgx.ErrNoRows)))
+}
+
+func TestRetryableTxError(t *testing.T) {
+	code, ok := retryableTxError(&pgconn.PgError{Code: "40001"})
+	require.True(t, ok)
+	require.Equal(t, "serialization_failures", code)
+
+	code, ok = retryableTxError(fmt.Errorf("transfer: %w", &pgconn.PgError{Code: "40P01"}))
+	require.True(t, ok)
+	require.Equal(t, "deadlocks", code)
+
+	_, ok = retryableTxError(&pgconn.PgError{Code: "23505"})
+	require.False(t, ok)
+	_, ok = retryableTxError(errors.New("40001"))
+	require.False(t, ok)
+	_, ok = retryableTxError(nil)
+	require.False(t, ok)
+
+	for attempt := 1; attempt <= 20; attempt++ {
+		delay := txRetryDelay(attempt)
+		require.GreaterOrEqual(t, delay, time.Duration(0))
+		require.Less(t, delay, txRetryMaxDelay)
+	}
+}
+
+func TestExecTxRetriesSerializationFailures(t *testing.T) {
+	store := NewStore(testDB).(*SQLStore)
+
+	attempts := 0
+	err := store.execTx(context.Background(), func(q *Queries) error {
+		attempts++
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = store.execTx(context.Background(), func(q *Queries) error {
+		attempts++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+	require.Error(t, err)
+	require.Equal(t, maxTxAttempts, attempts)
 
+	require.Equal(t, DeadlockDetected, ErrorCode(err))
 }
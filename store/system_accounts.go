@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"slices"
+)
+
+// Owners of the system accounts, seeded by the migrations. Each of them has
+// one account per currency that is opened the first time it is needed.
+const (
+	// SystemCash is the money held by the bank, debited when cash comes in.
+	SystemCash = "sys_cash"
+	// SystemFees collects the fees charged to customers.
+	SystemFees = "sys_fees"
+	// SystemFx holds the FX position of the bank for each currency.
+	SystemFx = "fx_house"
+	// SystemFxIncome collects the spread earned on currency conversions.
+	SystemFxIncome = "sys_fx_income"
+	// SystemSuspense parks money that cannot be booked to its final account yet.
+	SystemSuspense = "sys_suspense"
+	// SystemInterest pays the interest credited to customers, its balance is the interest expense.
+	SystemInterest = "sys_interest"
+)
+
+var systemOwners = []string{SystemCash, SystemFees, SystemFx, SystemFxIncome, SystemSuspense, SystemInterest}
+
+// SystemOwners returns the owners of the system accounts.
+func SystemOwners() []string {
+	return slices.Clone(systemOwners)
+}
+
+func IsSystemAccount(acc Account) bool {
+	return slices.Contains(systemOwners, acc.Owner)
+}
+
+// SystemAccount returns the account of the system owner for the currency and opens it on first use.
+func SystemAccount(ctx context.Context, q Querier, owner string, currency string) (Account, error) {
+	arg := GetAccountByOwnerAndCurrencyParams{
+		Owner:    owner,
+		Currency: currency,
+	}
+	acc, err := q.GetAccountByOwnerAndCurrency(ctx, arg)
+	if err != ErrRecordNotFound {
+		return acc, err
+	}
+
+	err = q.EnsureAccount(ctx, EnsureAccountParams{
+		Owner:    owner,
+		Currency: currency,
+	})
+	if err != nil {
+		return acc, err
+	}
+	return q.GetAccountByOwnerAndCurrency(ctx, arg)
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferBatch(t *testing.T, from Account, mode string, items []TransferBatchItemParams) TransferBatch {
+	batch, created, err := testQueries.CreateTransferBatchWithItems(context.Background(), CreateTransferBatchParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      from.Currency,
+		Mode:          mode,
+	}, items)
+	require.NoError(t, err)
+	require.Equal(t, BatchPending, batch.Status)
+	require.Equal(t, int32(len(items)), batch.ItemCount)
+	require.Len(t, created, len(items))
+	for i, item := range created {
+		require.Equal(t, int32(i), item.Position)
+		require.Equal(t, BatchItemPending, item.Status)
+	}
+	return batch
+}
+
+func batchItemsOf(t *testing.T, batch TransferBatch) []TransferBatchItem {
+	items, err := testQueries.ListTransferBatchItems(context.Background(), ListTransferBatchItemsParams{
+		BatchID: batch.ID,
+		Limit:   batch.ItemCount,
+	})
+	require.NoError(t, err)
+	return items
+}
+
+func TestExecuteTransferBatchTxAtomic(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to1 := createRandomAccountWithCurrency(t, util.USD)
+	to2 := createRandomAccountWithCurrency(t, util.USD)
+	batch := createRandomTransferBatch(t, from, BatchAtomic, []TransferBatchItemParams{
+		{ToAccountID: to1.ID, Amount: 30},
+		{ToAccountID: to2.ID, Amount: 30},
+	})
+
+	res, err := store.ExecuteTransferBatchTx(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.False(t, res.Skipped)
+	require.Equal(t, BatchCompleted, res.Batch.Status)
+	require.Equal(t, int32(2), res.Batch.SucceededCount)
+	require.True(t, res.Batch.FinishedAt.Valid)
+	for _, item := range batchItemsOf(t, batch) {
+		require.Equal(t, BatchItemSucceeded, item.Status)
+		require.True(t, item.TransferID.Valid)
+	}
+
+	acc, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-60, acc.Balance)
+
+	// a finished batch is not executed again
+	res, err = store.ExecuteTransferBatchTx(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.True(t, res.Skipped)
+}
+
+func TestExecuteTransferBatchTxAtomicRollback(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to := createRandomAccountWithCurrency(t, util.USD)
+	batch := createRandomTransferBatch(t, from, BatchAtomic, []TransferBatchItemParams{
+		{ToAccountID: to.ID, Amount: 30},
+		{ToAccountID: to.ID, Amount: 1000},
+		{ToAccountID: to.ID, Amount: 30},
+	})
+
+	res, err := store.ExecuteTransferBatchTx(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, BatchFailed, res.Batch.Status)
+	require.Zero(t, res.Batch.SucceededCount)
+	require.Equal(t, int32(3), res.Batch.FailedCount)
+
+	items := batchItemsOf(t, batch)
+	require.Contains(t, items[1].Error, ErrInsufficientFunds.Error())
+	for _, item := range items {
+		require.Equal(t, BatchItemFailed, item.Status)
+		require.False(t, item.TransferID.Valid)
+	}
+
+	// the first transfer was rolled back with the batch
+	acc, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, acc.Balance)
+}
+
+func TestExecuteTransferBatchTxBestEffort(t *testing.T) {
+	store := NewStore(testDB)
+
+	from := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 100)
+	to := createRandomAccountWithCurrency(t, util.USD)
+	batch := createRandomTransferBatch(t, from, BatchBestEffort, []TransferBatchItemParams{
+		{ToAccountID: to.ID, Amount: 30},
+		{ToAccountID: to.ID, Amount: 1000},
+		{ToAccountID: to.ID, Amount: 30},
+	})
+
+	res, err := store.ExecuteTransferBatchTx(context.Background(), batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, BatchPartiallyCompleted, res.Batch.Status)
+	require.Equal(t, int32(2), res.Batch.SucceededCount)
+	require.Equal(t, int32(1), res.Batch.FailedCount)
+
+	items := batchItemsOf(t, batch)
+	require.Equal(t, BatchItemSucceeded, items[0].Status)
+	require.Equal(t, BatchItemFailed, items[1].Status)
+	require.Contains(t, items[1].Error, ErrInsufficientFunds.Error())
+	require.Equal(t, BatchItemSucceeded, items[2].Status)
+
+	acc, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-60, acc.Balance)
+}
@@ -7,26 +7,69 @@ package store
 
 import (
 	"context"
+	"database/sql"
 )
 
+const addTransferReversedAmount = `-- name: AddTransferReversedAmount :one
+UPDATE transfers
+SET reversed_amount = reversed_amount + $1
+WHERE id = $2
+RETURNING id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee
+`
+
+type AddTransferReversedAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, addTransferReversedAmount, arg.Amount, arg.ID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.JournalID,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Fee,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  journal_id,
+  reversal_of,
+  fee
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	JournalID     sql.NullInt64 `json:"journal_id"`
+	ReversalOf    sql.NullInt64 `json:"reversal_of"`
+	Fee           int64         `json:"fee"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.JournalID,
+		arg.ReversalOf,
+		arg.Fee,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,17 +77,21 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalID,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Fee,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, getTransfer, id)
+	row := q.db.QueryRow(ctx, getTransfer, id)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -52,12 +99,146 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.JournalID,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Fee,
 	)
 	return i, err
 }
 
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.JournalID,
+		&i.ReversalOf,
+		&i.ReversedAmount,
+		&i.Fee,
+	)
+	return i, err
+}
+
+const listReversals = `-- name: ListReversals :many
+SELECT id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee FROM transfers
+WHERE reversal_of = $1
+ORDER BY id
+`
+
+func (q *Queries) ListReversals(ctx context.Context, reversalOf sql.NullInt64) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listReversals, reversalOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.JournalID,
+			&i.ReversalOf,
+			&i.ReversedAmount,
+			&i.Fee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferHistory = `-- name: ListTransferHistory :many
+SELECT id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee FROM transfers
+WHERE (
+    (from_account_id = $1 AND $2::bool IS NOT TRUE) OR
+    (to_account_id = $1 AND $2 IS NOT FALSE)
+  )
+  AND ($3::timestamptz IS NULL OR created_at >= $3)
+  AND ($4::timestamptz IS NULL OR created_at < $4)
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::bigint IS NULL OR $7 IN (from_account_id, to_account_id))
+  AND ($8::timestamptz IS NULL OR (created_at, id) < ($8, $9::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT $10
+`
+
+type ListTransferHistoryParams struct {
+	AccountID       int64         `json:"account_id"`
+	Incoming        sql.NullBool  `json:"incoming"`
+	StartTime       sql.NullTime  `json:"start_time"`
+	EndTime         sql.NullTime  `json:"end_time"`
+	MinAmount       sql.NullInt64 `json:"min_amount"`
+	MaxAmount       sql.NullInt64 `json:"max_amount"`
+	CounterpartyID  sql.NullInt64 `json:"counterparty_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        int64         `json:"cursor_id"`
+	Limit           int32         `json:"limit"`
+}
+
+// ListTransferHistory returns the transfers of an account newest first after the (created_at, id) cursor, null filters match any transfer.
+func (q *Queries) ListTransferHistory(ctx context.Context, arg ListTransferHistoryParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransferHistory,
+		arg.AccountID,
+		arg.Incoming,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CounterpartyID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.JournalID,
+			&i.ReversalOf,
+			&i.ReversedAmount,
+			&i.Fee,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, journal_id, reversal_of, reversed_amount, fee FROM transfers
 WHERE 
     from_account_id = $1 OR
     to_account_id = $2
@@ -74,7 +255,7 @@ type ListTransfersParams struct {
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
-	rows, err := q.db.QueryContext(ctx, listTransfers,
+	rows, err := q.db.Query(ctx, listTransfers,
 		arg.FromAccountID,
 		arg.ToAccountID,
 		arg.Limit,
@@ -93,14 +274,15 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.JournalID,
+			&i.ReversalOf,
+			&i.ReversedAmount,
+			&i.Fee,
 		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
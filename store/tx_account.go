@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Statuses of an account. Only active accounts can be posted to.
+const (
+	AccountActive = "active"
+	AccountFrozen = "frozen"
+	AccountClosed = "closed"
+)
+
+// JournalAccountClosure is the kind of the journal sweeping the balance of a closed account.
+const JournalAccountClosure = "account_closure"
+
+var (
+	ErrAccountNotFound    = errors.New("account not found")
+	ErrAccountFrozen      = errors.New("account is frozen")
+	ErrAccountClosed      = errors.New("account is closed")
+	ErrAccountHasHolds    = errors.New("account has active holds")
+	ErrAccountInDebit     = errors.New("account balance is negative")
+	ErrSweepAccountNeeded = errors.New("a sweep account is needed to close an account with a balance")
+)
+
+// lookupAccount loads an account inside a transaction and reports a missing account as
+// ErrAccountNotFound, unlike a missing row of any other table.
+func lookupAccount(ctx context.Context, q *Queries, id int64) (Account, error) {
+	acc, err := q.GetAccount(ctx, id)
+	if err == ErrRecordNotFound {
+		return acc, ErrAccountNotFound
+	}
+	return acc, err
+}
+
+// accountStatusError tells why an account cannot be posted to, it's nil for active accounts.
+func accountStatusError(acc Account) error {
+	switch acc.Status {
+	case AccountFrozen:
+		return ErrAccountFrozen
+	case AccountClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// CreateAccountTx opens an account and announces it to the downstream services.
+func (s *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var acc Account
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+		acc, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+		return q.EmitEvent(ctx, accountCreatedEvent(acc))
+	})
+	return acc, err
+}
+
+type CloseAccountTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// SweepAccountID receives the remaining balance, it's only needed when there is one
+	SweepAccountID int64  `json:"sweep_account_id"`
+	Reason         string `json:"reason"`
+}
+type CloseAccountTxResult struct {
+	Account Account `json:"account"`
+	// Sweep is set when a balance was moved to the sweep account
+	Sweep *PostJournalResult `json:"sweep,omitempty"`
+}
+
+// CloseAccountTx moves the remaining balance of an account to the sweep account and closes it.
+// The account keeps its entries and transfers, its standing orders are cancelled.
+func (s *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var res CloseAccountTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := accountStatusError(acc); err != nil {
+			return err
+		}
+		if acc.HeldBalance > 0 {
+			return ErrAccountHasHolds
+		}
+		if acc.Balance < 0 {
+			return ErrAccountInDebit
+		}
+
+		if acc.Balance > 0 {
+			if arg.SweepAccountID == 0 {
+				return ErrSweepAccountNeeded
+			}
+			sweep, err := postJournal(ctx, q, PostJournalParams{
+				Kind:      JournalAccountClosure,
+				Reference: strconv.FormatInt(acc.ID, 10),
+				Postings: []Posting{
+					{AccountID: acc.ID, Amount: -acc.Balance},
+					{AccountID: arg.SweepAccountID, Amount: acc.Balance},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if err := q.EmitEvent(ctx, journalPostedEvent(sweep)); err != nil {
+				return err
+			}
+			res.Sweep = &sweep
+		}
+
+		err = q.CancelAccountStandingOrders(ctx, acc.ID)
+		if err != nil {
+			return err
+		}
+
+		res.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:           acc.ID,
+			FromStatus:   AccountActive,
+			Status:       AccountClosed,
+			StatusReason: arg.Reason,
+			ClosedAt:     sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     "close_account",
+			EntityType: "account",
+			EntityID:   acc.ID,
+			Before:     acc,
+			After:      res.Account,
+		})
+	})
+	if err != nil {
+		return CloseAccountTxResult{}, err
+	}
+	return res, nil
+}
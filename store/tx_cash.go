@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kinds of cash receipts, the journal of each receipt uses the same kind.
+const (
+	JournalDeposit    = "deposit"
+	JournalWithdrawal = "withdrawal"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+type CashTxParams struct {
+	// Kind is JournalDeposit or JournalWithdrawal
+	Kind      string `json:"kind"`
+	AccountID int64  `json:"account_id"`
+	Amount    int64  `json:"amount"`
+	Teller    string `json:"teller"`
+}
+type CashTxResult struct {
+	Receipt CashReceipt `json:"receipt"`
+	Account Account     `json:"account"`
+	Entry   Entry       `json:"entry"`
+}
+
+// CashTx books cash handed over the counter against the cash system account of the
+// account currency and records a receipt for the teller's end-of-day totals.
+func (s *SQLStore) CashTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	var res CashTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		cash, err := SystemAccount(ctx, q, SystemCash, acc.Currency)
+		if err != nil {
+			return err
+		}
+
+		var amount int64
+		switch arg.Kind {
+		case JournalDeposit:
+			amount = arg.Amount
+		case JournalWithdrawal:
+			amount = -arg.Amount
+		default:
+			return fmt.Errorf("unknown cash receipt kind %q", arg.Kind)
+		}
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			Kind: arg.Kind,
+			Postings: []Posting{
+				{AccountID: cash.ID, Amount: -amount},
+				{AccountID: acc.ID, Amount: amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		res.Entry = journal.Entries[1]
+		res.Account = journal.Accounts[acc.ID]
+		if arg.Kind == JournalWithdrawal && !hasFunds(res.Account) {
+			return ErrInsufficientFunds
+		}
+		if err := q.EmitEvent(ctx, journalPostedEvent(journal)); err != nil {
+			return err
+		}
+
+		res.Receipt, err = q.CreateCashReceipt(ctx, CreateCashReceiptParams{
+			Kind:      arg.Kind,
+			AccountID: acc.ID,
+			Amount:    arg.Amount,
+			Currency:  acc.Currency,
+			Teller:    arg.Teller,
+			JournalID: journal.Journal.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     arg.Kind,
+			EntityType: "cash_receipt",
+			EntityID:   res.Receipt.ID,
+			After:      res.Receipt,
+		})
+	})
+	if err != nil {
+		return CashTxResult{}, err
+	}
+	return res, nil
+}
@@ -6,7 +6,8 @@ import (
 
 type CreateUserTxParams struct {
 	CreateUserParams
-	AfterCreate func(user User) error
+	// Tasks are enqueued through the outbox once the user is committed
+	Tasks []OutboxTask
 }
 type CreateUserTxResult struct {
 	User User
@@ -20,8 +21,21 @@ func (s *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (Cr
 		if err != nil {
 			return err
 		}
-		err = arg.AfterCreate(res.User)
-		return err
+		err = recordAudit(ctx, q, AuditRecord{
+			Action:     "create_user",
+			EntityType: "user",
+			EntityID:   res.User.Username,
+			After:      res.User,
+		})
+		if err != nil {
+			return err
+		}
+		for _, task := range arg.Tasks {
+			if err := q.EnqueueTask(ctx, task); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 	return res, err
 }
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// Types of an account, fee rules can differ per type.
+const (
+	AccountChecking = "checking"
+	AccountSavings  = "savings"
+	AccountBusiness = "business"
+)
+
+// Kinds of a fee rule.
+const (
+	FeeFlat       = "flat"
+	FeePercentage = "percentage"
+)
+
+// transferFee is what the sender pays on top of a transfer and the account it is booked to.
+type transferFee struct {
+	amount    int64
+	accountID int64
+}
+
+// feeFor looks up the fee rule for the sending account. Transfers without a rule are free.
+func feeFor(ctx context.Context, q *Queries, from Account, amount int64) (transferFee, error) {
+	if IsSystemAccount(from) {
+		return transferFee{}, nil
+	}
+	rule, err := q.GetFeeRule(ctx, GetFeeRuleParams{
+		Currency:    from.Currency,
+		AccountType: from.AccountType,
+	})
+	if err != nil {
+		if err == ErrRecordNotFound {
+			return transferFee{}, nil
+		}
+		return transferFee{}, err
+	}
+	fee, err := computeFee(rule, amount)
+	if err != nil || fee == 0 {
+		return transferFee{}, err
+	}
+
+	fees, err := SystemAccount(ctx, q, SystemFees, from.Currency)
+	if err != nil {
+		return transferFee{}, err
+	}
+	return transferFee{amount: fee, accountID: fees.ID}, nil
+}
+
+// postings debits the fee from the sender and credits it to the fee income account.
+func (f transferFee) postings(fromAccountID int64) []Posting {
+	if f.amount == 0 {
+		return nil
+	}
+	return []Posting{
+		{AccountID: fromAccountID, Amount: -f.amount},
+		{AccountID: f.accountID, Amount: f.amount},
+	}
+}
+
+// computeFee applies a fee rule to amount. Percentages are rounded half up to a minor unit
+// before the minimum and maximum are applied.
+func computeFee(rule FeeRule, amount int64) (int64, error) {
+	fee := rule.FlatAmount
+	if rule.Kind == FeePercentage {
+		var err error
+		fee, err = util.BasisPointsOf(amount, rule.RateBps)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if rule.MinAmount.Valid && fee < rule.MinAmount.Int64 {
+		fee = rule.MinAmount.Int64
+	}
+	if rule.MaxAmount.Valid && fee > rule.MaxAmount.Int64 {
+		fee = rule.MaxAmount.Int64
+	}
+	return fee, nil
+}
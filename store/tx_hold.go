@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Statuses of a hold.
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldVoided   = "voided"
+	HoldExpired  = "expired"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is no longer active")
+	ErrHoldExpired        = errors.New("hold expired")
+	ErrHoldNotExpired     = errors.New("hold has not expired yet")
+	ErrHoldAmountExceeded = errors.New("amount exceeds the remaining hold amount")
+)
+
+type CreateHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+type HoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// CreateHoldTx reserves amount on the account for a later capture. The hold lowers the
+// available balance of the account, its ledger balance stays the same until the capture.
+// Holds are subject to the transfer limits of the owner, which are checked again on capture.
+func (s *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (HoldTxResult, error) {
+	var res HoldTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := checkTransferLimits(ctx, q, acc, arg.Amount, time.Now()); err != nil {
+			return err
+		}
+
+		res.Account, err = q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		if err := accountStatusError(res.Account); err != nil {
+			return err
+		}
+		if !hasFunds(res.Account) {
+			return ErrInsufficientFunds
+		}
+
+		res.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			Currency:    res.Account.Currency,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     "create_hold",
+			EntityType: "hold",
+			EntityID:   res.Hold.ID,
+			After:      res.Hold,
+		})
+	})
+	if err != nil {
+		return HoldTxResult{}, err
+	}
+	return res, nil
+}
+
+type CaptureHoldTxParams struct {
+	ID     int64 `json:"id"`
+	Amount int64 `json:"amount"`
+	// Final releases whatever is left of the hold after this capture
+	Final bool `json:"final"`
+}
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx turns amount of an active hold into a transfer to the account the hold was
+// made for. A hold can be captured in several parts, it's captured once nothing is left.
+func (s *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var res CaptureHoldTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if hold.Status != HoldActive {
+			return ErrHoldNotActive
+		}
+		if !time.Now().Before(hold.ExpiresAt) {
+			return ErrHoldExpired
+		}
+		remaining := hold.Amount - hold.CapturedAmount
+		if arg.Amount > remaining {
+			return ErrHoldAmountExceeded
+		}
+
+		// holds don't count towards the limits until captured, so other transfers may have
+		// used up the limit since the hold was made
+		from, err := q.GetAccount(ctx, hold.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := checkTransferLimits(ctx, q, from, arg.Amount, time.Now()); err != nil {
+			return err
+		}
+
+		// the hold reserved the amount only, the fee has to be covered by the available balance
+		fee, err := feeFor(ctx, q, from, arg.Amount)
+		if err != nil {
+			return err
+		}
+		res.Transfer, err = transferTx(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        arg.Amount,
+		}, fee)
+		if err != nil {
+			return err
+		}
+
+		released := arg.Amount
+		status := HoldActive
+		if arg.Final || arg.Amount == remaining {
+			released = remaining
+			status = HoldCaptured
+		}
+		res.Transfer.FromAccount, err = q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			ID:     hold.AccountID,
+			Amount: -released,
+		})
+		if err != nil {
+			return err
+		}
+		if !hasFunds(res.Transfer.FromAccount) {
+			return ErrInsufficientFunds
+		}
+
+		res.Hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+			ID:             hold.ID,
+			Status:         status,
+			CapturedAmount: hold.CapturedAmount + arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     "capture_hold",
+			EntityType: "hold",
+			EntityID:   hold.ID,
+			Before:     hold,
+			After:      res.Hold,
+		})
+	})
+	if err != nil {
+		return CaptureHoldTxResult{}, err
+	}
+	return res, nil
+}
+
+// VoidHoldTx cancels an active hold and gives what is left of it back to the available balance.
+func (s *SQLStore) VoidHoldTx(ctx context.Context, id int64) (HoldTxResult, error) {
+	return s.releaseHoldTx(ctx, id, HoldVoided, "void_hold")
+}
+
+// ExpireHoldTx releases an active hold that is past its expiry time.
+func (s *SQLStore) ExpireHoldTx(ctx context.Context, id int64) (HoldTxResult, error) {
+	return s.releaseHoldTx(ctx, id, HoldExpired, "expire_hold")
+}
+
+func (s *SQLStore) releaseHoldTx(ctx context.Context, id int64, status string, action string) (HoldTxResult, error) {
+	var res HoldTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if hold.Status != HoldActive {
+			return ErrHoldNotActive
+		}
+		if status == HoldExpired && time.Now().Before(hold.ExpiresAt) {
+			return ErrHoldNotExpired
+		}
+
+		res.Account, err = q.AddAccountHeldBalance(ctx, AddAccountHeldBalanceParams{
+			ID:     hold.AccountID,
+			Amount: -(hold.Amount - hold.CapturedAmount),
+		})
+		if err != nil {
+			return err
+		}
+
+		res.Hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+			ID:             hold.ID,
+			Status:         status,
+			CapturedAmount: hold.CapturedAmount,
+		})
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     action,
+			EntityType: "hold",
+			EntityID:   hold.ID,
+			Before:     hold,
+			After:      res.Hold,
+		})
+	})
+	if err != nil {
+		return HoldTxResult{}, err
+	}
+	return res, nil
+}
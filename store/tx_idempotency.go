@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+type IdempotencyTxParams struct {
+	Username    string
+	Key         string
+	RequestHash string
+	// ExpiresAt is when the key can be used again and gets purged
+	ExpiresAt time.Time
+	// Handle runs the actual request. Its response is stored only when it returns a nil error.
+	Handle func() (code int32, body []byte, err error)
+}
+
+type IdempotencyTxResult struct {
+	ResponseCode int32
+	ResponseBody []byte
+	Replayed     bool
+}
+
+// IdempotencyTx runs arg.Handle at most once per username and key.
+// The key is claimed and committed before Handle runs, so Handle books its changes in
+// transactions of its own without holding the key row or a second connection.
+// A duplicate replays the stored response, or fails with ErrIdempotencyKeyInProgress
+// while the first request is still running. When Handle fails the claim is released
+// so the client can fix and retry the request. When storing the response fails the key
+// stays in progress until it expires rather than letting a retry run Handle again.
+func (s *SQLStore) IdempotencyTx(ctx context.Context, arg IdempotencyTxParams) (IdempotencyTxResult, error) {
+	n, err := s.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    arg.Username,
+		Key:         arg.Key,
+		RequestHash: arg.RequestHash,
+		ExpiresAt:   arg.ExpiresAt,
+	})
+	if err != nil {
+		return IdempotencyTxResult{}, err
+	}
+
+	if n == 0 {
+		key, err := s.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+			Username: arg.Username,
+			Key:      arg.Key,
+		})
+		if err != nil {
+			return IdempotencyTxResult{}, err
+		}
+		if key.RequestHash != arg.RequestHash {
+			return IdempotencyTxResult{}, ErrIdempotencyKeyReused
+		}
+		if !key.CompletedAt.Valid {
+			return IdempotencyTxResult{}, ErrIdempotencyKeyInProgress
+		}
+		return IdempotencyTxResult{
+			ResponseCode: key.ResponseCode,
+			ResponseBody: key.ResponseBody,
+			Replayed:     true,
+		}, nil
+	}
+
+	code, body, err := arg.Handle()
+	if err != nil {
+		releaseErr := s.DeleteIdempotencyKey(context.WithoutCancel(ctx), DeleteIdempotencyKeyParams{
+			Username: arg.Username,
+			Key:      arg.Key,
+		})
+		return IdempotencyTxResult{}, errors.Join(err, releaseErr)
+	}
+
+	key, err := s.UpdateIdempotencyKeyResponse(context.WithoutCancel(ctx), UpdateIdempotencyKeyResponseParams{
+		ResponseCode: code,
+		ResponseBody: body,
+		Username:     arg.Username,
+		Key:          arg.Key,
+	})
+	if err != nil {
+		return IdempotencyTxResult{}, err
+	}
+	return IdempotencyTxResult{
+		ResponseCode: key.ResponseCode,
+		ResponseBody: key.ResponseBody,
+	}, nil
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// JournalInterest is the kind of the journals capitalizing accrued interest.
+const JournalInterest = "interest"
+
+type AccrueInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Day is the date the interest accrues for, an account accrues at most once a day
+	Day time.Time `json:"day"`
+}
+type AccrueInterestTxResult struct {
+	Accrual InterestAccrual `json:"accrual"`
+	// Skipped is set when the account earns no interest or the day already accrued
+	Skipped bool `json:"skipped"`
+}
+
+// AccrueInterestTx records the interest the closing balance of the day earns under the
+// interest product of the account. Nothing is posted until the interest is capitalized.
+func (s *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error) {
+	var res AccrueInterestTxResult
+	day := arg.Day.UTC().Truncate(24 * time.Hour)
+	dayEnd := day.AddDate(0, 0, 1)
+	err := s.execTx(ctx, func(q *Queries) error {
+		// the lock keeps new entries out while the closing balance is worked out
+		acc, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if !acc.InterestProductID.Valid || acc.Status == AccountClosed || !acc.CreatedAt.Before(dayEnd) {
+			res.Skipped = true
+			return nil
+		}
+		if _, err := q.GetInterestAccrual(ctx, GetInterestAccrualParams{AccountID: acc.ID, AccruedOn: day}); err == nil {
+			res.Skipped = true
+			return nil
+		} else if err != ErrRecordNotFound {
+			return err
+		}
+
+		product, err := q.GetInterestProduct(ctx, acc.InterestProductID.Int64)
+		if err != nil {
+			return err
+		}
+		since, err := q.SumEntriesSince(ctx, SumEntriesSinceParams{
+			AccountID: acc.ID,
+			CreatedAt: dayEnd,
+		})
+		if err != nil {
+			return err
+		}
+		balance := acc.Balance - since
+
+		// a negative balance earns nothing, overdraft interest is charged separately
+		var amount int64
+		if balance > 0 {
+			amount, err = util.DailyInterestMicros(balance, product.AnnualRateBps, product.DayCount, day)
+			if err != nil {
+				return err
+			}
+		}
+
+		res.Accrual, err = q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:     acc.ID,
+			AccruedOn:     day,
+			ProductID:     product.ID,
+			AnnualRateBps: product.AnnualRateBps,
+			Balance:       balance,
+			AmountMicros:  amount,
+		})
+		return err
+	})
+	if err != nil {
+		return AccrueInterestTxResult{}, err
+	}
+	return res, nil
+}
+
+type CapitalizeInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Period is any day of the month to capitalize, a month is capitalized at most once
+	Period time.Time `json:"period"`
+}
+type CapitalizeInterestTxResult struct {
+	Capitalization InterestCapitalization `json:"capitalization"`
+	Account        Account                `json:"account"`
+	// Skipped is set when nothing accrued, the month was already capitalized or the
+	// account isn't active, the accruals then wait for a later month
+	Skipped bool `json:"skipped"`
+}
+
+// CapitalizeInterestTx posts the interest accrued up to the end of the month from the interest
+// system account to the account. Only whole minor units are posted, the fraction left over is
+// carried to the next month.
+func (s *SQLStore) CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error) {
+	var res CapitalizeInterestTxResult
+	period := arg.Period.UTC()
+	period = time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := period.AddDate(0, 1, 0)
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		res.Account = acc
+		if acc.Status != AccountActive {
+			res.Skipped = true
+			return nil
+		}
+		if _, err := q.GetInterestCapitalization(ctx, GetInterestCapitalizationParams{AccountID: acc.ID, Period: period}); err == nil {
+			res.Skipped = true
+			return nil
+		} else if err != ErrRecordNotFound {
+			return err
+		}
+
+		accrued, err := q.SumInterestAccruals(ctx, SumInterestAccrualsParams{
+			AccountID: acc.ID,
+			AccruedOn: periodEnd,
+		})
+		if err != nil {
+			return err
+		}
+		if accrued.Count == 0 {
+			res.Skipped = true
+			return nil
+		}
+		var carried int64
+		last, err := q.GetLastInterestCapitalization(ctx, acc.ID)
+		if err == nil {
+			carried = last.CarriedMicros
+		} else if err != ErrRecordNotFound {
+			return err
+		}
+
+		total := accrued.AmountMicros + carried
+		amount := total / util.MicrosPerUnit
+		var journalID sql.NullInt64
+		if amount > 0 {
+			interest, err := SystemAccount(ctx, q, SystemInterest, acc.Currency)
+			if err != nil {
+				return err
+			}
+			journal, err := postJournal(ctx, q, PostJournalParams{
+				Kind:      JournalInterest,
+				Reference: strconv.FormatInt(acc.ID, 10) + ":" + period.Format("2006-01"),
+				Postings: []Posting{
+					{AccountID: interest.ID, Amount: -amount},
+					{AccountID: acc.ID, Amount: amount},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if err := q.EmitEvent(ctx, journalPostedEvent(journal)); err != nil {
+				return err
+			}
+			res.Account = journal.Accounts[acc.ID]
+			journalID = sql.NullInt64{Int64: journal.Journal.ID, Valid: true}
+		}
+
+		res.Capitalization, err = q.CreateInterestCapitalization(ctx, CreateInterestCapitalizationParams{
+			AccountID:     acc.ID,
+			Period:        period,
+			AccruedMicros: total,
+			Amount:        amount,
+			CarriedMicros: total - amount*util.MicrosPerUnit,
+			JournalID:     journalID,
+		})
+		if err != nil {
+			return err
+		}
+		return q.CapitalizeInterestAccruals(ctx, CapitalizeInterestAccrualsParams{
+			CapitalizationID: sql.NullInt64{Int64: res.Capitalization.ID, Valid: true},
+			AccountID:        acc.ID,
+			Before:           periodEnd,
+		})
+	})
+	if err != nil {
+		return CapitalizeInterestTxResult{}, err
+	}
+	return res, nil
+}
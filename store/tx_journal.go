@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+)
+
+// Kinds of journals posted by the store.
+const (
+	JournalTransfer   = "transfer"
+	JournalFxTransfer = "fx_transfer"
+)
+
+var (
+	ErrJournalTooShort   = errors.New("journal needs at least two non-zero postings")
+	ErrJournalUnbalanced = errors.New("journal postings do not sum to zero")
+)
+
+// Posting moves amount into the account, negative amounts take money out.
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type PostJournalParams struct {
+	Kind      string    `json:"kind"`
+	Reference string    `json:"reference"`
+	Postings  []Posting `json:"postings"`
+}
+type PostJournalResult struct {
+	Journal Journal `json:"journal"`
+	// Entries are in the same order as the postings
+	Entries  []Entry           `json:"entries"`
+	Accounts map[int64]Account `json:"accounts"`
+}
+
+// PostJournal books a balanced set of postings in its own transaction and emits the
+// JournalPosted event. It's the only way balances are meant to change.
+func (s *SQLStore) PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error) {
+	var res PostJournalResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		var err error
+		res, err = postJournal(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+		return q.EmitEvent(ctx, journalPostedEvent(res))
+	})
+	if err != nil {
+		return PostJournalResult{}, err
+	}
+	return res, nil
+}
+
+// postJournal books the postings inside an existing transaction. The postings must sum to zero
+// for every currency, the entries_journal_balanced trigger checks the same again on commit.
+func postJournal(ctx context.Context, q *Queries, arg PostJournalParams) (PostJournalResult, error) {
+	var res PostJournalResult
+	if len(arg.Postings) < 2 {
+		return res, ErrJournalTooShort
+	}
+	for _, p := range arg.Postings {
+		if p.Amount == 0 {
+			return res, ErrJournalTooShort
+		}
+	}
+
+	var err error
+	res.Journal, err = q.CreateJournal(ctx, CreateJournalParams{
+		Kind:      arg.Kind,
+		Reference: arg.Reference,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res.Accounts, err = addBalances(ctx, q, arg.Postings)
+	if err != nil {
+		return res, err
+	}
+	sums := make(map[string]int64)
+	for _, p := range arg.Postings {
+		sums[res.Accounts[p.AccountID].Currency] += p.Amount
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return res, ErrJournalUnbalanced
+		}
+	}
+
+	res.Entries = make([]Entry, 0, len(arg.Postings))
+	for _, p := range arg.Postings {
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: p.AccountID,
+			Amount:    p.Amount,
+			JournalID: sql.NullInt64{Int64: res.Journal.ID, Valid: true},
+		})
+		if err != nil {
+			return res, err
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return res, nil
+}
+
+// addBalances applies the postings to the account balances, one update per account in id
+// order so that concurrent transactions always lock accounts in the same order. Frozen and
+// closed accounts cannot be posted to.
+func addBalances(ctx context.Context, q *Queries, postings []Posting) (map[int64]Account, error) {
+	deltas := make(map[int64]int64)
+	ids := make([]int64, 0, len(postings))
+	for _, p := range postings {
+		if _, ok := deltas[p.AccountID]; !ok {
+			ids = append(ids, p.AccountID)
+		}
+		deltas[p.AccountID] += p.Amount
+	}
+	slices.Sort(ids)
+
+	accounts := make(map[int64]Account, len(ids))
+	for _, id := range ids {
+		acc, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     id,
+			Amount: deltas[id],
+		})
+		if err == ErrRecordNotFound {
+			return nil, ErrAccountNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := accountStatusError(acc); err != nil {
+			return nil, err
+		}
+		accounts[id] = acc
+	}
+	return accounts, nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// Scopes of a transfer limit. Limits set for a user take precedence over the ones of its tier.
+const (
+	LimitScopeUser = "user"
+	LimitScopeTier = "tier"
+)
+
+// Limits a transfer can hit.
+const (
+	LimitPerTransfer = "per_transfer"
+	LimitDaily       = "daily"
+	LimitMonthly     = "monthly"
+	LimitHourlyCount = "hourly_count"
+)
+
+var ErrLimitExceeded = errors.New("transfer limit exceeded")
+
+// LimitError tells which limit a transfer hit and when it resets. It matches ErrLimitExceeded.
+type LimitError struct {
+	Limit    string
+	Max      int64
+	Currency string
+	// ResetsAt is zero for the per transfer limit, which doesn't reset
+	ResetsAt time.Time
+}
+
+func (e *LimitError) Error() string {
+	limit := util.NewMoney(e.Max, e.Currency).String()
+	switch e.Limit {
+	case LimitPerTransfer:
+		return fmt.Sprintf("transfer exceeds the per transfer limit of %s", limit)
+	case LimitHourlyCount:
+		return fmt.Sprintf("hourly limit of %d transfers reached, resets at %s", e.Max, e.ResetsAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s transfer limit of %s exceeded, resets at %s", e.Limit, limit, e.ResetsAt.Format(time.RFC3339))
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// mergeTransferLimits applies the limits set for the user over the ones of its tier, which
+// ListTransferLimits returns first. A limit the user has no value for falls back to the tier.
+func mergeTransferLimits(limits []TransferLimit) TransferLimit {
+	var res TransferLimit
+	for _, l := range limits {
+		if l.PerTransfer.Valid {
+			res.PerTransfer = l.PerTransfer
+		}
+		if l.Daily.Valid {
+			res.Daily = l.Daily
+		}
+		if l.Monthly.Valid {
+			res.Monthly = l.Monthly
+		}
+		if l.HourlyCount.Valid {
+			res.HourlyCount = l.HourlyCount
+		}
+	}
+	return res
+}
+
+// checkTransferLimits tells whether the owner of acc may send amount at now. The owner
+// is locked until the end of the transaction, so concurrent transfers of the same user are
+// checked one after the other and can't get past a limit together. It has to run before
+// postJournal locks the accounts.
+func checkTransferLimits(ctx context.Context, q *Queries, acc Account, amount int64, now time.Time) error {
+	if IsSystemAccount(acc) {
+		return nil
+	}
+	user, err := q.GetUserForUpdate(ctx, acc.Owner)
+	if err != nil {
+		return err
+	}
+
+	limits, err := q.ListTransferLimits(ctx, ListTransferLimitsParams{
+		Currency: acc.Currency,
+		Username: user.Username,
+		Tier:     user.Tier,
+	})
+	if err != nil {
+		return err
+	}
+	limit := mergeTransferLimits(limits)
+
+	if limit.PerTransfer.Valid && amount > limit.PerTransfer.Int64 {
+		return &LimitError{Limit: LimitPerTransfer, Max: limit.PerTransfer.Int64, Currency: acc.Currency}
+	}
+	if !limit.Daily.Valid && !limit.Monthly.Valid && !limit.HourlyCount.Valid {
+		return nil
+	}
+
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	usage, err := q.GetTransferUsage(ctx, GetTransferUsageParams{
+		DayStart:   dayStart,
+		MonthStart: monthStart,
+		HourStart:  now.Add(-time.Hour),
+		Owner:      acc.Owner,
+		Currency:   acc.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	if limit.HourlyCount.Valid && usage.HourCount >= int64(limit.HourlyCount.Int32) {
+		// the window frees up a slot once its oldest transfer is an hour old
+		return &LimitError{
+			Limit:    LimitHourlyCount,
+			Max:      int64(limit.HourlyCount.Int32),
+			Currency: acc.Currency,
+			ResetsAt: usage.HourFirst.Add(time.Hour).UTC(),
+		}
+	}
+	if limit.Daily.Valid && usage.DayTotal+amount > limit.Daily.Int64 {
+		return &LimitError{Limit: LimitDaily, Max: limit.Daily.Int64, Currency: acc.Currency, ResetsAt: dayStart.AddDate(0, 0, 1)}
+	}
+	if limit.Monthly.Valid && usage.MonthTotal+amount > limit.Monthly.Int64 {
+		return &LimitError{Limit: LimitMonthly, Max: limit.Monthly.Int64, Currency: acc.Currency, ResetsAt: monthStart.AddDate(0, 1, 0)}
+	}
+	return nil
+}
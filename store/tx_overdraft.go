@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// JournalOverdraftInterest is the kind of the journals charging interest on a negative balance.
+const JournalOverdraftInterest = "overdraft_interest"
+
+// hasFunds tells whether the account stays within its overdraft limit after a debit.
+func hasFunds(acc Account) bool {
+	return acc.AvailableBalance >= -acc.OverdraftLimit
+}
+
+type ChargeOverdraftInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Day is the date the interest is charged for, an account is charged at most once a day
+	Day time.Time `json:"day"`
+}
+type ChargeOverdraftInterestTxResult struct {
+	Charge  OverdraftCharge `json:"charge"`
+	Account Account         `json:"account"`
+	// Skipped is set when there was nothing to charge or the day was already charged
+	Skipped bool `json:"skipped"`
+}
+
+// ChargeOverdraftInterestTx posts one day of interest on the negative balance of an account
+// to the fees system account. Interest may take the balance beyond the overdraft limit.
+func (s *SQLStore) ChargeOverdraftInterestTx(ctx context.Context, arg ChargeOverdraftInterestTxParams) (ChargeOverdraftInterestTxResult, error) {
+	var res ChargeOverdraftInterestTxResult
+	day := arg.Day.UTC().Truncate(24 * time.Hour)
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		res.Account = acc
+		if acc.Balance >= 0 || acc.OverdraftInterestBps == 0 || acc.Status != AccountActive {
+			res.Skipped = true
+			return nil
+		}
+		if _, err := q.GetOverdraftCharge(ctx, GetOverdraftChargeParams{AccountID: acc.ID, ChargedOn: day}); err == nil {
+			res.Skipped = true
+			return nil
+		} else if err != ErrRecordNotFound {
+			return err
+		}
+
+		amount, err := dailyInterest(-acc.Balance, acc.OverdraftInterestBps)
+		if err != nil {
+			return err
+		}
+		if amount == 0 {
+			res.Skipped = true
+			return nil
+		}
+
+		fees, err := SystemAccount(ctx, q, SystemFees, acc.Currency)
+		if err != nil {
+			return err
+		}
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			Kind:      JournalOverdraftInterest,
+			Reference: strconv.FormatInt(acc.ID, 10) + ":" + day.Format(time.DateOnly),
+			Postings: []Posting{
+				{AccountID: acc.ID, Amount: -amount},
+				{AccountID: fees.ID, Amount: amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.EmitEvent(ctx, journalPostedEvent(journal)); err != nil {
+			return err
+		}
+		res.Account = journal.Accounts[acc.ID]
+
+		res.Charge, err = q.CreateOverdraftCharge(ctx, CreateOverdraftChargeParams{
+			AccountID: acc.ID,
+			ChargedOn: day,
+			Balance:   acc.Balance,
+			Amount:    amount,
+			JournalID: journal.Journal.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return ChargeOverdraftInterestTxResult{}, err
+	}
+	return res, nil
+}
+
+// dailyInterest is a 365th of the yearly interest on amount, rounded half up to a minor unit.
+func dailyInterest(amount int64, bps int32) (int64, error) {
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(bps))),
+		big.NewInt(10000*365),
+	)
+	return util.Round(r, util.RoundHalfUp)
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Kinds of a reconciliation finding.
+const (
+	FindingBalanceMismatch   = "balance_mismatch"
+	FindingUnbalancedJournal = "unbalanced_journal"
+)
+
+const reconcileBatchSize = 1000
+
+type ReconcileLedgerParams struct {
+	// Repair rebuilds the balance of mismatched accounts from their entries
+	Repair bool `json:"repair"`
+}
+type ReconcileLedgerResult struct {
+	Run      ReconciliationRun       `json:"run"`
+	Findings []ReconciliationFinding `json:"findings"`
+}
+
+// ReconcileLedger checks that the balance of every account equals the sum of its entries and
+// that the entries of every journal sum to zero per currency, and records what doesn't. The
+// ledger is read in batches, each from a snapshot of its own, so it stays writable meanwhile.
+func (s *SQLStore) ReconcileLedger(ctx context.Context, arg ReconcileLedgerParams) (ReconcileLedgerResult, error) {
+	var res ReconcileLedgerResult
+	run, err := s.CreateReconciliationRun(ctx, arg.Repair)
+	if err != nil {
+		return res, err
+	}
+	bounds, err := s.GetLedgerBounds(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	record := func(finding CreateReconciliationFindingParams) error {
+		finding.RunID = run.ID
+		f, err := s.CreateReconciliationFinding(ctx, finding)
+		if err != nil {
+			return err
+		}
+		res.Findings = append(res.Findings, f)
+		return nil
+	}
+
+	for afterID := int64(0); afterID < bounds.MaxAccountID; afterID += reconcileBatchSize {
+		mismatches, err := s.ListBalanceMismatches(ctx, ListBalanceMismatchesParams{
+			AfterID:   afterID,
+			BatchSize: reconcileBatchSize,
+		})
+		if err != nil {
+			return res, err
+		}
+		for _, m := range mismatches {
+			finding := CreateReconciliationFindingParams{
+				Kind:      FindingBalanceMismatch,
+				AccountID: sql.NullInt64{Int64: m.ID, Valid: true},
+				Currency:  m.Currency,
+				Expected:  m.EntriesTotal,
+				Actual:    m.Balance,
+			}
+			if arg.Repair {
+				finding.Repaired, err = s.repairAccountBalance(ctx, m.ID)
+				if err != nil {
+					return res, err
+				}
+			}
+			if err := record(finding); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	for afterID := int64(0); afterID < bounds.MaxJournalID; afterID += reconcileBatchSize {
+		journals, err := s.ListUnbalancedJournals(ctx, ListUnbalancedJournalsParams{
+			AfterID:   afterID,
+			BatchSize: reconcileBatchSize,
+		})
+		if err != nil {
+			return res, err
+		}
+		for _, j := range journals {
+			err := record(CreateReconciliationFindingParams{
+				Kind:      FindingUnbalancedJournal,
+				JournalID: sql.NullInt64{Int64: j.JournalID, Valid: true},
+				Currency:  j.Currency,
+				Actual:    j.Total,
+			})
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+
+	res.Run, err = s.FinishReconciliationRun(ctx, FinishReconciliationRunParams{
+		ID:            run.ID,
+		LastAccountID: bounds.MaxAccountID,
+		LastJournalID: bounds.MaxJournalID,
+		Findings:      int64(len(res.Findings)),
+	})
+	return res, err
+}
+
+// repairAccountBalance sets the balance of the account to the sum of its entries. The account is
+// locked before the entries are summed, so postings committed meanwhile are taken into account.
+// It tells whether the balance had to change.
+func (s *SQLStore) repairAccountBalance(ctx context.Context, accountID int64) (bool, error) {
+	repaired := false
+	err := s.execTx(ctx, func(q *Queries) error {
+		acc, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		total, err := q.SumAccountEntries(ctx, accountID)
+		if err != nil || total == acc.Balance {
+			return err
+		}
+
+		_, err = q.UpdateAccount(ctx, UpdateAccountParams{
+			ID:      accountID,
+			Balance: total,
+		})
+		repaired = err == nil
+		return err
+	})
+	return repaired, err
+}
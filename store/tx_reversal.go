@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// JournalReversal is the kind of the journals posted by ReverseTransferTx.
+const JournalReversal = "reversal"
+
+// Statuses of a transfer, derived from the reversed amount.
+const (
+	TransferCompleted         = "completed"
+	TransferPartiallyReversed = "partially_reversed"
+	TransferReversed          = "reversed"
+)
+
+var (
+	ErrTransferIsReversal    = errors.New("a reversal cannot be reversed")
+	ErrTransferNotReversible = errors.New("only transfers between accounts of the same currency can be reversed")
+	ErrReversalExceeded      = errors.New("amount exceeds the part of the transfer not yet reversed")
+)
+
+// TransferStatus tells whether a transfer was reversed, in full or in part.
+func TransferStatus(t Transfer) string {
+	switch {
+	case t.ReversedAmount == 0:
+		return TransferCompleted
+	case t.ReversedAmount < t.Amount:
+		return TransferPartiallyReversed
+	default:
+		return TransferReversed
+	}
+}
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	Amount     int64 `json:"amount"`
+}
+type ReverseTransferTxResult struct {
+	// Original is the reversed transfer with its updated reversed amount
+	Original Transfer `json:"original"`
+	TransferTxResult
+}
+
+// ReverseTransferTx moves amount of a transfer back to its source account with a transfer of its
+// own that references the original. A transfer can be reversed in several parts up to its amount.
+func (s *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var res ReverseTransferTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		if original.ReversalOf.Valid {
+			return ErrTransferIsReversal
+		}
+		if arg.Amount > original.Amount-original.ReversedAmount {
+			return ErrReversalExceeded
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			Kind:      JournalReversal,
+			Reference: strconv.FormatInt(original.ID, 10),
+			Postings: []Posting{
+				{AccountID: original.ToAccountID, Amount: -arg.Amount},
+				{AccountID: original.FromAccountID, Amount: arg.Amount},
+			},
+		})
+		if err != nil {
+			// the journal only balances when both accounts use the same currency
+			if errors.Is(err, ErrJournalUnbalanced) {
+				return ErrTransferNotReversible
+			}
+			return err
+		}
+		res.FromEntry = journal.Entries[0]
+		res.ToEntry = journal.Entries[1]
+		res.FromAccount = journal.Accounts[original.ToAccountID]
+		res.ToAccount = journal.Accounts[original.FromAccountID]
+		if !hasFunds(res.FromAccount) {
+			return ErrInsufficientFunds
+		}
+
+		res.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        arg.Amount,
+			JournalID:     sql.NullInt64{Int64: journal.Journal.ID, Valid: true},
+			ReversalOf:    sql.NullInt64{Int64: original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		res.Original, err = q.AddTransferReversedAmount(ctx, AddTransferReversedAmountParams{
+			ID:     original.ID,
+			Amount: arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		err = q.EmitEvent(ctx, transferCompletedEvent(TransferTxResult{
+			Transfer:    res.Transfer,
+			FromAccount: res.FromAccount,
+			ToAccount:   res.ToAccount,
+			ToEntry:     res.ToEntry,
+		}))
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, q, AuditRecord{
+			Action:     "reverse_transfer",
+			EntityType: "transfer",
+			EntityID:   original.ID,
+			Before:     original,
+			After:      res.Original,
+		})
+	})
+	if err != nil {
+		return ReverseTransferTxResult{}, err
+	}
+	return res, nil
+}
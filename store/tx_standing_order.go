@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+)
+
+// Statuses of a standing order.
+const (
+	StandingOrderActive    = "active"
+	StandingOrderPaused    = "paused"
+	StandingOrderCancelled = "cancelled"
+	StandingOrderCompleted = "completed"
+)
+
+// Statuses of a standing order run.
+const (
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+)
+
+type ExecuteStandingOrderTxParams struct {
+	ID int64 `json:"id"`
+	// ScheduledAt is the next_run_at the execution was enqueued for
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+type ExecuteStandingOrderTxResult struct {
+	Order StandingOrder    `json:"order"`
+	Run   StandingOrderRun `json:"run"`
+	// Skipped is set when the run was already executed or the order is no longer active
+	Skipped bool `json:"skipped"`
+}
+
+// ExecuteStandingOrderTx runs the transfer of a standing order once for the scheduled time.
+// Executing the same run again is a no-op, so it's safe to retry. When the transfer fails
+// for a business reason the failure is recorded and the order moves on to its next run.
+func (s *SQLStore) ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error) {
+	var res ExecuteStandingOrderTxResult
+	err := s.execTx(ctx, func(q *Queries) error {
+		order, due, err := lockDueStandingOrder(ctx, q, arg)
+		if err != nil || !due {
+			res.Order, res.Skipped = order, true
+			return err
+		}
+
+		transfer, err := bookTransfer(ctx, q, TransferTxParams{
+			FromAccountID: order.FromAccountID,
+			ToAccountID:   order.ToAccountID,
+			Amount:        order.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		res.Order, res.Run, err = finishStandingOrderRun(ctx, q, order, arg.ScheduledAt, transfer.Transfer.ID, nil)
+		return err
+	})
+	if err == nil || !isTransferFailure(err) {
+		return res, err
+	}
+
+	// the transfer was rolled back, record the failure in a transaction of its own
+	runErr := err
+	res = ExecuteStandingOrderTxResult{}
+	err = s.execTx(ctx, func(q *Queries) error {
+		order, due, err := lockDueStandingOrder(ctx, q, arg)
+		if err != nil || !due {
+			res.Order, res.Skipped = order, true
+			return err
+		}
+		res.Order, res.Run, err = finishStandingOrderRun(ctx, q, order, arg.ScheduledAt, 0, runErr)
+		return err
+	})
+	return res, err
+}
+
+func lockDueStandingOrder(ctx context.Context, q *Queries, arg ExecuteStandingOrderTxParams) (StandingOrder, bool, error) {
+	order, err := q.GetStandingOrderForUpdate(ctx, arg.ID)
+	if err != nil {
+		return order, false, err
+	}
+	due := order.Status == StandingOrderActive &&
+		order.NextRunAt.Valid &&
+		order.NextRunAt.Time.Equal(arg.ScheduledAt)
+	return order, due, nil
+}
+
+// finishStandingOrderRun records the run and schedules the next one, or completes the order
+// once it reached its end date or maximum number of executions.
+func finishStandingOrderRun(ctx context.Context, q *Queries, order StandingOrder, scheduledAt time.Time, transferID int64, runErr error) (StandingOrder, StandingOrderRun, error) {
+	runArg := CreateStandingOrderRunParams{
+		StandingOrderID: order.ID,
+		ScheduledAt:     scheduledAt,
+		Status:          RunSucceeded,
+		TransferID:      sql.NullInt64{Int64: transferID, Valid: runErr == nil},
+	}
+	executions := order.Executions
+	if runErr != nil {
+		runArg.Status = RunFailed
+		runArg.Error = runErr.Error()
+	} else {
+		executions++
+	}
+	run, err := q.CreateStandingOrderRun(ctx, runArg)
+	if err != nil {
+		return order, run, err
+	}
+
+	schedule, err := util.ParseSchedule(order.Schedule)
+	if err != nil {
+		return order, run, err
+	}
+	next := util.NextRun(schedule, scheduledAt, time.Now())
+
+	advance := AdvanceStandingOrderParams{
+		ID:         order.ID,
+		Status:     StandingOrderActive,
+		Executions: executions,
+		NextRunAt:  sql.NullTime{Time: next, Valid: true},
+	}
+	if (order.MaxExecutions.Valid && executions >= order.MaxExecutions.Int32) ||
+		(order.EndAt.Valid && next.After(order.EndAt.Time)) {
+		advance.Status = StandingOrderCompleted
+		advance.NextRunAt = sql.NullTime{}
+	}
+	order, err = q.AdvanceStandingOrder(ctx, advance)
+	return order, run, err
+}
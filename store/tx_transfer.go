@@ -2,13 +2,26 @@ package store
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQuoteNotFound = errors.New("fx quote not found")
+	ErrQuoteExpired  = errors.New("fx quote expired")
+	ErrQuoteUsed     = errors.New("fx quote already used")
+	ErrQuoteMismatch = errors.New("fx quote does not match the transfer")
 )
 
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	Amount        int64 `json:"amount"`
+	// QuoteID is set for cross-currency transfers, the quote decides the credited amount
+	QuoteID uuid.NullUUID `json:"quote_id"`
 }
 type TransferTxResult struct {
 	Transfer    Transfer `json:"transfer"`
@@ -16,72 +29,189 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	Quote       *FxQuote `json:"quote,omitempty"`
+	FxEntries   []Entry  `json:"fx_entries,omitempty"`
+	// FeeEntries debit the fee from the sender and credit it to the fee income account
+	FeeEntries []Entry `json:"fee_entries,omitempty"`
 }
 
-var txKey = struct{}{}
-
 func (s *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var res TransferTxResult
 	err := s.execTx(ctx, func(q *Queries) error {
 		var err error
+		res, err = bookTransfer(ctx, q, arg)
+		return err
+	})
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	return res, err
+}
 
-		txName := ctx.Value(txKey)
-		fmt.Println(txName)
-		res.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams(arg))
-		if err != nil {
-			return err
+// bookTransfer checks the limits of the sender, books the transfer with its fee inside an
+// existing transaction and audits it. It fails with ErrInsufficientFunds when the source
+// account can't cover the transfer.
+func bookTransfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	from, err := lookupAccount(ctx, q, arg.FromAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	if err := checkTransferLimits(ctx, q, from, arg.Amount, time.Now()); err != nil {
+		return TransferTxResult{}, err
+	}
+	fee, err := feeFor(ctx, q, from, arg.Amount)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	res, err := transferTx(ctx, q, arg, fee)
+	if err == nil && !hasFunds(res.FromAccount) {
+		err = ErrInsufficientFunds
+	}
+	if err != nil {
+		return res, err
+	}
+	return res, recordAudit(ctx, q, AuditRecord{
+		Action:     "create_transfer",
+		EntityType: "transfer",
+		EntityID:   res.Transfer.ID,
+		After:      res.Transfer,
+	})
+}
+
+// isTransferFailure tells errors the customer has to fix apart from errors worth a retry.
+func isTransferFailure(err error) bool {
+	return errors.Is(err, ErrInsufficientFunds) ||
+		errors.Is(err, ErrLimitExceeded) ||
+		errors.Is(err, ErrAccountFrozen) ||
+		errors.Is(err, ErrAccountClosed) ||
+		errors.Is(err, ErrJournalUnbalanced) ||
+		errors.Is(err, ErrAccountNotFound)
+}
+
+// transferTx books the transfer and its fee inside an existing transaction and emits the
+// TransferCompleted event.
+func transferTx(ctx context.Context, q *Queries, arg TransferTxParams, fee transferFee) (TransferTxResult, error) {
+	var res TransferTxResult
+	var err error
+	if arg.QuoteID.Valid {
+		err = fxTransfer(ctx, q, arg, fee, &res)
+	} else {
+		err = sameCurrencyTransfer(ctx, q, arg, fee, &res)
+	}
+	if err != nil {
+		return res, err
+	}
+	return res, q.EmitEvent(ctx, transferCompletedEvent(res))
+}
+
+// sameCurrencyTransfer moves money between two accounts of the same currency.
+func sameCurrencyTransfer(ctx context.Context, q *Queries, arg TransferTxParams, fee transferFee, res *TransferTxResult) error {
+	journal, err := postJournal(ctx, q, PostJournalParams{
+		Kind: JournalTransfer,
+		Postings: append([]Posting{
+			{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+			{AccountID: arg.ToAccountID, Amount: arg.Amount},
+		}, fee.postings(arg.FromAccountID)...),
+	})
+	if err != nil {
+		return err
+	}
+	res.FromEntry = journal.Entries[0]
+	res.ToEntry = journal.Entries[1]
+	if fee.amount != 0 {
+		res.FeeEntries = journal.Entries[2:]
+	}
+	res.FromAccount = journal.Accounts[arg.FromAccountID]
+	res.ToAccount = journal.Accounts[arg.ToAccountID]
+
+	res.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		JournalID:     sql.NullInt64{Int64: journal.Journal.ID, Valid: true},
+		Fee:           fee.amount,
+	})
+	return err
+}
+
+// fxTransfer moves money between accounts in different currencies through the FX house accounts.
+// The debited amount goes into the house account of the source currency, the house account of the
+// target currency pays out at the mid rate and the spread is credited to the FX income account.
+// The fee is charged in the source currency on top of the quoted amount.
+func fxTransfer(ctx context.Context, q *Queries, arg TransferTxParams, fee transferFee, res *TransferTxResult) error {
+	quote, err := q.GetFxQuoteForUpdate(ctx, arg.QuoteID.UUID)
+	if err != nil {
+		if err == ErrRecordNotFound {
+			return ErrQuoteNotFound
 		}
+		return err
+	}
+	if quote.TransferID.Valid {
+		return ErrQuoteUsed
+	}
+	if time.Now().After(quote.ExpiresAt) {
+		return ErrQuoteExpired
+	}
+	if quote.FromAccountID != arg.FromAccountID || quote.ToAccountID != arg.ToAccountID || quote.Amount != arg.Amount {
+		return ErrQuoteMismatch
+	}
 
-		res.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+	postings := []Posting{
+		{AccountID: quote.FromAccountID, Amount: -quote.Amount},
+		{AccountID: quote.HouseFromAccountID, Amount: quote.Amount},
+		{AccountID: quote.HouseToAccountID, Amount: -(quote.ConvertedAmount + quote.SpreadAmount)},
+	}
+	// the house pays out at the mid rate, the spread is the income of the bank
+	if quote.SpreadAmount != 0 {
+		to, err := lookupAccount(ctx, q, quote.ToAccountID)
 		if err != nil {
 			return err
 		}
-		res.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		income, err := SystemAccount(ctx, q, SystemFxIncome, to.Currency)
 		if err != nil {
 			return err
 		}
+		postings = append(postings, Posting{AccountID: income.ID, Amount: quote.SpreadAmount})
+	}
+	toIndex := len(postings)
+	postings = append(postings, Posting{AccountID: quote.ToAccountID, Amount: quote.ConvertedAmount})
 
-		if arg.FromAccountID < arg.ToAccountID {
-
-			res.FromAccount, res.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			res.ToAccount, res.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
-			}
-		}
-
-		return nil
+	journal, err := postJournal(ctx, q, PostJournalParams{
+		Kind:      JournalFxTransfer,
+		Reference: quote.ID.String(),
+		Postings:  append(postings, fee.postings(quote.FromAccountID)...),
 	})
 	if err != nil {
-		return TransferTxResult{}, err
+		return err
 	}
-	return res, err
-}
+	res.FromEntry = journal.Entries[0]
+	res.FxEntries = journal.Entries[1:toIndex]
+	res.ToEntry = journal.Entries[toIndex]
+	if fee.amount != 0 {
+		res.FeeEntries = journal.Entries[toIndex+1:]
+	}
+	res.FromAccount = journal.Accounts[quote.FromAccountID]
+	res.ToAccount = journal.Accounts[quote.ToAccountID]
 
-func addMoney(ctx context.Context, q *Queries, accID1 int64, amount1 int64, accID2 int64, amount2 int64) (acc1 Account, acc2 Account, err error) {
-	acc1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accID1,
-		Amount: amount1,
+	res.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		JournalID:     sql.NullInt64{Int64: journal.Journal.ID, Valid: true},
+		Fee:           fee.amount,
 	})
 	if err != nil {
-		return
+		return err
 	}
-	acc2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accID2,
-		Amount: amount2,
+
+	quote, err = q.SetFxQuoteTransfer(ctx, SetFxQuoteTransferParams{
+		TransferID: sql.NullInt64{Int64: res.Transfer.ID, Valid: true},
+		ID:         quote.ID,
 	})
 	if err != nil {
-		return
+		return err
 	}
-	return
+	res.Quote = &quote
+	return nil
 }
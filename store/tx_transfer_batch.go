@@ -0,0 +1,237 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Modes of a transfer batch. An atomic batch books all of its transfers or none, a best effort
+// batch books every transfer that can be booked.
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+// Statuses of a transfer batch.
+const (
+	BatchPending            = "pending"
+	BatchCompleted          = "completed"
+	BatchPartiallyCompleted = "partially_completed"
+	BatchFailed             = "failed"
+)
+
+// Statuses of a transfer batch item.
+const (
+	BatchItemPending   = "pending"
+	BatchItemSucceeded = "succeeded"
+	BatchItemFailed    = "failed"
+)
+
+type TransferBatchItemParams struct {
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+}
+
+// CreateTransferBatchWithItems stores a pending batch with its items in request order.
+func (q *Queries) CreateTransferBatchWithItems(ctx context.Context, arg CreateTransferBatchParams, items []TransferBatchItemParams) (TransferBatch, []TransferBatchItem, error) {
+	arg.ItemCount = int32(len(items))
+	batch, err := q.CreateTransferBatch(ctx, arg)
+	if err != nil {
+		return batch, nil, err
+	}
+
+	created := make([]TransferBatchItem, 0, len(items))
+	for i, item := range items {
+		batchItem, err := q.CreateTransferBatchItem(ctx, CreateTransferBatchItemParams{
+			BatchID:     batch.ID,
+			Position:    int32(i),
+			ToAccountID: item.ToAccountID,
+			Amount:      item.Amount,
+		})
+		if err != nil {
+			return batch, nil, err
+		}
+		created = append(created, batchItem)
+	}
+	return batch, created, nil
+}
+
+type ExecuteTransferBatchTxResult struct {
+	Batch TransferBatch `json:"batch"`
+	// Skipped is set when the batch was already executed
+	Skipped bool `json:"skipped"`
+}
+
+// ExecuteTransferBatchTx books the transfers of a pending batch and records the result of
+// every item. Items that fail for a business reason are recorded as failed, other errors are
+// returned and executing the batch again picks up where it stopped.
+func (s *SQLStore) ExecuteTransferBatchTx(ctx context.Context, id int64) (ExecuteTransferBatchTxResult, error) {
+	batch, err := s.GetTransferBatch(ctx, id)
+	if err != nil {
+		return ExecuteTransferBatchTxResult{}, err
+	}
+	if batch.Status != BatchPending {
+		return ExecuteTransferBatchTxResult{Batch: batch, Skipped: true}, nil
+	}
+	if batch.Mode == BatchAtomic {
+		return s.executeAtomicBatch(ctx, batch.ID)
+	}
+	return s.executeBestEffortBatch(ctx, batch.ID)
+}
+
+func (s *SQLStore) executeAtomicBatch(ctx context.Context, id int64) (ExecuteTransferBatchTxResult, error) {
+	var res ExecuteTransferBatchTxResult
+	var failed TransferBatchItem
+	err := s.execTx(ctx, func(q *Queries) error {
+		batch, err := q.GetTransferBatchForUpdate(ctx, id)
+		if err != nil || batch.Status != BatchPending {
+			res.Batch, res.Skipped = batch, true
+			return err
+		}
+		items, err := q.ListPendingTransferBatchItems(ctx, batch.ID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := bookBatchItem(ctx, q, batch, item); err != nil {
+				failed = item
+				return err
+			}
+		}
+		res.Batch, err = closeTransferBatch(ctx, q, batch)
+		return err
+	})
+	if err == nil || !isTransferFailure(err) {
+		return res, err
+	}
+
+	// the transfers were rolled back, record the failure in a transaction of its own
+	itemErr := err
+	res = ExecuteTransferBatchTxResult{}
+	err = s.execTx(ctx, func(q *Queries) error {
+		batch, err := q.GetTransferBatchForUpdate(ctx, id)
+		if err != nil || batch.Status != BatchPending {
+			res.Batch, res.Skipped = batch, true
+			return err
+		}
+		_, err = q.SetTransferBatchItemResult(ctx, SetTransferBatchItemResultParams{
+			ID:     failed.ID,
+			Status: BatchItemFailed,
+			Error:  itemErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+		err = q.FailPendingTransferBatchItems(ctx, FailPendingTransferBatchItemsParams{
+			BatchID: batch.ID,
+			Error:   fmt.Sprintf("rolled back, item %d failed", failed.Position),
+		})
+		if err != nil {
+			return err
+		}
+		res.Batch, err = closeTransferBatch(ctx, q, batch)
+		return err
+	})
+	return res, err
+}
+
+func (s *SQLStore) executeBestEffortBatch(ctx context.Context, id int64) (ExecuteTransferBatchTxResult, error) {
+	items, err := s.ListPendingTransferBatchItems(ctx, id)
+	if err != nil {
+		return ExecuteTransferBatchTxResult{}, err
+	}
+	for _, item := range items {
+		if err := s.executeBatchItem(ctx, id, item.ID); err != nil {
+			return ExecuteTransferBatchTxResult{}, err
+		}
+	}
+
+	var res ExecuteTransferBatchTxResult
+	err = s.execTx(ctx, func(q *Queries) error {
+		batch, err := q.GetTransferBatchForUpdate(ctx, id)
+		if err != nil || batch.Status != BatchPending {
+			res.Batch, res.Skipped = batch, true
+			return err
+		}
+		res.Batch, err = closeTransferBatch(ctx, q, batch)
+		return err
+	})
+	return res, err
+}
+
+// executeBatchItem books one item of a best effort batch in a transaction of its own.
+func (s *SQLStore) executeBatchItem(ctx context.Context, batchID int64, itemID int64) error {
+	err := s.execTx(ctx, func(q *Queries) error {
+		batch, item, pending, err := lockPendingBatchItem(ctx, q, batchID, itemID)
+		if err != nil || !pending {
+			return err
+		}
+		return bookBatchItem(ctx, q, batch, item)
+	})
+	if err == nil || !isTransferFailure(err) {
+		return err
+	}
+
+	itemErr := err
+	return s.execTx(ctx, func(q *Queries) error {
+		_, item, pending, err := lockPendingBatchItem(ctx, q, batchID, itemID)
+		if err != nil || !pending {
+			return err
+		}
+		_, err = q.SetTransferBatchItemResult(ctx, SetTransferBatchItemResultParams{
+			ID:     item.ID,
+			Status: BatchItemFailed,
+			Error:  itemErr.Error(),
+		})
+		return err
+	})
+}
+
+func lockPendingBatchItem(ctx context.Context, q *Queries, batchID int64, itemID int64) (TransferBatch, TransferBatchItem, bool, error) {
+	item, err := q.GetTransferBatchItemForUpdate(ctx, itemID)
+	if err != nil || item.Status != BatchItemPending {
+		return TransferBatch{}, item, false, err
+	}
+	batch, err := q.GetTransferBatch(ctx, batchID)
+	return batch, item, err == nil, err
+}
+
+// bookBatchItem books the transfer of an item and marks it succeeded.
+func bookBatchItem(ctx context.Context, q *Queries, batch TransferBatch, item TransferBatchItem) error {
+	transfer, err := bookTransfer(ctx, q, TransferTxParams{
+		FromAccountID: batch.FromAccountID,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+	})
+	if err != nil {
+		return fmt.Errorf("item %d: %w", item.Position, err)
+	}
+	_, err = q.SetTransferBatchItemResult(ctx, SetTransferBatchItemResultParams{
+		ID:         item.ID,
+		Status:     BatchItemSucceeded,
+		TransferID: sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true},
+	})
+	return err
+}
+
+// closeTransferBatch counts the results of the items and closes the batch.
+func closeTransferBatch(ctx context.Context, q *Queries, batch TransferBatch) (TransferBatch, error) {
+	counts, err := q.CountTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return batch, err
+	}
+	status := BatchPartiallyCompleted
+	switch {
+	case counts.Failed == 0:
+		status = BatchCompleted
+	case counts.Succeeded == 0:
+		status = BatchFailed
+	}
+	return q.FinishTransferBatch(ctx, FinishTransferBatchParams{
+		ID:             batch.ID,
+		Status:         status,
+		SucceededCount: counts.Succeeded,
+		FailedCount:    counts.Failed,
+	})
+}
@@ -29,6 +29,7 @@ func createRandomUser(t *testing.T) User {
 
 	require.NotZero(t, user.CreatedAt)
 	require.True(t, user.PasswordChangedAt.IsZero())
+	require.Equal(t, "standard", user.Tier)
 
 	return user
 }
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Event types of the webhooks. Users filter their endpoints on these.
+const (
+	WebhookTransferReceived = "transfer.received"
+	WebhookTransferSent     = "transfer.sent"
+	WebhookAccountCreated   = "account.created"
+	WebhookUserUpdated      = "user.updated"
+)
+
+// WebhookEventTypes lists every webhook event type.
+var WebhookEventTypes = []string{
+	WebhookTransferReceived,
+	WebhookTransferSent,
+	WebhookAccountCreated,
+	WebhookUserUpdated,
+}
+
+// Statuses of a webhook delivery.
+const (
+	WebhookPending   = "pending"
+	WebhookSucceeded = "succeeded"
+	WebhookFailed    = "failed"
+)
+
+// WebhookSecretOverlap is how long a rotated secret keeps signing deliveries, so receivers can
+// switch to the new secret without rejecting requests.
+const WebhookSecretOverlap = 24 * time.Hour
+
+// webhookBody is the JSON body posted to the endpoints.
+type webhookBody struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// webhookTarget is a user to notify about an event.
+type webhookTarget struct {
+	owner     string
+	eventType string
+}
+
+// webhookTargets returns who is notified about event and the data sent to them.
+func webhookTargets(ctx context.Context, q *Queries, event *pb.DomainEvent) ([]webhookTarget, proto.Message, error) {
+	switch payload := event.Payload.(type) {
+	case *pb.DomainEvent_TransferCompleted:
+		transfer := payload.TransferCompleted
+		from, err := q.GetAccount(ctx, transfer.FromAccountId)
+		if err != nil {
+			return nil, nil, err
+		}
+		to, err := q.GetAccount(ctx, transfer.ToAccountId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []webhookTarget{
+			{owner: to.Owner, eventType: WebhookTransferReceived},
+			{owner: from.Owner, eventType: WebhookTransferSent},
+		}, transfer, nil
+	case *pb.DomainEvent_AccountCreated:
+		return []webhookTarget{{owner: payload.AccountCreated.Owner, eventType: WebhookAccountCreated}}, payload.AccountCreated, nil
+	case *pb.DomainEvent_UserUpdated:
+		return []webhookTarget{{owner: payload.UserUpdated.Username, eventType: WebhookUserUpdated}}, payload.UserUpdated, nil
+	}
+	return nil, nil, nil
+}
+
+// CreateWebhookDeliveries creates a pending delivery for every endpoint that listens to event.
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, event *pb.DomainEvent) ([]WebhookDelivery, error) {
+	targets, data, err := webhookTargets(ctx, q, event)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+	eventID, err := uuid.Parse(event.Id)
+	if err != nil {
+		return nil, err
+	}
+	dataJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []WebhookDelivery
+	for _, target := range targets {
+		endpoints, err := q.ListWebhookEndpointsForEvent(ctx, ListWebhookEndpointsForEventParams{
+			Owner:     target.owner,
+			EventType: target.eventType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(endpoints) == 0 {
+			continue
+		}
+
+		body, err := json.Marshal(webhookBody{
+			ID:        event.Id,
+			Type:      target.eventType,
+			CreatedAt: event.OccurredAt.AsTime(),
+			Data:      dataJSON,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range endpoints {
+			delivery, err := q.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+				EndpointID: endpoint.ID,
+				EventID:    eventID,
+				EventType:  target.eventType,
+				Payload:    body,
+			})
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+// WebhookSecrets returns the secrets that sign deliveries to endpoint at now, newest first.
+func WebhookSecrets(endpoint WebhookEndpoint, now time.Time) []string {
+	secrets := []string{endpoint.Secret}
+	if endpoint.PreviousSecret != "" && endpoint.PreviousSecretExpiresAt.Valid && now.Before(endpoint.PreviousSecretExpiresAt.Time) {
+		secrets = append(secrets, endpoint.PreviousSecret)
+	}
+	return secrets
+}
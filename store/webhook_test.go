@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, owner string, eventTypes ...string) WebhookEndpoint {
+	secret, err := util.NewWebhookSecret()
+	require.NoError(t, err)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	endpoint, err := testQueries.CreateWebhookEndpoint(context.Background(), CreateWebhookEndpointParams{
+		Owner:      owner,
+		Url:        "https://example.com/hooks",
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	require.NoError(t, err)
+	require.Equal(t, secret, endpoint.Secret)
+	require.Empty(t, endpoint.PreviousSecret)
+	return endpoint
+}
+
+func TestCreateWebhookDeliveries(t *testing.T) {
+	acc1 := fundAccount(t, createRandomAccountWithCurrency(t, util.USD), 1000)
+	acc2 := createRandomAccountWithCurrency(t, util.USD)
+	sender := createRandomWebhookEndpoint(t, acc1.Owner)
+	receiver := createRandomWebhookEndpoint(t, acc2.Owner, WebhookTransferReceived)
+	// filtered out
+	createRandomWebhookEndpoint(t, acc2.Owner, WebhookUserUpdated)
+
+	event := transferCompletedEvent(TransferTxResult{
+		Transfer:    Transfer{ID: util.RandomInt(1, 1000), FromAccountID: acc1.ID, ToAccountID: acc2.ID, Amount: 10},
+		FromAccount: acc1,
+		ToAccount:   acc2,
+		ToEntry:     Entry{Amount: 10},
+	})
+	event.Id = uuid.NewString()
+	event.OccurredAt = timestamppb.Now()
+	deliveries, err := testQueries.CreateWebhookDeliveries(context.Background(), event)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	byEndpoint := map[int64]WebhookDelivery{}
+	for _, delivery := range deliveries {
+		require.Equal(t, WebhookPending, delivery.Status)
+		require.Equal(t, event.Id, delivery.EventID.String())
+		byEndpoint[delivery.EndpointID] = delivery
+	}
+	require.Equal(t, WebhookTransferSent, byEndpoint[sender.ID].EventType)
+	require.Equal(t, WebhookTransferReceived, byEndpoint[receiver.ID].EventType)
+
+	var body webhookBody
+	require.NoError(t, json.Unmarshal(byEndpoint[receiver.ID].Payload, &body))
+	require.Equal(t, event.Id, body.ID)
+	require.Equal(t, WebhookTransferReceived, body.Type)
+	require.Contains(t, string(body.Data), `"to_account_id"`)
+}
+
+func TestRotateWebhookSecret(t *testing.T) {
+	endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).Username)
+	require.Equal(t, []string{endpoint.Secret}, WebhookSecrets(endpoint, time.Now()))
+
+	expiresAt := time.Now().Add(WebhookSecretOverlap)
+	rotated, err := testQueries.RotateWebhookSecret(context.Background(), RotateWebhookSecretParams{
+		PreviousSecretExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+		Secret:                  "whsec_rotated",
+		ID:                      endpoint.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "whsec_rotated", rotated.Secret)
+	require.Equal(t, endpoint.Secret, rotated.PreviousSecret)
+
+	require.Equal(t, []string{"whsec_rotated", endpoint.Secret}, WebhookSecrets(rotated, time.Now()))
+	require.Equal(t, []string{"whsec_rotated"}, WebhookSecrets(rotated, expiresAt.Add(time.Second)))
+}
@@ -23,17 +23,19 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 
 type MyCustomClaims struct {
 	Username string `json:"username"`
+	Role     string `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", nil, err
 	}
 
 	claims := MyCustomClaims{
 		payload.Username,
+		payload.Role,
 		jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
 			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
@@ -70,6 +72,7 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 	}
 	return &Payload{
 		Username:  payload.Username,
+		Role:      payload.Role,
 		IssuedAt:  payload.IssuedAt.Time,
 		ExpiredAt: payload.ExpiresAt.Time,
 	}, nil
@@ -7,8 +7,13 @@ import (
 )
 
 type Config struct {
-	DbDriver             string        `mapstructure:"DB_DRIVER"`
 	DbSource             string        `mapstructure:"DB_SOURCE"`
+	DbReplicaSource      string        `mapstructure:"DB_REPLICA_SOURCE"`
+	DbMaxConns           int32         `mapstructure:"DB_MAX_CONNS"`
+	DbMinConns           int32         `mapstructure:"DB_MIN_CONNS"`
+	DbMaxConnLifetime    time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME"`
+	DbMaxConnIdleTime    time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
+	DbStatementCacheMode string        `mapstructure:"DB_STATEMENT_CACHE_MODE"`
 	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
 	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
@@ -16,6 +21,14 @@ type Config struct {
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
 	Environment          string        `mapstructure:"ENVIRONMNET"`
 	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
+	FxQuoteDuration      time.Duration `mapstructure:"FX_QUOTE_DURATION"`
+	FxRatesFile          string        `mapstructure:"FX_RATES_FILE"`
+	HoldDuration         time.Duration `mapstructure:"HOLD_DURATION"`
+	ReversalWindow       time.Duration `mapstructure:"REVERSAL_WINDOW"`
+	IdempotencyKeyTTL    time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	EventSink            string        `mapstructure:"EVENT_SINK"`
+	EventStream          string        `mapstructure:"EVENT_STREAM"`
+	EventFile            string        `mapstructure:"EVENT_FILE"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,14 +1,59 @@
 package util
 
+import "sync"
+
 const (
 	USD = "USD"
 	INR = "INR"
 )
 
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, INR:
-		return true
+type Currency struct {
+	Code       string
+	MinorUnits int32
+	Symbol     string
+	Enabled    bool
+}
+
+// CurrencyRegistry is an in-memory cache of the currencies table.
+type CurrencyRegistry struct {
+	mu         sync.RWMutex
+	currencies map[string]Currency
+}
+
+func NewCurrencyRegistry(currencies ...Currency) *CurrencyRegistry {
+	r := &CurrencyRegistry{}
+	r.Set(currencies)
+	return r
+}
+
+// Set replaces every cached currency with the given ones.
+func (r *CurrencyRegistry) Set(currencies []Currency) {
+	m := make(map[string]Currency, len(currencies))
+	for _, c := range currencies {
+		m[c.Code] = c
 	}
-	return false
+	r.mu.Lock()
+	r.currencies = m
+	r.mu.Unlock()
+}
+
+func (r *CurrencyRegistry) Lookup(code string) (Currency, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.currencies[code]
+	return c, ok
+}
+
+// Currencies is the registry used by the validators. It starts with the
+// currencies seeded by the migrations and is refreshed from the database.
+var Currencies = NewCurrencyRegistry(
+	Currency{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true},
+	Currency{Code: INR, MinorUnits: 2, Symbol: "₹", Enabled: true},
+)
+
+// IsSupportedCurrency reports whether new accounts and transfers may use the currency.
+// Disabled currencies stay in the registry so existing balances can still be formatted.
+func IsSupportedCurrency(currency string) bool {
+	c, ok := Currencies.Lookup(currency)
+	return ok && c.Enabled
 }
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyRegistry(t *testing.T) {
+	registry := NewCurrencyRegistry(Currency{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true})
+
+	c, ok := registry.Lookup(USD)
+	require.True(t, ok)
+	require.Equal(t, int32(2), c.MinorUnits)
+
+	_, ok = registry.Lookup(INR)
+	require.False(t, ok)
+
+	registry.Set([]Currency{{Code: INR, MinorUnits: 2, Symbol: "₹", Enabled: true}})
+	_, ok = registry.Lookup(USD)
+	require.False(t, ok)
+	_, ok = registry.Lookup(INR)
+	require.True(t, ok)
+}
+
+func TestIsSupportedCurrency(t *testing.T) {
+	require.True(t, IsSupportedCurrency(USD))
+	require.True(t, IsSupportedCurrency(INR))
+	require.False(t, IsSupportedCurrency("XYZ"))
+}
+
+func TestDisabledCurrency(t *testing.T) {
+	saved := Currencies
+	defer func() { Currencies = saved }()
+
+	Currencies = NewCurrencyRegistry(Currency{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: false})
+	require.False(t, IsSupportedCurrency("JPY"))
+
+	c, ok := Currencies.Lookup("JPY")
+	require.True(t, ok)
+	require.Equal(t, int32(0), c.MinorUnits)
+}
@@ -0,0 +1,109 @@
+package util
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const basisPoints = 10000
+
+type FxRate struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          string
+	SpreadBps     int32
+}
+
+// ParseRate parses a decimal rate such as "83.125" and rejects zero or negative rates.
+func ParseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return nil, fmt.Errorf("invalid rate %q", rate)
+	}
+	if r.Sign() <= 0 {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+	return r, nil
+}
+
+// QuoteRate takes the spread off a mid market rate, so the customer always gets the worse side.
+func QuoteRate(mid *big.Rat, spreadBps int32) *big.Rat {
+	factor := big.NewRat(int64(basisPoints-spreadBps), basisPoints)
+	return new(big.Rat).Mul(mid, factor)
+}
+
+// BasisPointsOf returns bps basis points of amount, rounded half up to a minor unit.
+func BasisPointsOf(amount int64, bps int32) (int64, error) {
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(bps))),
+		big.NewInt(basisPoints),
+	)
+	return Round(r, RoundHalfUp)
+}
+
+// ConvertAmount converts an amount in minor units of one currency into minor units
+// of another at the given rate. The result is rounded down in favour of the bank.
+func ConvertAmount(amount int64, rate *big.Rat, fromMinorUnits int32, toMinorUnits int32) int64 {
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	v.Mul(v, scale(toMinorUnits-fromMinorUnits))
+	return new(big.Int).Quo(v.Num(), v.Denom()).Int64()
+}
+
+func scale(exp int32) *big.Rat {
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+}
+
+// ReadFxRates loads rates from a csv file with base_currency,quote_currency,rate,spread_bps rows.
+// Blank lines and lines starting with # are skipped.
+func ReadFxRates(path string) ([]FxRate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rates []FxRate
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("invalid fx rate row %v", record)
+		}
+
+		rate := FxRate{
+			BaseCurrency:  strings.ToUpper(strings.TrimSpace(record[0])),
+			QuoteCurrency: strings.ToUpper(strings.TrimSpace(record[1])),
+			Rate:          strings.TrimSpace(record[2]),
+		}
+		if _, err := ParseRate(rate.Rate); err != nil {
+			return nil, err
+		}
+		if len(record) == 4 {
+			bps, err := strconv.ParseInt(strings.TrimSpace(record[3]), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid spread %q", record[3])
+			}
+			rate.SpreadBps = int32(bps)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
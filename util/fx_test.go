@@ -0,0 +1,78 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAmount(t *testing.T) {
+	rate, err := ParseRate("83.125")
+	require.NoError(t, err)
+
+	// 10.00 USD -> 831.25 INR
+	require.Equal(t, int64(83125), ConvertAmount(1000, rate, 2, 2))
+	// 10.00 USD -> 831 JPY-like currency without minor units, rounded down
+	require.Equal(t, int64(831), ConvertAmount(1000, rate, 2, 0))
+	// 1000 units without minor units -> 83125.000 with three
+	require.Equal(t, int64(83125000), ConvertAmount(1000, rate, 0, 3))
+}
+
+func TestQuoteRate(t *testing.T) {
+	mid, err := ParseRate("100")
+	require.NoError(t, err)
+
+	quoted := QuoteRate(mid, 50)
+	require.Equal(t, "99.5000", quoted.FloatString(4))
+
+	midAmount := ConvertAmount(1000, mid, 2, 2)
+	quotedAmount := ConvertAmount(1000, quoted, 2, 2)
+	require.Equal(t, int64(500), midAmount-quotedAmount)
+}
+
+func TestParseRate(t *testing.T) {
+	_, err := ParseRate("abc")
+	require.Error(t, err)
+	_, err = ParseRate("0")
+	require.Error(t, err)
+	_, err = ParseRate("-1.5")
+	require.Error(t, err)
+}
+
+func TestReadFxRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	content := "# base,quote,rate,spread_bps\nUSD,INR,83.12,50\n\neur, usd, 1.08\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	rates, err := ReadFxRates(path)
+	require.NoError(t, err)
+	require.Equal(t, []FxRate{
+		{BaseCurrency: USD, QuoteCurrency: INR, Rate: "83.12", SpreadBps: 50},
+		{BaseCurrency: "EUR", QuoteCurrency: USD, Rate: "1.08"},
+	}, rates)
+
+	require.NoError(t, os.WriteFile(path, []byte("USD,INR,zero\n"), 0o600))
+	_, err = ReadFxRates(path)
+	require.Error(t, err)
+}
+
+func TestBasisPointsOf(t *testing.T) {
+	testCases := []struct {
+		amount int64
+		bps    int32
+		want   int64
+	}{
+		{1000, 150, 15},
+		{1010, 150, 15},
+		{100, 50, 1},
+		{-100, 50, -1},
+		{1000, 0, 0},
+	}
+	for _, tc := range testCases {
+		got, err := BasisPointsOf(tc.amount, tc.bps)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "%d bps of %d", tc.bps, tc.amount)
+	}
+}
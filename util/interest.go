@@ -0,0 +1,88 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+// Day count conventions of an interest product, they decide how much of the yearly
+// interest a single day earns.
+const (
+	// DayCountActual365 counts every day as 1/365 of a year, also in leap years.
+	DayCountActual365 = "act/365"
+	// DayCountActual360 counts every day as 1/360 of a year.
+	DayCountActual360 = "act/360"
+	// DayCountActualActual counts every day as 1/365 or 1/366 of a year depending on the year.
+	DayCountActualActual = "act/act"
+	// DayCount30360 counts every month as 30 days and the year as 360 days.
+	DayCount30360 = "30/360"
+)
+
+// MicrosPerUnit is the number of micros in a minor unit. Accrued interest is kept in
+// micros so small balances don't round down to nothing every day.
+const MicrosPerUnit = 1_000_000
+
+// FormatMicros formats an amount in micros of a minor unit of the currency as a decimal string.
+func FormatMicros(micros int64, currency string) string {
+	minorUnits := int32(defaultMinorUnits)
+	if c, ok := Currencies.Lookup(currency); ok {
+		minorUnits = c.MinorUnits
+	}
+	return FormatDecimal(micros, minorUnits+6)
+}
+
+var dayCounts = []string{DayCountActual365, DayCountActual360, DayCountActualActual, DayCount30360}
+
+func IsSupportedDayCount(dayCount string) bool {
+	return slices.Contains(dayCounts, dayCount)
+}
+
+// DayFraction returns the part of a year the day counts for under the day count convention.
+func DayFraction(dayCount string, day time.Time) (*big.Rat, error) {
+	switch dayCount {
+	case DayCountActual365:
+		return big.NewRat(1, 365), nil
+	case DayCountActual360:
+		return big.NewRat(1, 360), nil
+	case DayCountActualActual:
+		return big.NewRat(1, int64(daysInYear(day.Year()))), nil
+	case DayCount30360:
+		return big.NewRat(int64(days30(day)), 360), nil
+	}
+	return nil, fmt.Errorf("unknown day count convention %q", dayCount)
+}
+
+// days30 is the number of days a calendar day stands for when every month has 30 days.
+// The 31st doesn't count and the last day of February makes up for the missing days.
+func days30(day time.Time) int {
+	d := day.Day()
+	if d == 31 {
+		return 0
+	}
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day.Month() == time.February && d == last {
+		return 30 - d + 1
+	}
+	return 1
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// DailyInterestMicros returns the interest balance earns on day at the yearly rate, in
+// micros of a minor unit rounded half up.
+func DailyInterestMicros(balance int64, rateBps int32, dayCount string, day time.Time) (int64, error) {
+	fraction, err := DayFraction(dayCount, day)
+	if err != nil {
+		return 0, err
+	}
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(balance), big.NewInt(int64(rateBps)*MicrosPerUnit)),
+		big.NewInt(basisPoints),
+	)
+	r.Mul(r, fraction)
+	return Round(r, RoundHalfUp)
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDailyInterestMicros(t *testing.T) {
+	day := date(2023, time.March, 15)
+
+	// 3.65% on 1000.00 earns 10 cents a day under act/365
+	amount, err := DailyInterestMicros(100000, 365, DayCountActual365, day)
+	require.NoError(t, err)
+	require.Equal(t, int64(10*MicrosPerUnit), amount)
+
+	// 3.60% under act/360
+	amount, err = DailyInterestMicros(100000, 360, DayCountActual360, day)
+	require.NoError(t, err)
+	require.Equal(t, int64(10*MicrosPerUnit), amount)
+
+	// one cent at 1% still accrues a fraction of a cent
+	amount, err = DailyInterestMicros(1, 100, DayCountActual365, day)
+	require.NoError(t, err)
+	require.Equal(t, int64(27), amount)
+
+	_, err = DailyInterestMicros(100000, 365, "act/365.25", day)
+	require.Error(t, err)
+}
+
+func TestDayFraction(t *testing.T) {
+	f, err := DayFraction(DayCountActualActual, date(2024, time.July, 1))
+	require.NoError(t, err)
+	require.Equal(t, "1/366", f.String())
+
+	f, err = DayFraction(DayCountActualActual, date(2023, time.July, 1))
+	require.NoError(t, err)
+	require.Equal(t, "1/365", f.String())
+}
+
+func TestDays30(t *testing.T) {
+	// every month adds up to 30 days
+	for _, year := range []int{2023, 2024} {
+		for month := time.January; month <= time.December; month++ {
+			total := 0
+			for day := date(year, month, 1); day.Month() == month; day = day.AddDate(0, 0, 1) {
+				total += days30(day)
+			}
+			require.Equal(t, 30, total, "%d-%02d", year, month)
+		}
+	}
+	require.Zero(t, days30(date(2023, time.January, 31)))
+	require.Equal(t, 3, days30(date(2023, time.February, 28)))
+	require.Equal(t, 2, days30(date(2024, time.February, 29)))
+}
+
+func TestFormatMicros(t *testing.T) {
+	require.Equal(t, "0.00000027", FormatMicros(27, USD))
+	require.Equal(t, "0.10000000", FormatMicros(10*MicrosPerUnit, USD))
+}
@@ -0,0 +1,140 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Money is an amount in the minor units of its currency, e.g. cents for USD.
+// The number of minor units per currency comes from the currency registry.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type RoundingMode int
+
+const (
+	// RoundUnnecessary rejects values with more decimals than the currency allows.
+	RoundUnnecessary RoundingMode = iota
+	// RoundDown rounds towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, ties away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest value, ties to the even neighbour (banker's rounding).
+	RoundHalfEven
+)
+
+var (
+	ErrUnknownCurrency = errors.New("not supported currency")
+	ErrInvalidDecimal  = errors.New("not valid decimal amount")
+	ErrRoundingNeeded  = errors.New("amount has more decimals than the currency allows")
+	ErrAmountOverflow  = errors.New("amount out of range")
+)
+
+var (
+	isValidDecimal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`).MatchString
+	maxAmount      = big.NewInt(1<<63 - 1)
+	minAmount      = big.NewInt(-1 << 63)
+)
+
+// defaultMinorUnits is the ISO 4217 exponent of most currencies.
+const defaultMinorUnits = 2
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "12.30" into minor units of the currency.
+func ParseMoney(value string, currency string, mode RoundingMode) (Money, error) {
+	c, ok := Currencies.Lookup(currency)
+	if !ok {
+		return Money{}, ErrUnknownCurrency
+	}
+	amount, err := ParseDecimal(value, c.MinorUnits, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// ParseDecimal parses a decimal string into an integer scaled by 10^minorUnits.
+func ParseDecimal(value string, minorUnits int32, mode RoundingMode) (int64, error) {
+	value = strings.TrimSpace(value)
+	if !isValidDecimal(value) {
+		return 0, ErrInvalidDecimal
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, ErrInvalidDecimal
+	}
+	r.Mul(r, scale(minorUnits))
+	return Round(r, mode)
+}
+
+// Round rounds a rational number to an int64 with the given mode.
+func Round(r *big.Rat, mode RoundingMode) (int64, error) {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		// |rem| * 2 compared with the denominator tells below, at or above half
+		half := new(big.Int).Abs(rem)
+		half.Lsh(half, 1)
+		cmp := half.Cmp(r.Denom())
+
+		away := false
+		switch mode {
+		case RoundUnnecessary:
+			return 0, ErrRoundingNeeded
+		case RoundDown:
+		case RoundUp:
+			away = true
+		case RoundHalfUp:
+			away = cmp >= 0
+		case RoundHalfEven:
+			away = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+		default:
+			return 0, fmt.Errorf("unknown rounding mode %d", mode)
+		}
+		if away {
+			quo.Add(quo, big.NewInt(int64(r.Sign())))
+		}
+	}
+	if quo.Cmp(maxAmount) > 0 || quo.Cmp(minAmount) < 0 {
+		return 0, ErrAmountOverflow
+	}
+	return quo.Int64(), nil
+}
+
+// FormatDecimal formats an amount in minor units as a decimal string, e.g. 1230 with 2 minor units is "12.30".
+func FormatDecimal(amount int64, minorUnits int32) string {
+	if minorUnits <= 0 {
+		return fmt.Sprintf("%d", amount)
+	}
+	sign := ""
+	abs := new(big.Int).SetInt64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+	digits := fmt.Sprintf("%0*s", minorUnits+1, abs.String())
+	cut := len(digits) - int(minorUnits)
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// Decimal formats the amount with the exponent of its currency.
+func (m Money) Decimal() string {
+	minorUnits := int32(defaultMinorUnits)
+	if c, ok := Currencies.Lookup(m.Currency); ok {
+		minorUnits = c.MinorUnits
+	}
+	return FormatDecimal(m.Amount, minorUnits)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		minorUnits int32
+		mode       RoundingMode
+		amount     int64
+		err        error
+	}{
+		{name: "Whole", value: "12", minorUnits: 2, amount: 1200},
+		{name: "Exact", value: "12.30", minorUnits: 2, amount: 1230},
+		{name: "ShortFraction", value: "12.3", minorUnits: 2, amount: 1230},
+		{name: "NoMinorUnits", value: "500", minorUnits: 0, amount: 500},
+		{name: "ThreeMinorUnits", value: "1.005", minorUnits: 3, amount: 1005},
+		{name: "Negative", value: "-0.05", minorUnits: 2, amount: -5},
+		{name: "RoundingNeeded", value: "12.345", minorUnits: 2, err: ErrRoundingNeeded},
+		{name: "RoundDown", value: "12.349", minorUnits: 2, mode: RoundDown, amount: 1234},
+		{name: "RoundUp", value: "12.341", minorUnits: 2, mode: RoundUp, amount: 1235},
+		{name: "RoundUpNegative", value: "-12.341", minorUnits: 2, mode: RoundUp, amount: -1235},
+		{name: "HalfUp", value: "12.345", minorUnits: 2, mode: RoundHalfUp, amount: 1235},
+		{name: "HalfUpNegative", value: "-12.345", minorUnits: 2, mode: RoundHalfUp, amount: -1235},
+		{name: "HalfEvenDown", value: "12.345", minorUnits: 2, mode: RoundHalfEven, amount: 1234},
+		{name: "HalfEvenUp", value: "12.355", minorUnits: 2, mode: RoundHalfEven, amount: 1236},
+		{name: "HalfEvenAbove", value: "12.3451", minorUnits: 2, mode: RoundHalfEven, amount: 1235},
+		{name: "Exponent", value: "1e3", minorUnits: 2, err: ErrInvalidDecimal},
+		{name: "Fraction", value: "1/3", minorUnits: 2, err: ErrInvalidDecimal},
+		{name: "TrailingDot", value: "12.", minorUnits: 2, err: ErrInvalidDecimal},
+		{name: "Empty", value: "", minorUnits: 2, err: ErrInvalidDecimal},
+		{name: "Overflow", value: "92233720368547758.08", minorUnits: 2, err: ErrAmountOverflow},
+		{name: "MaxAmount", value: "92233720368547758.07", minorUnits: 2, amount: 1<<63 - 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := ParseDecimal(tc.value, tc.minorUnits, tc.mode)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.amount, amount)
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	_, err := Round(big.NewRat(1, 2), RoundingMode(-1))
+	require.Error(t, err)
+
+	amount, err := Round(big.NewRat(5, 2), RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), amount)
+}
+
+func TestFormatDecimal(t *testing.T) {
+	require.Equal(t, "12.30", FormatDecimal(1230, 2))
+	require.Equal(t, "0.05", FormatDecimal(5, 2))
+	require.Equal(t, "-0.05", FormatDecimal(-5, 2))
+	require.Equal(t, "0.000", FormatDecimal(0, 3))
+	require.Equal(t, "500", FormatDecimal(500, 0))
+	require.Equal(t, "-92233720368547758.08", FormatDecimal(-1<<63, 2))
+}
+
+func TestMoney(t *testing.T) {
+	saved := Currencies
+	defer func() { Currencies = saved }()
+	Currencies = NewCurrencyRegistry(
+		Currency{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true},
+		Currency{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true},
+	)
+
+	m, err := ParseMoney("12.3", USD, RoundUnnecessary)
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(1230, USD), m)
+	require.Equal(t, "12.30 USD", m.String())
+
+	_, err = ParseMoney("12.5", "JPY", RoundUnnecessary)
+	require.ErrorIs(t, err, ErrRoundingNeeded)
+
+	m, err = ParseMoney("12.5", "JPY", RoundHalfEven)
+	require.NoError(t, err)
+	require.Equal(t, "12", m.Decimal())
+
+	_, err = ParseMoney("1", "XXX", RoundUnnecessary)
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
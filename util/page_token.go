@@ -0,0 +1,42 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageToken is the (created_at, id) position of the last row of a page, the next page starts
+// after it. Clients only pass it back as the opaque string of Encode.
+type PageToken struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// Encode packs the token into a URL safe string. Postgres keeps microseconds, so that is the
+// precision of CreatedAt.
+func (t PageToken) Encode() string {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, uint64(t.CreatedAt.UnixMicro()))
+	binary.BigEndian.PutUint64(b[8:], uint64(t.ID))
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePageToken parses a token returned by Encode.
+func DecodePageToken(s string) (PageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != 16 {
+		return PageToken{}, ErrInvalidPageToken
+	}
+	t := PageToken{
+		CreatedAt: time.UnixMicro(int64(binary.BigEndian.Uint64(b))),
+		ID:        int64(binary.BigEndian.Uint64(b[8:])),
+	}
+	if t.ID <= 0 {
+		return PageToken{}, ErrInvalidPageToken
+	}
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	token := PageToken{
+		CreatedAt: time.Now().Truncate(time.Microsecond),
+		ID:        RandomInt(1, 1000),
+	}
+
+	decoded, err := DecodePageToken(token.Encode())
+	require.NoError(t, err)
+	require.True(t, token.CreatedAt.Equal(decoded.CreatedAt))
+	require.Equal(t, token.ID, decoded.ID)
+
+	for _, s := range []string{"", "not a token", token.Encode()[1:], PageToken{CreatedAt: time.Now()}.Encode()} {
+		_, err := DecodePageToken(s)
+		require.ErrorIs(t, err, ErrInvalidPageToken, s)
+	}
+}
@@ -0,0 +1,9 @@
+package util
+
+const (
+	DepositorRole = "depositor"
+	BankerRole    = "banker"
+	TellerRole    = "teller"
+	AdminRole     = "admin"
+	AuditorRole   = "auditor"
+)
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ParseSchedule parses a standing order schedule. It accepts 5 field cron
+// expressions such as "0 9 1 * *", descriptors such as "@monthly" and
+// intervals such as "@every 168h".
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	return cron.ParseStandard(spec)
+}
+
+// FirstRun returns the first run at or after start. Interval schedules
+// run right at start, cron schedules at their first match.
+func FirstRun(schedule cron.Schedule, start time.Time) time.Time {
+	if _, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return start
+	}
+	return schedule.Next(start.Add(-time.Second))
+}
+
+// NextRun returns the run that follows the one scheduled at last. When the
+// scheduler was down for several periods the missed runs are skipped, so a
+// late order is executed once and then continues from now.
+func NextRun(schedule cron.Schedule, last time.Time, now time.Time) time.Time {
+	next := schedule.Next(last)
+	if !next.After(now) {
+		return schedule.Next(now)
+	}
+	return next
+}
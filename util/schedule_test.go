@@ -0,0 +1,48 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, spec := range []string{"0 9 1 * *", "@monthly", "@every 168h"} {
+		_, err := ParseSchedule(spec)
+		require.NoError(t, err, spec)
+	}
+	for _, spec := range []string{"", "every month", "0 9 1 *", "@every 1x"} {
+		_, err := ParseSchedule(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	schedule, err := ParseSchedule("0 9 1 * *")
+	require.NoError(t, err)
+
+	last := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	// on time, the next run is a month later
+	next := NextRun(schedule, last, last.Add(time.Minute))
+	require.Equal(t, time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC), next)
+
+	// three months late, the missed runs are skipped
+	now := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+	next = NextRun(schedule, last, now)
+	require.Equal(t, time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestFirstRun(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	monthly, err := ParseSchedule("0 9 1 * *")
+	require.NoError(t, err)
+	require.Equal(t, start, FirstRun(monthly, start))
+	require.Equal(t, start.AddDate(0, 1, 0), FirstRun(monthly, start.Add(time.Minute)))
+
+	weekly, err := ParseSchedule("@every 168h")
+	require.NoError(t, err)
+	require.Equal(t, start, FirstRun(weekly, start))
+}
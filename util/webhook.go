@@ -0,0 +1,87 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Headers of a webhook request. The signature header holds one v1= entry per valid secret,
+// there are two while a rotated secret is still valid.
+const (
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// NewWebhookSecret returns a random secret for signing webhooks.
+func NewWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// SignWebhook computes the HMAC-SHA256 of "timestamp.body" with secret. Receivers recompute it
+// and reject old timestamps to prevent replays.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSignatureHeaderValue signs body with every secret for the signature header.
+func WebhookSignatureHeaderValue(secrets []string, timestamp int64, body []byte) string {
+	parts := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		parts = append(parts, "v1="+SignWebhook(secret, timestamp, body))
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifyWebhookSignature reports whether header holds a valid signature of body by secret.
+func VerifyWebhookSignature(secret string, header string, timestamp int64, body []byte) bool {
+	expected := SignWebhook(secret, timestamp, body)
+	for _, part := range strings.Split(header, ",") {
+		sig, ok := strings.CutPrefix(strings.TrimSpace(part), "v1=")
+		if ok && hmac.Equal([]byte(sig), []byte(expected)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublicWebhookIP reports whether a webhook may be delivered to ip. Loopback, private,
+// link-local, multicast and unspecified addresses are internal to the bank's network.
+func IsPublicWebhookIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// WebhookDialControl is a net.Dialer Control hook that refuses connections to non public
+// addresses. It runs after name resolution, so a host that resolves to an internal address
+// is refused even when it resolved to a public one at validation time.
+func WebhookDialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicWebhookIP(ip) {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	secret, err := NewWebhookSecret()
+	require.NoError(t, err)
+	oldSecret, err := NewWebhookSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, oldSecret)
+
+	body := []byte(`{"type":"transfer.received"}`)
+	timestamp := time.Now().Unix()
+
+	header := WebhookSignatureHeaderValue([]string{secret}, timestamp, body)
+	require.True(t, VerifyWebhookSignature(secret, header, timestamp, body))
+	require.False(t, VerifyWebhookSignature(oldSecret, header, timestamp, body))
+	require.False(t, VerifyWebhookSignature(secret, header, timestamp+1, body))
+	require.False(t, VerifyWebhookSignature(secret, header, timestamp, []byte(`{}`)))
+
+	// during a rotation both secrets verify
+	header = WebhookSignatureHeaderValue([]string{secret, oldSecret}, timestamp, body)
+	require.True(t, VerifyWebhookSignature(secret, header, timestamp, body))
+	require.True(t, VerifyWebhookSignature(oldSecret, header, timestamp, body))
+}
+
+func TestIsPublicWebhookIP(t *testing.T) {
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1::1"} {
+		require.True(t, IsPublicWebhookIP(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "fd00::1",
+		"169.254.169.254", "fe80::1", "0.0.0.0", "::", "::ffff:127.0.0.1", "224.0.0.1",
+	} {
+		require.False(t, IsPublicWebhookIP(net.ParseIP(addr)), addr)
+	}
+
+	require.NoError(t, WebhookDialControl("tcp", "93.184.216.34:443", nil))
+	require.Error(t, WebhookDialControl("tcp", "169.254.169.254:80", nil))
+	require.Error(t, WebhookDialControl("tcp6", "[::1]:443", nil))
+}
@@ -2,13 +2,20 @@ package val
 
 import (
 	"fmt"
+	"net"
 	"net/mail"
+	"net/url"
 	"regexp"
+	"strings"
+
+	"github.com/anil1226/go-simplebank-grpc/util"
 )
 
 var (
-	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
-	isValidFullname = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidUsername     = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+	isValidFullname     = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	isValidCurrencyCode = regexp.MustCompile(`^[A-Z]{3}$`).MatchString
+	isValidTier         = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 )
 
 func ValidateString(value string, min int, max int) error {
@@ -53,3 +60,190 @@ func ValidateFullname(value string) error {
 	}
 	return nil
 }
+
+func ValidateCurrencyCode(value string) error {
+	if !isValidCurrencyCode(value) {
+		return fmt.Errorf("not valid ISO 4217 currency code")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if err := ValidateCurrencyCode(value); err != nil {
+		return err
+	}
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("not supported currency")
+	}
+	return nil
+}
+
+func ValidateMinorUnits(value int32) error {
+	if value < 0 || value > 4 {
+		return fmt.Errorf("not valid minor units")
+	}
+	return nil
+}
+
+func ValidateID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive id")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive amount")
+	}
+	return nil
+}
+
+func ValidateLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+func ValidateSpreadBps(value int32) error {
+	if value < 0 || value >= 10000 {
+		return fmt.Errorf("not valid spread")
+	}
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("must be at least 1")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("must be between 5 and 10")
+	}
+	return nil
+}
+
+func ValidateSchedule(value string) error {
+	if _, err := util.ParseSchedule(value); err != nil {
+		return fmt.Errorf("not valid schedule: %w", err)
+	}
+	return nil
+}
+
+func ValidateReason(value string) error {
+	if err := ValidateString(value, 3, 200); err != nil {
+		return fmt.Errorf("must be between 3 and 200 characters")
+	}
+	return nil
+}
+
+func ValidateInterestBps(value int32) error {
+	if value < 0 || value > 10000 {
+		return fmt.Errorf("must be between 0 and 10000")
+	}
+	return nil
+}
+
+func ValidateTier(value string) error {
+	if err := ValidateString(value, 3, 30); err != nil {
+		return fmt.Errorf("must be between 3 and 30 characters")
+	}
+	if !isValidTier(value) {
+		return fmt.Errorf("must contain only lowercase letters, digits or underscore")
+	}
+	return nil
+}
+
+func ValidateCount(value int32) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive number")
+	}
+	return nil
+}
+
+func ValidateProductName(value string) error {
+	if err := ValidateString(value, 3, 50); err != nil {
+		return fmt.Errorf("must be between 3 and 50 characters")
+	}
+	return nil
+}
+
+func ValidateDayCount(value string) error {
+	if !util.IsSupportedDayCount(value) {
+		return fmt.Errorf("must be one of act/365, act/360, act/act or 30/360")
+	}
+	return nil
+}
+
+func ValidateAccountType(value string) error {
+	switch value {
+	case "checking", "savings", "business":
+		return nil
+	}
+	return fmt.Errorf("must be one of checking, savings or business")
+}
+
+func ValidateFeeRateBps(value int32) error {
+	if value <= 0 || value > 10000 {
+		return fmt.Errorf("must be between 1 and 10000")
+	}
+	return nil
+}
+
+func ValidateOffset(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+func ValidateBatchSize(value int32) error {
+	if value < 1 || value > 1000 {
+		return fmt.Errorf("must be between 1 and 1000")
+	}
+	return nil
+}
+
+func ValidateWebhookURL(value string) error {
+	if err := ValidateString(value, 10, 2048); err != nil {
+		return fmt.Errorf("must be between 10 and 2048 characters")
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("is not a valid http or https url")
+	}
+	// hosts that resolve to internal addresses are refused again when the webhook is posted
+	host := u.Hostname()
+	if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !util.IsPublicWebhookIP(ip)) {
+		return fmt.Errorf("must not point to a loopback, private or link-local address")
+	}
+	return nil
+}
+
+func ValidateWebhookEventType(value string) error {
+	switch value {
+	case "transfer.received", "transfer.sent", "account.created", "user.updated":
+		return nil
+	}
+	return fmt.Errorf("must be one of transfer.received, transfer.sent, account.created or user.updated")
+}
+
+func ValidateBatchMode(value string) error {
+	switch value {
+	case "atomic", "best_effort":
+		return nil
+	}
+	return fmt.Errorf("must be one of atomic or best_effort")
+}
+
+func ValidateDirection(value string) error {
+	switch value {
+	case "incoming", "outgoing":
+		return nil
+	}
+	return fmt.Errorf("must be one of incoming or outgoing")
+}
@@ -3,14 +3,16 @@ package worker
 import (
 	"context"
 
+	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/hibiken/asynq"
 )
 
+// TaskDistributor enqueues the tasks of the outbox. Tasks are written to the outbox with
+// store.EnqueueTask and published by the OutboxRelay, never enqueued directly.
 type TaskDistributor interface {
-	DistributeTaskSendVerifyEmail(
+	DistributeOutboxMessage(
 		ctx context.Context,
-		payload *PayLoadSendVerifyEmail,
-		opts ...asynq.Option,
+		msg store.OutboxMessage,
 	) error
 }
 
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/event"
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventBatchSize    = 100
+	eventPollInterval = time.Second
+)
+
+// EventPublisher hands the domain events emitted by the store transactions to the sink and
+// queues the webhooks they trigger.
+type EventPublisher struct {
+	store store.Store
+	sink  event.Sink
+}
+
+func NewEventPublisher(store store.Store, sink event.Sink) *EventPublisher {
+	return &EventPublisher{
+		store: store,
+		sink:  sink,
+	}
+}
+
+// Run publishes events until ctx is done.
+func (p *EventPublisher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// keep going while full batches come back
+		for {
+			n, err := p.PublishBatch(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("cannot publish domain events")
+				break
+			}
+			if n < eventBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// PublishBatch publishes the next pending events and returns how many there were. The sink is
+// called outside of any transaction. When marking the events fails they are published again
+// with the same offsets, which the sink skips.
+func (p *EventPublisher) PublishBatch(ctx context.Context) (int, error) {
+	events, err := p.store.NextDomainEventsTx(ctx, eventBatchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	if err := p.sink.Publish(ctx, events); err != nil {
+		return 0, err
+	}
+	err = p.store.MarkDomainEventsPublishedTx(ctx, events, func(q *store.Queries, events []*pb.DomainEvent) error {
+		return queueWebhookDeliveries(ctx, q, events)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
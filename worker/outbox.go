@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outboxBatchSize       = 100
+	outboxPollInterval    = time.Second
+	outboxLease           = time.Minute
+	outboxMaxBackoff      = 10 * time.Minute
+	outboxRetention       = 24 * time.Hour
+	outboxCleanupInterval = time.Hour
+)
+
+func (rtd *RedisTaskDistributor) DistributeOutboxMessage(
+	ctx context.Context,
+	msg store.OutboxMessage,
+) error {
+	opts := []asynq.Option{
+		asynq.Queue(msg.Queue),
+		asynq.MaxRetry(int(msg.MaxRetry)),
+	}
+	if msg.TaskID != "" {
+		opts = append(opts, asynq.TaskID(msg.TaskID))
+	}
+
+	task := asynq.NewTask(msg.TaskType, msg.Payload, opts...)
+	taskInfo, err := rtd.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return err
+	}
+	log.Info().
+		Str("type", taskInfo.Type).
+		Bytes("payload", task.Payload()).
+		Str("queue", taskInfo.Queue).
+		Int("max_retry", taskInfo.MaxRetry).
+		Int64("outbox_id", msg.ID).
+		Msg("enqueue task")
+	return nil
+}
+
+// OutboxRelay publishes the pending outbox messages to the distributor. Messages are published
+// at least once: a relay that stops between enqueueing a task and marking its message gets the
+// message published again after the lease, so tasks must tolerate running twice.
+type OutboxRelay struct {
+	store       store.Store
+	distributor TaskDistributor
+}
+
+func NewOutboxRelay(store store.Store, distributor TaskDistributor) *OutboxRelay {
+	return &OutboxRelay{
+		store:       store,
+		distributor: distributor,
+	}
+}
+
+// Run relays messages until ctx is done. Several relays can run side by side, each message is
+// leased to one of them at a time.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	lastCleanup := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		// keep going while full batches come back
+		for {
+			n, err := r.RelayBatch(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("cannot relay outbox messages")
+				break
+			}
+			if n < outboxBatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			err := r.store.DeletePublishedOutboxMessages(ctx, time.Now().Add(-outboxRetention))
+			if err != nil {
+				log.Error().Err(err).Msg("cannot delete published outbox messages")
+			}
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+// RelayBatch publishes one batch of due messages and returns how many were claimed. Messages
+// that cannot be published are retried with an exponential backoff.
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	msgs, err := r.store.ClaimOutboxMessages(ctx, store.ClaimOutboxMessagesParams{
+		LeasedUntil: time.Now().Add(outboxLease),
+		Limit:       outboxBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range msgs {
+		err := r.distributor.DistributeOutboxMessage(ctx, msg)
+		// a conflict means the task with this id is still queued
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			log.Error().Err(err).
+				Int64("outbox_id", msg.ID).
+				Str("type", msg.TaskType).
+				Int32("attempts", msg.Attempts+1).
+				Msg("cannot publish outbox message")
+			err = r.store.MarkOutboxMessageFailed(ctx, store.MarkOutboxMessageFailedParams{
+				ID:            msg.ID,
+				LastError:     err.Error(),
+				NextAttemptAt: time.Now().Add(outboxBackoff(msg.Attempts)),
+			})
+			if err != nil {
+				return len(msgs), err
+			}
+			continue
+		}
+		if err := r.store.MarkOutboxMessagePublished(ctx, msg.ID); err != nil {
+			return len(msgs), err
+		}
+	}
+	return len(msgs), nil
+}
+
+// outboxBackoff doubles the delay with every failed attempt, starting at one second.
+func outboxBackoff(attempts int32) time.Duration {
+	if attempts >= 10 {
+		return outboxMaxBackoff
+	}
+	return min(time.Second<<attempts, outboxMaxBackoff)
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/hibiken/asynq"
@@ -18,11 +19,56 @@ type TaskProcessor interface {
 		ctx context.Context,
 		task *asynq.Task,
 	) error
+	ProcessTaskRunStandingOrders(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskExecuteStandingOrder(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskExpireHolds(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskChargeOverdraftInterest(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskAccrueInterest(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskCapitalizeInterest(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskReconcileLedger(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskDeliverWebhook(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskExecuteTransferBatch(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskSnapshotBalances(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
+	ProcessTaskPurgeIdempotencyKeys(
+		ctx context.Context,
+		task *asynq.Task,
+	) error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  store.Store
+	server     *asynq.Server
+	store      store.Store
+	httpClient *http.Client
 }
 
 func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store store.Store) TaskProcessor {
@@ -33,17 +79,30 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store store.Store) Tas
 				QueueCritical: 10,
 				QueueDefault:  5,
 			},
+			RetryDelayFunc: retryDelay,
 		},
 	)
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
+		server:     server,
+		store:      store,
+		httpClient: newWebhookClient(),
 	}
 }
 
 func (rtp *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TaskSendVerifyEmail, rtp.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskRunStandingOrders, rtp.ProcessTaskRunStandingOrders)
+	mux.HandleFunc(TaskExecuteStandingOrder, rtp.ProcessTaskExecuteStandingOrder)
+	mux.HandleFunc(TaskExpireHolds, rtp.ProcessTaskExpireHolds)
+	mux.HandleFunc(TaskChargeOverdraftInterest, rtp.ProcessTaskChargeOverdraftInterest)
+	mux.HandleFunc(TaskAccrueInterest, rtp.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskCapitalizeInterest, rtp.ProcessTaskCapitalizeInterest)
+	mux.HandleFunc(TaskReconcileLedger, rtp.ProcessTaskReconcileLedger)
+	mux.HandleFunc(TaskDeliverWebhook, rtp.ProcessTaskDeliverWebhook)
+	mux.HandleFunc(TaskExecuteTransferBatch, rtp.ProcessTaskExecuteTransferBatch)
+	mux.HandleFunc(TaskSnapshotBalances, rtp.ProcessTaskSnapshotBalances)
+	mux.HandleFunc(TaskPurgeIdempotencyKeys, rtp.ProcessTaskPurgeIdempotencyKeys)
 
 	return rtp.server.Start(mux)
 }
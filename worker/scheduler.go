@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"github.com/hibiken/asynq"
+)
+
+// NewScheduler enqueues the periodic tasks. Run a single scheduler per deployment,
+// the tasks it enqueues are processed by every task processor.
+func NewScheduler(redisOpt asynq.RedisClientOpt) (*asynq.Scheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+
+	_, err := scheduler.Register("@every 1m", asynq.NewTask(TaskRunStandingOrders, nil), asynq.Queue(QueueCritical))
+	if err != nil {
+		return nil, err
+	}
+	_, err = scheduler.Register("@every 1m", asynq.NewTask(TaskExpireHolds, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	// shortly after midnight UTC, the interest is charged for the day before
+	_, err = scheduler.Register("5 0 * * *", asynq.NewTask(TaskChargeOverdraftInterest, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	// the accrual of the last day of the month runs before the month is capitalized
+	_, err = scheduler.Register("15 0 * * *", asynq.NewTask(TaskAccrueInterest, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	_, err = scheduler.Register("45 0 1 * *", asynq.NewTask(TaskCapitalizeInterest, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	// late enough for the transactions open at midnight to have committed
+	_, err = scheduler.Register("30 0 * * *", asynq.NewTask(TaskSnapshotBalances, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	_, err = scheduler.Register("@every 1h", asynq.NewTask(TaskPurgeIdempotencyKeys, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	// after the nightly postings are done
+	_, err = scheduler.Register("0 2 * * *", asynq.NewTask(TaskReconcileLedger, nil), asynq.Queue(QueueDefault))
+	if err != nil {
+		return nil, err
+	}
+	return scheduler, nil
+}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSnapshotBalances = "task:snapshot_balances"
+
+// ProcessTaskSnapshotBalances is triggered by the scheduler once a day and snapshots the
+// balance of every account at the midnight UTC that ended the day before.
+func (rtp *RedisTaskProcessor) ProcessTaskSnapshotBalances(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	now := time.Now().UTC()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	taken, err := rtp.store.SnapshotBalances(ctx, asOf)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot balances: %w", err)
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Time("as_of", asOf).
+		Int64("snapshots", taken).
+		Msg("process task")
+	return nil
+}
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/pb"
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskDeliverWebhook = "task:deliver_webhook"
+
+const (
+	webhookMaxRetry     = 8
+	webhookTimeout      = 10 * time.Second
+	webhookFirstBackoff = 30 * time.Second
+	webhookMaxBackoff   = 6 * time.Hour
+)
+
+type PayloadDeliverWebhook struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+// DeliverWebhookTask is the outbox task that posts a delivery to its endpoint.
+func DeliverWebhookTask(deliveryID int64) store.OutboxTask {
+	return store.OutboxTask{
+		Type:     TaskDeliverWebhook,
+		Payload:  &PayloadDeliverWebhook{DeliveryID: deliveryID},
+		Queue:    QueueDefault,
+		MaxRetry: webhookMaxRetry,
+	}
+}
+
+// queueWebhookDeliveries creates the deliveries of the published events and enqueues them.
+func queueWebhookDeliveries(ctx context.Context, q *store.Queries, events []*pb.DomainEvent) error {
+	for _, e := range events {
+		deliveries, err := q.CreateWebhookDeliveries(ctx, e)
+		if err != nil {
+			return fmt.Errorf("cannot create webhook deliveries for event %d: %w", e.Offset, err)
+		}
+		for _, delivery := range deliveries {
+			if err := q.EnqueueTask(ctx, DeliverWebhookTask(delivery.ID)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// webhookRetryDelay doubles the wait after every failed delivery, from 30 seconds up to 6 hours.
+func webhookRetryDelay(n int) time.Duration {
+	if n >= 20 {
+		return webhookMaxBackoff
+	}
+	return min(webhookFirstBackoff<<n, webhookMaxBackoff)
+}
+
+// retryDelay keeps the default delays of asynq for every other task.
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() == TaskDeliverWebhook {
+		return webhookRetryDelay(n)
+	}
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
+
+func (rtp *RedisTaskProcessor) ProcessTaskDeliverWebhook(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadDeliverWebhook
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	delivery, err := rtp.store.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return fmt.Errorf("webhook delivery does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if delivery.Status == store.WebhookSucceeded {
+		return nil
+	}
+	endpoint, err := rtp.store.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	code, sendErr := postWebhook(ctx, rtp.httpClient, endpoint, delivery.Payload)
+	arg := store.RecordWebhookAttemptParams{
+		ID:           delivery.ID,
+		Status:       store.WebhookSucceeded,
+		ResponseCode: int32(code),
+	}
+	if sendErr != nil {
+		arg.Status = store.WebhookPending
+		arg.LastError = sendErr.Error()
+		if retried, _ := asynq.GetRetryCount(ctx); retried >= webhookMaxRetryOf(ctx) {
+			arg.Status = store.WebhookFailed
+		}
+	}
+	delivery, err = rtp.store.RecordWebhookAttempt(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int64("delivery_id", delivery.ID).
+		Str("status", delivery.Status).
+		Int32("response_code", delivery.ResponseCode).
+		Int32("attempts", delivery.Attempts).
+		Msg("process task")
+	if sendErr != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", sendErr)
+	}
+	return nil
+}
+
+// webhookMaxRetryOf returns how often the running task is retried, redelivered tasks included.
+func webhookMaxRetryOf(ctx context.Context) int {
+	if maxRetry, ok := asynq.GetMaxRetry(ctx); ok {
+		return maxRetry
+	}
+	return webhookMaxRetry
+}
+
+// newWebhookClient returns the client for posting webhooks. It only dials public addresses
+// and does not follow redirects, so an endpoint cannot steer requests into the bank's network.
+// A redirect response is returned as is and fails the delivery.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: util.WebhookDialControl,
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: webhookTimeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// postWebhook posts body to the endpoint, signed with its current secrets. Any status other
+// than 2xx is an error.
+func postWebhook(ctx context.Context, client *http.Client, endpoint store.WebhookEndpoint, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(util.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(util.WebhookSignatureHeader, util.WebhookSignatureHeaderValue(store.WebhookSecrets(endpoint, time.Now()), timestamp, body))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10)) //nolint:errcheck
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("endpoint responded %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/anil1226/go-simplebank-grpc/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostWebhook(t *testing.T) {
+	body := []byte(`{"id":"1","type":"transfer.received"}`)
+	endpoint := store.WebhookEndpoint{
+		Secret:                  "whsec_new",
+		PreviousSecret:          "whsec_old",
+		PreviousSecretExpiresAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	var received [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, got)
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(util.WebhookTimestampHeader), 10, 64)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+		signature := r.Header.Get(util.WebhookSignatureHeader)
+		// receivers still on the old secret keep accepting deliveries during the overlap
+		require.True(t, util.VerifyWebhookSignature("whsec_new", signature, timestamp, got))
+		require.True(t, util.VerifyWebhookSignature("whsec_old", signature, timestamp, got))
+
+		if len(received) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	endpoint.Url = receiver.URL
+
+	code, err := postWebhook(context.Background(), receiver.Client(), endpoint, body)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+
+	code, err = postWebhook(context.Background(), receiver.Client(), endpoint, body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, [][]byte{body, body}, received)
+}
+
+func TestWebhookClient(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer receiver.Close()
+	endpoint := store.WebhookEndpoint{Url: receiver.URL, Secret: "whsec_new"}
+
+	// the receiver listens on loopback, which the webhook client refuses to dial
+	client := newWebhookClient()
+	_, err := postWebhook(context.Background(), client, endpoint, []byte(`{}`))
+	require.ErrorContains(t, err, "is not public")
+	require.False(t, called)
+
+	// redirects are not followed
+	client.Transport = receiver.Client().Transport
+	code, err := postWebhook(context.Background(), client, endpoint, []byte(`{}`))
+	require.Error(t, err)
+	require.Equal(t, http.StatusFound, code)
+	require.True(t, called)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	require.Equal(t, 30*time.Second, webhookRetryDelay(0))
+	require.Equal(t, time.Minute, webhookRetryDelay(1))
+	require.Equal(t, 8*time.Minute, webhookRetryDelay(4))
+	require.Equal(t, webhookMaxBackoff, webhookRetryDelay(10))
+	require.Equal(t, webhookMaxBackoff, webhookRetryDelay(64))
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpireHolds = "task:expire_holds"
+
+const expireHoldsBatchSize = 100
+
+// ProcessTaskExpireHolds is triggered by the scheduler and releases the holds past their expiry time.
+func (rtp *RedisTaskProcessor) ProcessTaskExpireHolds(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	holds, err := rtp.store.ListExpiredHolds(ctx, store.ListExpiredHoldsParams{
+		ExpiresAt: time.Now(),
+		Limit:     expireHoldsBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list expired holds: %w", err)
+	}
+
+	for _, hold := range holds {
+		_, err := rtp.store.ExpireHoldTx(ctx, hold.ID)
+		// the hold was captured or voided in the meantime
+		if err != nil && !errors.Is(err, store.ErrHoldNotActive) {
+			return fmt.Errorf("failed to expire hold %d: %w", hold.ID, err)
+		}
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Int("count", len(holds)).
+		Msg("process task")
+	return nil
+}
@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskAccrueInterest     = "task:accrue_interest"
+	TaskCapitalizeInterest = "task:capitalize_interest"
+)
+
+const interestBatchSize = 100
+
+// ProcessTaskAccrueInterest is triggered by the scheduler once a day and accrues the interest
+// of the day that just ended on every account with an interest product. Accruing a day twice
+// is a no-op, so a failed run can simply be retried.
+func (rtp *RedisTaskProcessor) ProcessTaskAccrueInterest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	day := time.Now().UTC().AddDate(0, 0, -1)
+
+	var afterID int64
+	accrued := 0
+	for {
+		accounts, err := rtp.store.ListInterestBearingAccounts(ctx, store.ListInterestBearingAccountsParams{
+			AfterID: afterID,
+			Limit:   interestBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list interest bearing accounts: %w", err)
+		}
+
+		for _, acc := range accounts {
+			res, err := rtp.store.AccrueInterestTx(ctx, store.AccrueInterestTxParams{
+				AccountID: acc.ID,
+				Day:       day,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to accrue interest on account %d: %w", acc.ID, err)
+			}
+			if !res.Skipped {
+				accrued++
+			}
+			afterID = acc.ID
+		}
+		if len(accounts) < interestBatchSize {
+			break
+		}
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Str("day", day.Format(time.DateOnly)).
+		Int("accrued", accrued).
+		Msg("process task")
+	return nil
+}
+
+// ProcessTaskCapitalizeInterest is triggered by the scheduler on the first of the month and
+// posts the interest accrued during the month before. Accruals of accounts that couldn't be
+// capitalized are picked up again the next month.
+func (rtp *RedisTaskProcessor) ProcessTaskCapitalizeInterest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	now := time.Now().UTC()
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+
+	var afterID int64
+	capitalized := 0
+	for {
+		accountIDs, err := rtp.store.ListUncapitalizedInterestAccounts(ctx, store.ListUncapitalizedInterestAccountsParams{
+			Before:  period.AddDate(0, 1, 0),
+			AfterID: afterID,
+			Limit:   interestBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list accounts with accrued interest: %w", err)
+		}
+
+		for _, id := range accountIDs {
+			res, err := rtp.store.CapitalizeInterestTx(ctx, store.CapitalizeInterestTxParams{
+				AccountID: id,
+				Period:    period,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to capitalize interest on account %d: %w", id, err)
+			}
+			if !res.Skipped {
+				capitalized++
+			}
+			afterID = id
+		}
+		if len(accountIDs) < interestBatchSize {
+			break
+		}
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Str("period", period.Format("2006-01")).
+		Int("capitalized", capitalized).
+		Msg("process task")
+	return nil
+}
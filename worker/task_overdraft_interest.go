@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskChargeOverdraftInterest = "task:charge_overdraft_interest"
+
+const overdraftBatchSize = 100
+
+// ProcessTaskChargeOverdraftInterest is triggered by the scheduler once a day and charges the
+// interest of the day that just ended on every negative balance. Charging a day twice is a no-op.
+func (rtp *RedisTaskProcessor) ProcessTaskChargeOverdraftInterest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	day := time.Now().UTC().AddDate(0, 0, -1)
+
+	var afterID int64
+	charged := 0
+	for {
+		accounts, err := rtp.store.ListOverdrawnAccounts(ctx, store.ListOverdrawnAccountsParams{
+			AfterID: afterID,
+			Limit:   overdraftBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list overdrawn accounts: %w", err)
+		}
+
+		for _, acc := range accounts {
+			res, err := rtp.store.ChargeOverdraftInterestTx(ctx, store.ChargeOverdraftInterestTxParams{
+				AccountID: acc.ID,
+				Day:       day,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to charge overdraft interest on account %d: %w", acc.ID, err)
+			}
+			if !res.Skipped {
+				charged++
+			}
+			afterID = acc.ID
+		}
+		if len(accounts) < overdraftBatchSize {
+			break
+		}
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Str("day", day.Format(time.DateOnly)).
+		Int("charged", charged).
+		Msg("process task")
+	return nil
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskPurgeIdempotencyKeys = "task:purge_idempotency_keys"
+
+// ProcessTaskPurgeIdempotencyKeys is triggered by the scheduler and deletes the idempotency keys
+// past their expiry time.
+func (rtp *RedisTaskProcessor) ProcessTaskPurgeIdempotencyKeys(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	n, err := rtp.store.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Int64("count", n).
+		Msg("process task")
+	return nil
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskReconcileLedger = "task:reconcile_ledger"
+
+// ProcessTaskReconcileLedger is triggered by the scheduler once a day and checks the ledger
+// without repairing it, repairs are left to an operator running the reconcile command.
+func (rtp *RedisTaskProcessor) ProcessTaskReconcileLedger(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	res, err := ReconcileLedger(ctx, rtp.store, false)
+	if err != nil {
+		return err
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Int64("run_id", res.Run.ID).
+		Int64("findings", res.Run.Findings).
+		Msg("process task")
+	return nil
+}
+
+// ReconcileLedger runs a reconciliation and raises an alert for each finding.
+func ReconcileLedger(ctx context.Context, s store.Store, repair bool) (store.ReconcileLedgerResult, error) {
+	res, err := s.ReconcileLedger(ctx, store.ReconcileLedgerParams{Repair: repair})
+	if err != nil {
+		return res, fmt.Errorf("failed to reconcile ledger: %w", err)
+	}
+	for _, f := range res.Findings {
+		event := log.Error().
+			Str("alert", "ledger_mismatch").
+			Int64("run_id", f.RunID).
+			Str("kind", f.Kind).
+			Str("currency", f.Currency).
+			Int64("expected", f.Expected).
+			Int64("actual", f.Actual).
+			Bool("repaired", f.Repaired)
+		if f.AccountID.Valid {
+			event = event.Int64("account_id", f.AccountID.Int64)
+		}
+		if f.JournalID.Valid {
+			event = event.Int64("journal_id", f.JournalID.Int64)
+		}
+		event.Msg("ledger reconciliation finding")
+	}
+	return res, nil
+}
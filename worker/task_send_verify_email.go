@@ -2,10 +2,10 @@ package worker
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 
+	"github.com/anil1226/go-simplebank-grpc/store"
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 )
@@ -16,29 +16,6 @@ type PayLoadSendVerifyEmail struct {
 	Username string `json:"username"`
 }
 
-func (rtd *RedisTaskDistributor) DistributeTaskSendVerifyEmail(
-	ctx context.Context,
-	payload *PayLoadSendVerifyEmail,
-	opts ...asynq.Option,
-) error {
-	plByte, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-	task := asynq.NewTask(TaskSendVerifyEmail, plByte, opts...)
-	taskInfo, err := rtd.client.EnqueueContext(ctx, task)
-	if err != nil {
-		return err
-	}
-	log.Info().
-		Str("type", taskInfo.Type).
-		Bytes("payload", task.Payload()).
-		Str("queue", taskInfo.Queue).
-		Int("max_retry", taskInfo.MaxRetry).
-		Msg("enqueue task")
-	return nil
-}
-
 func (rtp *RedisTaskProcessor) ProcessTaskSendVerifyEmail(
 	ctx context.Context,
 	task *asynq.Task,
@@ -50,7 +27,7 @@ func (rtp *RedisTaskProcessor) ProcessTaskSendVerifyEmail(
 	}
 	user, err := rtp.store.GetUser(ctx, payload.Username)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if err == store.ErrRecordNotFound {
 			return fmt.Errorf("user does not exist: %w", asynq.SkipRetry)
 		}
 		return fmt.Errorf("failed to get user: %w", err)
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskRunStandingOrders    = "task:run_standing_orders"
+	TaskExecuteStandingOrder = "task:execute_standing_order"
+)
+
+const standingOrdersBatchSize = 100
+
+type PayloadExecuteStandingOrder struct {
+	ID          int64     `json:"id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// ProcessTaskRunStandingOrders is triggered by the scheduler and enqueues one task per due order.
+func (rtp *RedisTaskProcessor) ProcessTaskRunStandingOrders(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	orders, err := rtp.store.ListDueStandingOrders(ctx, store.ListDueStandingOrdersParams{
+		NextRunAt: sql.NullTime{Time: time.Now(), Valid: true},
+		Limit:     standingOrdersBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list due standing orders: %w", err)
+	}
+
+	for _, order := range orders {
+		scheduledAt := order.NextRunAt.Time
+		err := rtp.store.EnqueueTask(ctx, store.OutboxTask{
+			Type: TaskExecuteStandingOrder,
+			Payload: &PayloadExecuteStandingOrder{
+				ID:          order.ID,
+				ScheduledAt: scheduledAt,
+			},
+			Queue:    QueueCritical,
+			MaxRetry: 10,
+			// the task id makes enqueueing the same run twice a no-op while the first one is pending
+			TaskID: fmt.Sprintf("standing_order:%d:%d", order.ID, scheduledAt.Unix()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enqueue standing order %d: %w", order.ID, err)
+		}
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Int("count", len(orders)).
+		Msg("process task")
+	return nil
+}
+
+func (rtp *RedisTaskProcessor) ProcessTaskExecuteStandingOrder(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadExecuteStandingOrder
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	res, err := rtp.store.ExecuteStandingOrderTx(ctx, store.ExecuteStandingOrderTxParams{
+		ID:          payload.ID,
+		ScheduledAt: payload.ScheduledAt,
+	})
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return fmt.Errorf("standing order does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to execute standing order: %w", err)
+	}
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Bool("skipped", res.Skipped).
+		Str("status", res.Run.Status).
+		Msg("process task")
+	return nil
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/anil1226/go-simplebank-grpc/store"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExecuteTransferBatch = "task:execute_transfer_batch"
+
+type PayloadExecuteTransferBatch struct {
+	BatchID int64 `json:"batch_id"`
+}
+
+// ExecuteTransferBatchTask is the outbox task that books the transfers of a batch.
+func ExecuteTransferBatchTask(batchID int64) store.OutboxTask {
+	return store.OutboxTask{
+		Type:     TaskExecuteTransferBatch,
+		Payload:  &PayloadExecuteTransferBatch{BatchID: batchID},
+		Queue:    QueueCritical,
+		MaxRetry: 10,
+		TaskID:   fmt.Sprintf("transfer_batch:%d", batchID),
+	}
+}
+
+func (rtp *RedisTaskProcessor) ProcessTaskExecuteTransferBatch(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadExecuteTransferBatch
+	err := json.Unmarshal(task.Payload(), &payload)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	res, err := rtp.store.ExecuteTransferBatchTx(ctx, payload.BatchID)
+	if err != nil {
+		if err == store.ErrRecordNotFound {
+			return fmt.Errorf("transfer batch does not exist: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("failed to execute transfer batch: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Int64("batch_id", res.Batch.ID).
+		Str("status", res.Batch.Status).
+		Int32("succeeded", res.Batch.SucceededCount).
+		Int32("failed", res.Batch.FailedCount).
+		Bool("skipped", res.Skipped).
+		Msg("process task")
+	return nil
+}